@@ -7,18 +7,25 @@ import (
 	"github.com/noot/atomic-swap/common"
 	"github.com/noot/atomic-swap/common/rpcclient"
 	"github.com/noot/atomic-swap/rpc"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
 )
 
-// MakeOffer calls net_makeOffer.
-func (c *Client) MakeOffer(min, max, exchangeRate float64) (string, error) {
+// MakeOffer calls net_makeOffer. The token is the ERC-20 token to swap for XMR, or the zero address for
+// ether. If expiresIn is non-zero, the offer expires after that many seconds.
+func (c *Client) MakeOffer(provides common.ProvidesCoin, token ethcommon.Address, min, max common.Amount,
+	exchangeRate common.ExchangeRate, expiresIn uint64) (string, error) {
 	const (
 		method = "net_makeOffer"
 	)
 
 	req := &rpc.MakeOfferRequest{
+		Provides:      provides,
 		MinimumAmount: min,
 		MaximumAmount: max,
-		ExchangeRate:  common.ExchangeRate(exchangeRate),
+		ExchangeRate:  exchangeRate,
+		ExpiresIn:     expiresIn,
+		Token:         token,
 	}
 
 	params, err := json.Marshal(req)
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/rpcclient"
+	"github.com/noot/atomic-swap/common/types"
+	"github.com/noot/atomic-swap/rpc"
+)
+
+// GetOffers calls personal_getOffers.
+func (c *Client) GetOffers() ([]*types.Offer, error) {
+	const (
+		method = "personal_getOffers"
+	)
+
+	resp, err := rpcclient.PostRPC(c.endpoint, method, "{}")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, resp.Error)
+	}
+
+	var res *rpc.GetOffersResponse
+	if err = json.Unmarshal(resp.Result, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Offers, nil
+}
+
+// UpdateOffer calls net_updateOffer. If expiresIn is non-zero, the offer expires after that many seconds.
+func (c *Client) UpdateOffer(offerID string, min, max common.Amount, exchangeRate common.ExchangeRate,
+	expiresIn uint64) error {
+	const (
+		method = "net_updateOffer"
+	)
+
+	req := &rpc.UpdateOfferRequest{
+		OfferID: offerID,
+		MakeOfferRequest: rpc.MakeOfferRequest{
+			MinimumAmount: min,
+			MaximumAmount: max,
+			ExchangeRate:  exchangeRate,
+			ExpiresIn:     expiresIn,
+		},
+	}
+
+	params, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := rpcclient.PostRPC(c.endpoint, method, string(params))
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != nil {
+		return fmt.Errorf("failed to call %s: %w", method, resp.Error)
+	}
+
+	return nil
+}
+
+// CancelOffer calls net_cancelOffer.
+func (c *Client) CancelOffer(offerID string) error {
+	const (
+		method = "net_cancelOffer"
+	)
+
+	req := &rpc.CancelOfferRequest{
+		OfferID: offerID,
+	}
+
+	params, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := rpcclient.PostRPC(c.endpoint, method, string(params))
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != nil {
+		return fmt.Errorf("failed to call %s: %w", method, resp.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/noot/atomic-swap/common/rpcclient"
+	"github.com/noot/atomic-swap/rpc"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsEndpoint returns the daemon's websocket endpoint, given its HTTP endpoint.
+func (c *Client) wsEndpoint() string {
+	endpoint := strings.TrimSuffix(c.endpoint, "/")
+	switch {
+	case strings.HasPrefix(endpoint, "https://"):
+		endpoint = "wss://" + strings.TrimPrefix(endpoint, "https://")
+	case strings.HasPrefix(endpoint, "http://"):
+		endpoint = "ws://" + strings.TrimPrefix(endpoint, "http://")
+	}
+
+	return endpoint + "/ws"
+}
+
+// subscribe opens a websocket connection to the daemon and sends a subscription request.
+func (c *Client) subscribe(method string, req interface{}) (*websocket.Conn, error) {
+	params, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(c.wsEndpoint(), nil) //nolint:bodyclose
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to websocket: %w", err)
+	}
+
+	msg := []byte(`{"jsonrpc":"2.0","method":"` + method + `","params":` + string(params) + `,"id":0}`)
+	if err = conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// readResult reads the next response from the connection and decodes its result into v.
+func readResult(conn *websocket.Conn, method string, v interface{}) error {
+	var resp *rpcclient.ServerResponse
+	if err := conn.ReadJSON(&resp); err != nil {
+		return err
+	}
+
+	if resp.Error != nil {
+		return fmt.Errorf("failed to call %s: %w", method, resp.Error)
+	}
+
+	return json.Unmarshal(resp.Result, v)
+}
+
+// SubscribeSwapStatus calls swap_subscribeStatus. The returned channel receives the swap's current
+// status, followed by an event for each change, and is closed once the swap completes.
+func (c *Client) SubscribeSwapStatus(id uint64) (<-chan *rpc.SwapStatusEvent, error) {
+	const (
+		method = "swap_subscribeStatus"
+	)
+
+	conn, err := c.subscribe(method, &rpc.SubscribeSwapStatusRequest{
+		ID: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the first response is the current status, or an error if the swap doesn't exist
+	var first *rpc.SwapStatusEvent
+	if err = readResult(conn, method, &first); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	ch := make(chan *rpc.SwapStatusEvent)
+	go func() {
+		defer func() {
+			close(ch)
+			_ = conn.Close()
+		}()
+
+		ev := first
+		for {
+			ch <- ev
+			if ev.Status != "ongoing" {
+				return
+			}
+
+			ev = nil
+			if err := readResult(conn, method, &ev); err != nil {
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// SubscribeOffers calls net_subscribeOffers. The returned channel receives each offer
+// discovered on the network, until the connection is closed.
+func (c *Client) SubscribeOffers(req *rpc.SubscribeOffersRequest) (<-chan *rpc.OfferEvent, error) {
+	const (
+		method = "net_subscribeOffers"
+	)
+
+	if req == nil {
+		return nil, errors.New("request must not be nil")
+	}
+
+	conn, err := c.subscribe(method, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *rpc.OfferEvent)
+	go func() {
+		defer func() {
+			close(ch)
+			_ = conn.Close()
+		}()
+
+		for {
+			var ev *rpc.OfferEvent
+			if err := readResult(conn, method, &ev); err != nil {
+				return
+			}
+
+			ch <- ev
+		}
+	}()
+
+	return ch, nil
+}
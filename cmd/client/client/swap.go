@@ -31,13 +31,45 @@ func (c *Client) GetPastSwapIDs() ([]uint64, error) {
 	return res.IDs, nil
 }
 
+// GetOngoingSwapIDs calls swap_getOngoingIDs
+func (c *Client) GetOngoingSwapIDs() ([]uint64, error) {
+	const (
+		method = "swap_getOngoingIDs"
+	)
+
+	resp, err := rpcclient.PostRPC(c.endpoint, method, "{}")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, resp.Error)
+	}
+
+	var res *rpc.GetOngoingIDsResponse
+	if err = json.Unmarshal(resp.Result, &res); err != nil {
+		return nil, err
+	}
+
+	return res.IDs, nil
+}
+
 // GetOngoingSwap calls swap_getOngoing
-func (c *Client) GetOngoingSwap() (*rpc.GetOngoingResponse, error) {
+func (c *Client) GetOngoingSwap(id uint64) (*rpc.GetOngoingResponse, error) {
 	const (
 		method = "swap_getOngoing"
 	)
 
-	resp, err := rpcclient.PostRPC(c.endpoint, method, "{}")
+	req := &rpc.GetOngoingRequest{
+		ID: id,
+	}
+
+	params, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rpcclient.PostRPC(c.endpoint, method, string(params))
 	if err != nil {
 		return nil, err
 	}
@@ -85,3 +117,59 @@ func (c *Client) GetPastSwap(id uint64) (*rpc.GetPastResponse, error) {
 
 	return res, nil
 }
+
+// ConfirmSwap calls swap_confirm
+func (c *Client) ConfirmSwap(id uint64, accept bool) error {
+	const (
+		method = "swap_confirm"
+	)
+
+	req := &rpc.ConfirmRequest{
+		ID:     id,
+		Accept: accept,
+	}
+
+	params, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := rpcclient.PostRPC(c.endpoint, method, string(params))
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != nil {
+		return fmt.Errorf("failed to call %s: %w", method, resp.Error)
+	}
+
+	return nil
+}
+
+// SetSweepAddress calls swap_setSweepAddress
+func (c *Client) SetSweepAddress(id uint64, address string) error {
+	const (
+		method = "swap_setSweepAddress"
+	)
+
+	req := &rpc.SetSweepAddressRequest{
+		ID:      id,
+		Address: address,
+	}
+
+	params, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := rpcclient.PostRPC(c.endpoint, method, string(params))
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != nil {
+		return fmt.Errorf("failed to call %s: %w", method, resp.Error)
+	}
+
+	return nil
+}
@@ -4,12 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/noot/atomic-swap/common"
 	"github.com/noot/atomic-swap/common/rpcclient"
 	"github.com/noot/atomic-swap/rpc"
 )
 
 // TakeOffer calls net_takeOffer.
-func (c *Client) TakeOffer(maddr string, offerID string, providesAmount float64) (uint64, error) {
+func (c *Client) TakeOffer(maddr string, offerID string, providesAmount common.Amount) (uint64, error) {
 	const (
 		method = "net_takeOffer"
 	)
@@ -4,10 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/noot/atomic-swap/cmd/client/client"
 	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/rpc"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	logging "github.com/ipfs/go-log"
 	"github.com/urfave/cli"
 )
@@ -65,20 +68,44 @@ var (
 			{
 				Name:    "make",
 				Aliases: []string{"m"},
-				Usage:   "mke a swap offer; currently monero holders must be the makers",
+				Usage:   "make a swap offer, providing either XMR, or ETH or an ERC-20 token",
 				Action:  runMake,
-				Flags: []cli.Flag{
-					&cli.Float64Flag{
-						Name:  "min-amount",
-						Usage: "minimum amount to be swapped, in XMR",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "provides",
+						Usage: "coin to provide in the swap: one of [ETH, XMR, ERC20:<token address>]; default XMR",
 					},
-					&cli.Float64Flag{
-						Name:  "max-amount",
-						Usage: "maximum amount to be swapped, in XMR",
+					&cli.StringFlag{
+						Name:  "token",
+						Usage: "address of an ERC-20 token to receive instead of ETH, when providing XMR",
 					},
-					&cli.Float64Flag{
-						Name:  "exchange-rate",
-						Usage: "desired exchange rate of XMR:ETH, eg. --exchange-rate=0.1 means 10XMR = 1ETH",
+				}, append(offerTermsFlags, daemonAddrFlag)...),
+			},
+			{
+				Name:   "get-offers",
+				Usage:  "list the offers we've made which are still active",
+				Action: runGetOffers,
+				Flags:  []cli.Flag{daemonAddrFlag},
+			},
+			{
+				Name:   "update-offer",
+				Usage:  "replace the terms of one of our offers; it keeps its ID",
+				Action: runUpdateOffer,
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "offer-id",
+						Usage: "ID of the offer to update",
+					},
+				}, append(offerTermsFlags, daemonAddrFlag)...),
+			},
+			{
+				Name:   "cancel-offer",
+				Usage:  "cancel one of our offers, so that it can no longer be taken",
+				Action: runCancelOffer,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "offer-id",
+						Usage: "ID of the offer to cancel",
 					},
 					daemonAddrFlag,
 				},
@@ -86,7 +113,7 @@ var (
 			{
 				Name:    "take",
 				Aliases: []string{"t"},
-				Usage:   "initiate a swap by taking an offer; currently only eth holders can be the takers",
+				Usage:   "initiate a swap by taking an offer; we provide whichever coin the offer doesn't",
 				Action:  runTake,
 				Flags: []cli.Flag{
 					&cli.StringFlag{
@@ -97,9 +124,9 @@ var (
 						Name:  "offer-id",
 						Usage: "ID of the offer being taken",
 					},
-					&cli.Float64Flag{
+					&cli.StringFlag{
 						Name:  "provides-amount",
-						Usage: "amount of coin to send in the swap",
+						Usage: "amount of coin to send in the swap: ETH if the offer provides XMR, and XMR if it provides ETH",
 					},
 					daemonAddrFlag,
 				},
@@ -110,11 +137,23 @@ var (
 				Action: runGetPastSwapIDs,
 				Flags:  []cli.Flag{daemonAddrFlag},
 			},
+			{
+				Name:   "get-ongoing-swap-ids",
+				Usage:  "get ongoing swap IDs",
+				Action: runGetOngoingSwapIDs,
+				Flags:  []cli.Flag{daemonAddrFlag},
+			},
 			{
 				Name:   "get-ongoing-swap",
-				Usage:  "get information about ongoing swap, if there is one",
+				Usage:  "get information about an ongoing swap with the given ID",
 				Action: runGetOngoingSwap,
-				Flags:  []cli.Flag{daemonAddrFlag},
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:  "id",
+						Usage: "ID of swap to retrieve info for",
+					},
+					daemonAddrFlag,
+				},
 			},
 			{
 				Name:   "get-past-swap",
@@ -128,6 +167,50 @@ var (
 					daemonAddrFlag,
 				},
 			},
+			{
+				Name:   "subscribe-swap-status",
+				Usage:  "follow the progress of a swap with the given ID until it completes",
+				Action: runSubscribeSwapStatus,
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:  "id",
+						Usage: "ID of swap to follow",
+					},
+					daemonAddrFlag,
+				},
+			},
+			{
+				Name:   "confirm-swap",
+				Usage:  "accept or decline a swap which is awaiting confirmation",
+				Action: runConfirmSwap,
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:  "id",
+						Usage: "ID of swap to confirm",
+					},
+					&cli.BoolFlag{
+						Name:  "decline",
+						Usage: "decline the swap instead of accepting it",
+					},
+					daemonAddrFlag,
+				},
+			},
+			{
+				Name:   "set-sweep-address",
+				Usage:  "set the address the XMR received in an ongoing swap is swept to",
+				Action: runSetSweepAddress,
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:  "id",
+						Usage: "ID of swap",
+					},
+					&cli.StringFlag{
+						Name:  "address",
+						Usage: "monero address to sweep to; if empty, the XMR is left in the swap wallet",
+					},
+					daemonAddrFlag,
+				},
+			},
 		},
 		Flags: []cli.Flag{daemonAddrFlag},
 	}
@@ -136,6 +219,26 @@ var (
 		Name:  "daemon-addr",
 		Usage: "address of swap daemon; default http://localhost:5001",
 	}
+
+	// offerTermsFlags are the flags used by make and update-offer
+	offerTermsFlags = []cli.Flag{
+		&cli.StringFlag{
+			Name:  "min-amount",
+			Usage: "minimum amount to be swapped, in the coin the offer provides",
+		},
+		&cli.StringFlag{
+			Name:  "max-amount",
+			Usage: "maximum amount to be swapped, in the coin the offer provides",
+		},
+		&cli.StringFlag{
+			Name:  "exchange-rate",
+			Usage: "desired exchange rate of XMR:ETH, or XMR:token, eg. --exchange-rate=0.1 means 10XMR = 1ETH",
+		},
+		&cli.UintFlag{
+			Name:  "expires-in",
+			Usage: "number of seconds after which the offer expires; by default it doesn't expire",
+		},
+	}
 )
 
 func main() {
@@ -214,20 +317,48 @@ func runQuery(ctx *cli.Context) error {
 	return nil
 }
 
+// parseOfferTerms parses the flags in offerTermsFlags.
+func parseOfferTerms(ctx *cli.Context) (min, max common.Amount, exchangeRate common.ExchangeRate, err error) {
+	min, err = common.NewAmount(ctx.String("min-amount"))
+	if err != nil || min.IsZero() {
+		return min, max, exchangeRate, errors.New("must provide non-zero --min-amount")
+	}
+
+	max, err = common.NewAmount(ctx.String("max-amount"))
+	if err != nil || max.IsZero() {
+		return min, max, exchangeRate, errors.New("must provide non-zero --max-amount")
+	}
+
+	exchangeRate, err = common.NewExchangeRate(ctx.String("exchange-rate"))
+	if err != nil {
+		return min, max, exchangeRate, errors.New("must provide non-zero --exchange-rate")
+	}
+
+	return min, max, exchangeRate, nil
+}
+
 func runMake(ctx *cli.Context) error {
-	min := ctx.Float64("min-amount")
-	if min == 0 {
-		return errors.New("must provide non-zero --min-amount")
+	provides := common.ProvidesXMR
+	if ctx.String("provides") != "" {
+		var err error
+		provides, err = common.NewProvidesCoin(ctx.String("provides"))
+		if err != nil {
+			return err
+		}
 	}
 
-	max := ctx.Float64("max-amount")
-	if max == 0 {
-		return errors.New("must provide non-zero --max-amount")
+	var token ethcommon.Address
+	if ctx.String("token") != "" {
+		if !ethcommon.IsHexAddress(ctx.String("token")) {
+			return errors.New("token must be a hex-encoded address")
+		}
+
+		token = ethcommon.HexToAddress(ctx.String("token"))
 	}
 
-	exchangeRate := ctx.Float64("exchange-rate")
-	if exchangeRate == 0 {
-		return errors.New("must provide non-zero --exchange-rate")
+	min, max, exchangeRate, err := parseOfferTerms(ctx)
+	if err != nil {
+		return err
 	}
 
 	endpoint := ctx.String("daemon-addr")
@@ -236,7 +367,7 @@ func runMake(ctx *cli.Context) error {
 	}
 
 	c := client.NewClient(endpoint)
-	id, err := c.MakeOffer(min, max, exchangeRate)
+	id, err := c.MakeOffer(provides, token, min, max, exchangeRate, uint64(ctx.Uint("expires-in")))
 	if err != nil {
 		return err
 	}
@@ -245,6 +376,69 @@ func runMake(ctx *cli.Context) error {
 	return nil
 }
 
+func runGetOffers(ctx *cli.Context) error {
+	endpoint := ctx.String("daemon-addr")
+	if endpoint == "" {
+		endpoint = defaultSwapdAddress
+	}
+
+	c := client.NewClient(endpoint)
+	offers, err := c.GetOffers()
+	if err != nil {
+		return err
+	}
+
+	for _, o := range offers {
+		fmt.Printf("%v\n", o)
+	}
+	return nil
+}
+
+func runUpdateOffer(ctx *cli.Context) error {
+	offerID := ctx.String("offer-id")
+	if offerID == "" {
+		return errors.New("must provide --offer-id")
+	}
+
+	min, max, exchangeRate, err := parseOfferTerms(ctx)
+	if err != nil {
+		return err
+	}
+
+	endpoint := ctx.String("daemon-addr")
+	if endpoint == "" {
+		endpoint = defaultSwapdAddress
+	}
+
+	c := client.NewClient(endpoint)
+	if err = c.UpdateOffer(offerID, min, max, exchangeRate, uint64(ctx.Uint("expires-in"))); err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated offer with ID %s\n", offerID)
+	return nil
+}
+
+func runCancelOffer(ctx *cli.Context) error {
+	offerID := ctx.String("offer-id")
+	if offerID == "" {
+		return errors.New("must provide --offer-id")
+	}
+
+	endpoint := ctx.String("daemon-addr")
+	if endpoint == "" {
+		endpoint = defaultSwapdAddress
+	}
+
+	c := client.NewClient(endpoint)
+	if err := c.CancelOffer(offerID); err != nil {
+		return err
+	}
+
+	fmt.Printf("Cancelled offer with ID %s\n", offerID)
+	return nil
+}
+
 func runTake(ctx *cli.Context) error {
 	maddr := ctx.String("multiaddr")
 	if maddr == "" {
@@ -256,8 +450,8 @@ func runTake(ctx *cli.Context) error {
 		return errors.New("must provide --offer-id")
 	}
 
-	providesAmount := ctx.Float64("provides-amount")
-	if providesAmount == 0 {
+	providesAmount, err := common.NewAmount(ctx.String("provides-amount"))
+	if err != nil || providesAmount.IsZero() {
 		return errors.New("must provide --provides-amount")
 	}
 
@@ -292,26 +486,37 @@ func runGetPastSwapIDs(ctx *cli.Context) error {
 	return nil
 }
 
+func runGetOngoingSwapIDs(ctx *cli.Context) error {
+	endpoint := ctx.String("daemon-addr")
+	if endpoint == "" {
+		endpoint = defaultSwapdAddress
+	}
+
+	c := client.NewClient(endpoint)
+	ids, err := c.GetOngoingSwapIDs()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Ongoing swap IDs: %v\n", ids)
+	return nil
+}
+
 func runGetOngoingSwap(ctx *cli.Context) error {
+	id := ctx.Uint("id")
+
 	endpoint := ctx.String("daemon-addr")
 	if endpoint == "" {
 		endpoint = defaultSwapdAddress
 	}
 
 	c := client.NewClient(endpoint)
-	info, err := c.GetOngoingSwap()
+	info, err := c.GetOngoingSwap(uint64(id))
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("ID: %d\n Provided: %s\n ProvidedAmount: %v\n ReceivedAmount: %v\n ExchangeRate: %v\n Status: %s\n",
-		info.ID,
-		info.Provided,
-		info.ProvidedAmount,
-		info.ReceivedAmount,
-		info.ExchangeRate,
-		info.Status,
-	)
+	printSwapInfo(&info.SwapInfo)
 	return nil
 }
 
@@ -329,13 +534,123 @@ func runGetPastSwap(ctx *cli.Context) error {
 		return err
 	}
 
-	fmt.Printf("ID: %d\n Provided: %s\n ProvidedAmount: %v\n ReceivedAmount: %v\n ExchangeRate: %v\n Status: %s\n",
-		id,
+	printSwapInfo(&info.SwapInfo)
+	return nil
+}
+
+func printSwapInfo(info *rpc.SwapInfo) {
+	fmt.Printf("ID: %d\n Provided: %s\n ProvidedAmount: %v\n ReceivedAmount: %v\n ExchangeRate: %v\n Status: %s\n Stage: %s\n",
+		info.ID,
 		info.Provided,
 		info.ProvidedAmount,
 		info.ReceivedAmount,
 		info.ExchangeRate,
 		info.Status,
+		info.Stage,
 	)
+
+	if info.AwaitingConfirmation {
+		fmt.Println(" AwaitingConfirmation: true")
+	}
+
+	if info.Counterparty != "" {
+		fmt.Printf(" Counterparty: %s\n", info.Counterparty)
+	}
+
+	if info.ContractAddress != "" {
+		fmt.Printf(" ContractAddress: %s\n", info.ContractAddress)
+	}
+
+	if info.ContractSwapID != "" {
+		fmt.Printf(" ContractSwapID: %s\n", info.ContractSwapID)
+	}
+
+	if info.XMRLockAddress != "" {
+		fmt.Printf(" XMRLockAddress: %s\n", info.XMRLockAddress)
+	}
+
+	if info.XMRLockTxHash != "" {
+		fmt.Printf(" XMRLockTxHash: %s\n", info.XMRLockTxHash)
+	}
+
+	if info.Timeout0 != 0 && info.Timeout1 != 0 {
+		fmt.Printf(" Timeout0: %s\n Timeout1: %s\n",
+			time.Unix(info.Timeout0, 0).Format(time.RFC3339),
+			time.Unix(info.Timeout1, 0).Format(time.RFC3339),
+		)
+	}
+}
+
+func runSubscribeSwapStatus(ctx *cli.Context) error {
+	id := ctx.Uint("id")
+
+	endpoint := ctx.String("daemon-addr")
+	if endpoint == "" {
+		endpoint = defaultSwapdAddress
+	}
+
+	c := client.NewClient(endpoint)
+	events, err := c.SubscribeSwapStatus(uint64(id))
+	if err != nil {
+		return err
+	}
+
+	for ev := range events {
+		fmt.Printf("%s ID: %d Stage: %s Status: %s AwaitingConfirmation: %t TxHash: %s\n",
+			ev.Time.Format(time.RFC3339),
+			ev.ID,
+			ev.Stage,
+			ev.Status,
+			ev.AwaitingConfirmation,
+			ev.TxHash,
+		)
+	}
+
+	return nil
+}
+
+func runConfirmSwap(ctx *cli.Context) error {
+	id := ctx.Uint("id")
+	accept := !ctx.Bool("decline")
+
+	endpoint := ctx.String("daemon-addr")
+	if endpoint == "" {
+		endpoint = defaultSwapdAddress
+	}
+
+	c := client.NewClient(endpoint)
+	if err := c.ConfirmSwap(uint64(id), accept); err != nil {
+		return err
+	}
+
+	if accept {
+		fmt.Printf("Accepted swap with ID=%d\n", id)
+	} else {
+		fmt.Printf("Declined swap with ID=%d\n", id)
+	}
+
+	return nil
+}
+
+func runSetSweepAddress(ctx *cli.Context) error {
+	id := ctx.Uint("id")
+	address := ctx.String("address")
+
+	endpoint := ctx.String("daemon-addr")
+	if endpoint == "" {
+		endpoint = defaultSwapdAddress
+	}
+
+	c := client.NewClient(endpoint)
+	if err := c.SetSweepAddress(uint64(id), address); err != nil {
+		return err
+	}
+
+	if address == "" {
+		fmt.Printf("Swap with ID=%d won't sweep its XMR\n", id)
+	} else {
+		fmt.Printf("Swap with ID=%d will sweep its XMR to %s\n", id, address)
+	}
+
 	return nil
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"os"
 	"strings"
@@ -10,12 +11,18 @@ import (
 
 	"github.com/noot/atomic-swap/cmd/utils"
 	"github.com/noot/atomic-swap/common"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+	"github.com/noot/atomic-swap/dleq"
+	"github.com/noot/atomic-swap/monero"
 	"github.com/noot/atomic-swap/net"
+	pcommon "github.com/noot/atomic-swap/protocol"
 	"github.com/noot/atomic-swap/protocol/alice"
 	"github.com/noot/atomic-swap/protocol/bob"
 	"github.com/noot/atomic-swap/protocol/swap"
 	"github.com/noot/atomic-swap/rpc"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
 	logging "github.com/ipfs/go-log"
 )
 
@@ -41,9 +48,12 @@ var (
 	_   = logging.SetLogLevel("alice", "debug")
 	_   = logging.SetLogLevel("bob", "debug")
 	_   = logging.SetLogLevel("common", "debug")
+	_   = logging.SetLogLevel("contract", "debug")
 	_   = logging.SetLogLevel("cmd", "debug")
 	_   = logging.SetLogLevel("net", "debug")
+	_   = logging.SetLogLevel("protocol", "debug")
 	_   = logging.SetLogLevel("rpc", "debug")
+	_   = logging.SetLogLevel("swap", "debug")
 )
 
 var (
@@ -60,6 +70,10 @@ var (
 				Name:  "basepath",
 				Usage: "path to store swap artefacts",
 			},
+			&cli.StringFlag{
+				Name:  "secrets-password-file",
+				Usage: "file containing the passphrase to encrypt the swap secrets written to the basepath with. if not set, it's prompted for; in development, the secrets are written in plaintext instead.", //nolint:lll
+			},
 			&cli.StringFlag{
 				Name:  "libp2p-key",
 				Usage: "libp2p private key",
@@ -96,10 +110,30 @@ var (
 				Name:  "ethereum-privkey",
 				Usage: "file containing a private key hex string",
 			},
+			&cli.StringFlag{
+				Name:  "ethereum-keystore",
+				Usage: "ethereum keystore file, or directory, containing the encrypted key to use instead of --ethereum-privkey",
+			},
+			&cli.StringFlag{
+				Name:  "ethereum-signer",
+				Usage: "endpoint of an external signer with a Clef-compatible API, eg. http://localhost:8550, to sign transactions with instead of holding a key", //nolint:lll
+			},
+			&cli.StringFlag{
+				Name:  "ethereum-account",
+				Usage: "address of the account to use, if the --ethereum-keystore directory, or --ethereum-signer, has several",
+			},
+			&cli.StringFlag{
+				Name:  "ethereum-keystore-password-file",
+				Usage: "file containing the passphrase of the --ethereum-keystore key. if not set, it's prompted for, or if not run from a terminal, the key is locked until unlocked with personal_unlock.", //nolint:lll
+			},
 			&cli.UintFlag{
 				Name:  "ethereum-chain-id",
 				Usage: "ethereum chain ID; eg. mainnet=1, ropsten=3, rinkeby=4, goerli=5, ganache=1337",
 			},
+			&cli.StringFlag{
+				Name:  "contract-address",
+				Usage: "address of the SwapFactory contract to use; if not set, one is deployed on the first swap",
+			},
 			&cli.StringFlag{
 				Name:  "bootnodes",
 				Usage: "comma-separated string of libp2p bootnodes",
@@ -108,10 +142,48 @@ var (
 				Name:  "gas-price",
 				Usage: "ethereum gas price to use for transactions (in gwei). if not set, the gas price is set via oracle.",
 			},
+			&cli.Uint64Flag{
+				Name:  "max-fee-per-gas",
+				Usage: "maximum fee per gas to pay for transactions (in wei), however urgent they are. if not set, there's no maximum.", //nolint:lll
+			},
+			&cli.Uint64Flag{
+				Name:  "max-priority-fee-per-gas",
+				Usage: "maximum priority fee per gas to pay for transactions (in wei), however urgent they are. if not set, there's no maximum.", //nolint:lll
+			},
 			&cli.UintFlag{
 				Name:  "gas-limit",
 				Usage: "ethereum gas limit to use for transactions. if not set, the gas limit is estimated for each transaction.",
 			},
+			&cli.UintFlag{
+				Name:  "eth-confirmations",
+				Usage: "number of confirmations our ethereum transactions, and the counterparty's swap, must have before proceeding. if not set, the environment's default is used.", //nolint:lll
+			},
+			&cli.UintFlag{
+				Name:  "xmr-confirmations",
+				Usage: "number of confirmations the counterparty's XMR lock must have before proceeding. if not set, the environment's default is used.", //nolint:lll
+			},
+			&cli.StringFlag{
+				Name:  "sweep-address",
+				Usage: "monero address to sweep received XMR to once it's unlocked; if not set, it's left in the swap wallet. it can be changed for each swap with swap_setSweepAddress", //nolint:lll
+			},
+			&cli.StringFlag{
+				Name:  "dleq-backend",
+				Usage: "DLEq proof implementation to use: one of farcaster or go; default farcaster",
+				Value: dleq.BackendFarcaster,
+			},
+			&cli.StringFlag{
+				Name:  "dleq-bin-path",
+				Usage: "directory containing the farcaster-dleq binaries; only used with --dleq-backend=farcaster",
+			},
+			&cli.BoolFlag{
+				Name:  "manual-confirm",
+				Usage: "require each swap to be confirmed with swap_confirm before any funds are locked",
+			},
+			&cli.DurationFlag{
+				Name:  "confirm-timeout",
+				Usage: "how long to wait for a swap to be confirmed when --manual-confirm is set before declining it",
+				Value: pcommon.DefaultConfirmTimeout,
+			},
 			&cli.BoolFlag{
 				Name: "dev-alice",
 			},
@@ -130,14 +202,17 @@ func main() {
 }
 
 type aliceHandler interface {
+	net.Handler
 	rpc.Alice
 	SetMessageSender(net.MessageSender)
+	ResumeSwaps() error
 }
 
 type bobHandler interface {
 	net.Handler
 	rpc.Bob
 	SetMessageSender(net.MessageSender)
+	ResumeSwaps() error
 }
 
 func runDaemon(c *cli.Context) error {
@@ -154,12 +229,36 @@ func runDaemon(c *cli.Context) error {
 		chainID = cfg.EthereumChainID
 	}
 
-	sm := swap.NewManager()
+	db, err := swap.OpenDatabase(cfg.Basepath)
+	if err != nil {
+		return fmt.Errorf("failed to open swap database: %w", err)
+	}
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Errorf("failed to close swap database: %s", err)
+		}
+	}()
+
+	sm, err := swap.NewManager(db)
+	if err != nil {
+		return err
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	a, b, err := getProtocolInstances(ctx, c, env, cfg, chainID, devBob, sm)
+	signer, err := utils.GetEthereumSigner(ctx, c, env, devBob)
+	if err != nil {
+		return err
+	}
+
+	secretsPassphrase, err := utils.GetSecretsPassphrase(c, env)
+	if err != nil {
+		return err
+	}
+
+	a, b, err := getProtocolInstances(ctx, c, env, cfg, chainID, devBob, sm, signer, secretsPassphrase)
 	if err != nil {
 		return err
 	}
@@ -207,7 +306,7 @@ func runDaemon(c *cli.Context) error {
 		Port:        libp2pPort,
 		KeyFile:     libp2pKey,
 		Bootnodes:   bootnodes,
-		Handler:     b, // handler handles initiated ("taken") swaps
+		Handler:     pcommon.NewHandler(a, b), // handler handles initiated ("taken") swaps of our offers
 	}
 
 	host, err := net.NewHost(netCfg)
@@ -219,6 +318,15 @@ func runDaemon(c *cli.Context) error {
 	a.SetMessageSender(host)
 	b.SetMessageSender(host)
 
+	// finish any swaps that were interrupted when swapd last exited
+	if err = a.ResumeSwaps(); err != nil {
+		return err
+	}
+
+	if err = b.ResumeSwaps(); err != nil {
+		return err
+	}
+
 	if err = host.Start(); err != nil {
 		return err
 	}
@@ -236,6 +344,7 @@ func runDaemon(c *cli.Context) error {
 	}
 
 	rpcCfg := &rpc.Config{
+		Ctx:         ctx,
 		Port:        rpcPort,
 		Net:         host,
 		Alice:       a,
@@ -243,6 +352,11 @@ func runDaemon(c *cli.Context) error {
 		SwapManager: sm,
 	}
 
+	// only keys from a keystore can be unlocked with personal_unlock
+	if key, ok := signer.(*pcommon.EthereumKey); ok {
+		rpcCfg.EthereumKey = key
+	}
+
 	s, err := rpc.NewServer(rpcCfg)
 	if err != nil {
 		return err
@@ -267,7 +381,8 @@ func runDaemon(c *cli.Context) error {
 }
 
 func getProtocolInstances(ctx context.Context, c *cli.Context, env common.Environment, cfg common.Config,
-	chainID int64, devBob bool, sm *swap.Manager) (a aliceHandler, b bobHandler, err error) {
+	chainID int64, devBob bool, sm *swap.Manager, signer pcommon.Signer,
+	secretsPassphrase string) (a aliceHandler, b bobHandler, err error) {
 	var (
 		moneroEndpoint, daemonEndpoint, ethEndpoint string
 	)
@@ -286,11 +401,6 @@ func getProtocolInstances(ctx context.Context, c *cli.Context, env common.Enviro
 		ethEndpoint = common.DefaultEthEndpoint
 	}
 
-	ethPrivKey, err := utils.GetEthereumPrivateKey(c, env, devBob)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	if c.String("monero-daemon-endpoint") != "" {
 		daemonEndpoint = c.String("monero-daemon-endpoint")
 	} else {
@@ -303,17 +413,71 @@ func getProtocolInstances(ctx context.Context, c *cli.Context, env common.Enviro
 		gasPrice = big.NewInt(int64(c.Uint("gas-price")))
 	}
 
+	var maxFeePerGas, maxPriorityFeePerGas *big.Int
+	if c.Uint64("max-fee-per-gas") != 0 {
+		maxFeePerGas = new(big.Int).SetUint64(c.Uint64("max-fee-per-gas"))
+	}
+
+	if c.Uint64("max-priority-fee-per-gas") != 0 {
+		maxPriorityFeePerGas = new(big.Int).SetUint64(c.Uint64("max-priority-fee-per-gas"))
+	}
+
+	// Alice and Bob send transactions from the same account, so they share a transaction
+	// manager to assign their nonces
+	txManager, err := newTxManager(ethEndpoint, signer, chainID, maxFeePerGas, maxPriorityFeePerGas)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dleqBackend, err := dleq.NewBackend(c.String("dleq-backend"), c.String("dleq-bin-path"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ethConfirmations, xmrConfirmations := cfg.EthereumConfirmations, cfg.MoneroConfirmations
+	if c.Uint("eth-confirmations") != 0 {
+		ethConfirmations = uint64(c.Uint("eth-confirmations"))
+	}
+
+	if c.Uint("xmr-confirmations") != 0 {
+		xmrConfirmations = uint64(c.Uint("xmr-confirmations"))
+	}
+
+	var contractAddr ethcommon.Address
+	if c.String("contract-address") != "" {
+		if !ethcommon.IsHexAddress(c.String("contract-address")) {
+			return nil, nil, fmt.Errorf("invalid --contract-address: %s", c.String("contract-address"))
+		}
+
+		contractAddr = ethcommon.HexToAddress(c.String("contract-address"))
+	}
+
+	// Alice and Bob use the same monero-wallet-rpc instance, so they must not change its open
+	// wallet concurrently
+	walletLock := monero.NewWalletLock(monero.NewClient(moneroEndpoint))
+
 	aliceCfg := &alice.Config{
-		Ctx:                  ctx,
-		Basepath:             cfg.Basepath,
-		MoneroWalletEndpoint: moneroEndpoint,
-		EthereumEndpoint:     ethEndpoint,
-		EthereumPrivateKey:   ethPrivKey,
-		Environment:          env,
-		ChainID:              chainID,
-		GasPrice:             gasPrice,
-		GasLimit:             uint64(c.Uint("gas-limit")),
-		SwapManager:          sm,
+		Ctx:                   ctx,
+		Basepath:              cfg.Basepath,
+		SecretsPassphrase:     secretsPassphrase,
+		MoneroWalletEndpoint:  moneroEndpoint,
+		MoneroDaemonEndpoint:  daemonEndpoint,
+		EthereumEndpoint:      ethEndpoint,
+		Signer:                signer,
+		Environment:           env,
+		ChainID:               chainID,
+		GasPrice:              gasPrice,
+		GasLimit:              uint64(c.Uint("gas-limit")),
+		TxManager:             txManager,
+		SwapManager:           sm,
+		DLEq:                  dleqBackend,
+		ManualConfirm:         c.Bool("manual-confirm"),
+		ConfirmTimeout:        c.Duration("confirm-timeout"),
+		SwapContractAddress:   contractAddr,
+		EthereumConfirmations: ethConfirmations,
+		MoneroConfirmations:   xmrConfirmations,
+		SweepAddress:          mcrypto.Address(c.String("sweep-address")),
+		WalletLock:            walletLock,
 	}
 
 	a, err = alice.NewInstance(aliceCfg)
@@ -327,19 +491,27 @@ func getProtocolInstances(ctx context.Context, c *cli.Context, env common.Enviro
 	walletPassword := c.String("wallet-password")
 
 	bobCfg := &bob.Config{
-		Ctx:                  ctx,
-		Basepath:             cfg.Basepath,
-		MoneroWalletEndpoint: moneroEndpoint,
-		MoneroDaemonEndpoint: daemonEndpoint,
-		WalletFile:           walletFile,
-		WalletPassword:       walletPassword,
-		EthereumEndpoint:     ethEndpoint,
-		EthereumPrivateKey:   ethPrivKey,
-		Environment:          env,
-		ChainID:              chainID,
-		GasPrice:             gasPrice,
-		GasLimit:             uint64(c.Uint("gas-limit")),
-		SwapManager:          sm,
+		Ctx:                   ctx,
+		Basepath:              cfg.Basepath,
+		SecretsPassphrase:     secretsPassphrase,
+		MoneroWalletEndpoint:  moneroEndpoint,
+		MoneroDaemonEndpoint:  daemonEndpoint,
+		WalletFile:            walletFile,
+		WalletPassword:        walletPassword,
+		EthereumEndpoint:      ethEndpoint,
+		Signer:                signer,
+		Environment:           env,
+		ChainID:               chainID,
+		GasPrice:              gasPrice,
+		GasLimit:              uint64(c.Uint("gas-limit")),
+		TxManager:             txManager,
+		SwapManager:           sm,
+		DLEq:                  dleqBackend,
+		ManualConfirm:         c.Bool("manual-confirm"),
+		ConfirmTimeout:        c.Duration("confirm-timeout"),
+		EthereumConfirmations: ethConfirmations,
+		SweepAddress:          mcrypto.Address(c.String("sweep-address")),
+		WalletLock:            walletLock,
 	}
 
 	b, err = bob.NewInstance(bobCfg)
@@ -353,3 +525,21 @@ func getProtocolInstances(ctx context.Context, c *cli.Context, env common.Enviro
 	)
 	return a, b, nil
 }
+
+// newTxManager returns a transaction manager for the account of the given signer.
+func newTxManager(ethEndpoint string, signer pcommon.Signer, chainID int64, maxFeePerGas,
+	maxPriorityFeePerGas *big.Int) (*pcommon.TxManager, error) {
+	ec, err := ethclient.Dial(ethEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return pcommon.NewTxManager(&pcommon.TxManagerConfig{
+		Client:               ec,
+		From:                 signer.Address(),
+		Signer:               signer,
+		ChainID:              big.NewInt(chainID),
+		MaxFeePerGas:         maxFeePerGas,
+		MaxPriorityFeePerGas: maxPriorityFeePerGas,
+	}), nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"os"
 
@@ -62,6 +63,10 @@ var (
 				Name:  "gas-price",
 				Usage: "ethereum gas price to use for transactions (in gwei). if not set, the gas price is set via oracle.",
 			},
+			&cli.StringFlag{
+				Name:  "secrets-password-file",
+				Usage: "file containing the passphrase the --alice-secret-file or --bob-secret-file is encrypted with. if not set, it's prompted for.", //nolint:lll
+			},
 			&cli.UintFlag{
 				Name:  "gas-limit",
 				Usage: "ethereum gas limit to use for transactions. if not set, the gas limit is estimated for each transaction.",
@@ -71,21 +76,33 @@ var (
 			{
 				Name:    "monero",
 				Aliases: []string{"xmr"},
-				Usage:   "recover monero funds from an aborted swap; must provide 2/3 of --alice-secret, --bob-secret, and --contract-addr", //nolint:lll
+				Usage:   "recover monero funds from an aborted swap; must provide 2/3 of --alice-secret (or --alice-secret-file), --bob-secret (or --bob-secret-file), and --contract-addr with --swap-id", //nolint:lll
 				Action:  runRecoverMonero,
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:  "alice-secret",
-						Usage: "Alice's swap secret, can be found in the basepath (default ~/.atomicswap), format is a hex-encoded string", //nolint:lll
+						Usage: "Alice's swap secret, format is a hex-encoded string", //nolint:lll
 					},
 					&cli.StringFlag{
 						Name:  "bob-secret",
-						Usage: "Bob's swap secret, can be found in the basepath (default ~/.atomicswap), format is a hex-encoded string", //nolint:lll
+						Usage: "Bob's swap secret, format is a hex-encoded string", //nolint:lll
+					},
+					&cli.StringFlag{
+						Name:  "alice-secret-file",
+						Usage: "file in the basepath (default ~/.atomicswap) containing Alice's swap secret, eg. <swap-id>/alice-secret-<time>.key; used instead of --alice-secret", //nolint:lll
+					},
+					&cli.StringFlag{
+						Name:  "bob-secret-file",
+						Usage: "file in the basepath (default ~/.atomicswap) containing Bob's swap secret, eg. <swap-id>/bob-secret-<time>.key; used instead of --bob-secret", //nolint:lll
 					},
 					&cli.StringFlag{
 						Name:  "contract-addr",
 						Usage: "address of deployed ethereum swap contract, can be found in the basepath (default ~/.atomicswap)", //nolint:lll
 					},
+					&cli.StringFlag{
+						Name:  "swap-id",
+						Usage: "ID of the swap within the swap contract, can be found in the basepath (default ~/.atomicswap)", //nolint:lll
+					},
 				},
 			},
 		},
@@ -102,20 +119,26 @@ func main() {
 // MoneroRecoverer is implemented by a backend which is able to recover monero
 type MoneroRecoverer interface {
 	WalletFromSecrets(aliceSecret, bobSecret string) (mcrypto.Address, error)
-	RecoverFromBobSecretAndContract(b *bob.Instance, bobSecret, contractAddr string) (*bob.RecoveryResult, error)
-	RecoverFromAliceSecretAndContract(a *alice.Instance, aliceSecret, contractAddr string) (*alice.RecoveryResult, error)
+	RecoverFromBobSecretAndContract(b *bob.Instance, bobSecret, contractAddr,
+		swapID string) (*bob.RecoveryResult, error)
+	RecoverFromAliceSecretAndContract(a *alice.Instance, aliceSecret, contractAddr,
+		swapID string) (*alice.RecoveryResult, error)
 }
 
 func runRecoverMonero(c *cli.Context) error {
-	as := c.String("alice-secret")
-	bs := c.String("bob-secret")
 	contractAddr := c.String("contract-addr")
+	swapID := c.String("swap-id")
 
 	env, cfg, err := utils.GetEnvironment(c)
 	if err != nil {
 		return err
 	}
 
+	as, bs, err := getSecrets(c)
+	if err != nil {
+		return err
+	}
+
 	if as == "" && bs == "" {
 		return errors.New("must also provide one of --alice-secret or --bob-secret")
 	}
@@ -128,6 +151,10 @@ func runRecoverMonero(c *cli.Context) error {
 		return errors.New("must also provide one of --contract-addr or --bob-secret")
 	}
 
+	if contractAddr != "" && swapID == "" {
+		return errors.New("must also provide --swap-id with --contract-addr")
+	}
+
 	r, err := getRecoverer(c, env)
 	if err != nil {
 		return err
@@ -149,7 +176,7 @@ func runRecoverMonero(c *cli.Context) error {
 			return err
 		}
 
-		res, err := r.RecoverFromBobSecretAndContract(b, bs, contractAddr)
+		res, err := r.RecoverFromBobSecretAndContract(b, bs, contractAddr, swapID)
 		if err != nil {
 			return err
 		}
@@ -171,7 +198,7 @@ func runRecoverMonero(c *cli.Context) error {
 			return err
 		}
 
-		res, err := r.RecoverFromAliceSecretAndContract(a, as, contractAddr)
+		res, err := r.RecoverFromAliceSecretAndContract(a, as, contractAddr, swapID)
 		if err != nil {
 			return err
 		}
@@ -191,6 +218,49 @@ func runRecoverMonero(c *cli.Context) error {
 	return nil
 }
 
+// getSecrets returns Alice's and Bob's swap secrets, if they were provided, either directly or
+// as files in the basepath. The passphrase of encrypted files is only asked for once it's needed.
+func getSecrets(c *cli.Context) (aliceSecret, bobSecret string, err error) {
+	var passphrase string
+	readSecret := func(secretFlag, fileFlag string) (string, error) {
+		if c.String(fileFlag) == "" {
+			return c.String(secretFlag), nil
+		}
+
+		if c.String(secretFlag) != "" {
+			return "", fmt.Errorf("cannot provide both --%s and --%s", secretFlag, fileFlag)
+		}
+
+		kp, err := mcrypto.ReadKeysFromFile(c.String(fileFlag), passphrase) //nolint:govet
+		if errors.Is(err, mcrypto.ErrKeysEncrypted) {
+			passphrase, err = utils.GetSecretsDecryptionPassphrase(c)
+			if err != nil {
+				return "", err
+			}
+
+			kp, err = mcrypto.ReadKeysFromFile(c.String(fileFlag), passphrase)
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("failed to read --%s: %w", fileFlag, err)
+		}
+
+		return kp.SpendKey().Hex(), nil
+	}
+
+	aliceSecret, err = readSecret("alice-secret", "alice-secret-file")
+	if err != nil {
+		return "", "", err
+	}
+
+	bobSecret, err = readSecret("bob-secret", "bob-secret-file")
+	if err != nil {
+		return "", "", err
+	}
+
+	return aliceSecret, bobSecret, nil
+}
+
 func getRecoverer(c *cli.Context, env common.Environment) (MoneroRecoverer, error) {
 	var (
 		moneroEndpoint, ethEndpoint string
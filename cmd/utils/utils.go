@@ -1,15 +1,21 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	logging "github.com/ipfs/go-log"
 	"github.com/urfave/cli"
+	"golang.org/x/term"
 
 	"github.com/noot/atomic-swap/common"
+	pcommon "github.com/noot/atomic-swap/protocol"
 )
 
 var log = logging.Logger("cmd")
@@ -47,6 +53,233 @@ func GetEthereumPrivateKey(c *cli.Context, env common.Environment, devBob bool)
 	return ethPrivKey, nil
 }
 
+// GetEthereumSigner returns the signer of our ethereum transactions given the CLI options: the
+// external signer at the --ethereum-signer endpoint if it's set, or otherwise our key, as
+// returned by GetEthereumKey.
+func GetEthereumSigner(ctx context.Context, c *cli.Context, env common.Environment,
+	devBob bool) (pcommon.Signer, error) {
+	if c.String("ethereum-signer") == "" {
+		return GetEthereumKey(c, env, devBob)
+	}
+
+	var account ethcommon.Address
+	if c.String("ethereum-account") != "" {
+		if !ethcommon.IsHexAddress(c.String("ethereum-account")) {
+			return nil, fmt.Errorf("invalid --ethereum-account: %s", c.String("ethereum-account"))
+		}
+
+		account = ethcommon.HexToAddress(c.String("ethereum-account"))
+	}
+
+	signer, err := pcommon.NewExternalSigner(ctx, c.String("ethereum-signer"), account)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("using external signer for ethereum account %s", signer.Address())
+	return signer, nil
+}
+
+// GetEthereumKey returns the key of the ethereum account to use given the CLI options. If a keystore
+// is given with --ethereum-keystore, its key is unlocked with the passphrase in the
+// --ethereum-keystore-password-file, or prompted for if we're run from a terminal; otherwise, it's
+// left locked, to be unlocked with personal_unlock. If no keystore is given, the key is from
+// --ethereum-privkey, as for GetEthereumPrivateKey.
+func GetEthereumKey(c *cli.Context, env common.Environment, devBob bool) (*pcommon.EthereumKey, error) {
+	if c.String("ethereum-keystore") == "" {
+		ethPrivKey, err := GetEthereumPrivateKey(c, env, devBob)
+		if err != nil {
+			return nil, err
+		}
+
+		pk, err := crypto.HexToECDSA(ethPrivKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return pcommon.NewEthereumKey(pk), nil
+	}
+
+	keyJSON, err := readKeystore(c.String("ethereum-keystore"), c.String("ethereum-account"))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := pcommon.NewKeystoreKey(keyJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	var passphrase string
+	switch {
+	case c.String("ethereum-keystore-password-file") != "":
+		passphrase, err = readPasswordFile(c.String("ethereum-keystore-password-file"))
+		if err != nil {
+			return nil, err
+		}
+	case term.IsTerminal(int(os.Stdin.Fd())):
+		passphrase, err = promptPassphrase(fmt.Sprintf("Passphrase for ethereum account %s: ", key.Address()))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		log.Warnf("ethereum account %s is locked, it must be unlocked with personal_unlock before swapping",
+			key.Address())
+		return key, nil
+	}
+
+	if err = key.Unlock(passphrase); err != nil {
+		return nil, err
+	}
+
+	log.Infof("unlocked ethereum account %s", key.Address())
+	return key, nil
+}
+
+// GetSecretsPassphrase returns the passphrase to encrypt the swap secrets we write with, given the
+// CLI options: the contents of the --secrets-password-file, or, outside of development, prompted
+// for if we're run from a terminal. In development, the secrets are written in plaintext if no
+// passphrase is given.
+func GetSecretsPassphrase(c *cli.Context, env common.Environment) (string, error) {
+	var (
+		passphrase string
+		err        error
+	)
+	switch {
+	case c.String("secrets-password-file") != "":
+		passphrase, err = readPasswordFile(c.String("secrets-password-file"))
+		if err != nil {
+			return "", err
+		}
+	case env != common.Development && term.IsTerminal(int(os.Stdin.Fd())):
+		passphrase, err = promptPassphrase("Passphrase to encrypt swap secrets with: ")
+		if err != nil {
+			return "", err
+		}
+
+		// the secrets can't be recovered without the passphrase, so make sure it wasn't mistyped
+		repeated, err := promptPassphrase("Repeat passphrase: ") //nolint:govet
+		if err != nil {
+			return "", err
+		}
+
+		if repeated != passphrase {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+
+	if passphrase != "" {
+		return passphrase, nil
+	}
+
+	if env != common.Development {
+		return "", errors.New("must provide a passphrase to encrypt swap secrets with for non-development environment")
+	}
+
+	log.Warn("no secrets passphrase provided, swap secrets will be written in plaintext")
+	return "", nil
+}
+
+// GetSecretsDecryptionPassphrase returns the passphrase to decrypt swap secrets with, given the CLI
+// options: the contents of the --secrets-password-file, or prompted for if we're run from a terminal.
+func GetSecretsDecryptionPassphrase(c *cli.Context) (string, error) {
+	switch {
+	case c.String("secrets-password-file") != "":
+		return readPasswordFile(c.String("secrets-password-file"))
+	case term.IsTerminal(int(os.Stdin.Fd())):
+		return promptPassphrase("Passphrase the swap secrets are encrypted with: ")
+	default:
+		return "", errors.New("swap secrets are encrypted, their passphrase must be given with --secrets-password-file")
+	}
+}
+
+// promptPassphrase prints the given prompt and reads a passphrase from the terminal.
+func promptPassphrase(prompt string) (string, error) {
+	fmt.Print(prompt)
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+
+	return string(passphrase), nil
+}
+
+// readKeystore returns the contents of the keystore file at the given path. If the path is a
+// keystore directory, it returns the file for the given account address, which can be omitted if
+// the directory only has one account.
+func readKeystore(path, account string) ([]byte, error) {
+	if account != "" && !ethcommon.IsHexAddress(account) {
+		return nil, fmt.Errorf("invalid --ethereum-account: %s", account)
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ethereum keystore: %w", err)
+	}
+
+	files := []string{path}
+	if fi.IsDir() {
+		entries, err := os.ReadDir(path) //nolint:govet
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ethereum keystore: %w", err)
+		}
+
+		files = files[:0]
+		for _, entry := range entries {
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+
+	var (
+		found     []byte
+		addresses []string
+	)
+	for _, file := range files {
+		keyJSON, err := os.ReadFile(filepath.Clean(file)) //nolint:govet
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ethereum keystore: %w", err)
+		}
+
+		address, err := pcommon.KeystoreAddress(keyJSON)
+		if err != nil {
+			log.Debugf("skipping invalid keystore file %s: %s", file, err)
+			continue
+		}
+
+		addresses = append(addresses, address.Hex())
+		if account == "" || address == ethcommon.HexToAddress(account) {
+			found = keyJSON
+		}
+	}
+
+	switch {
+	case len(addresses) == 0:
+		return nil, fmt.Errorf("no accounts found in ethereum keystore %s", path)
+	case account == "" && len(addresses) > 1:
+		return nil, fmt.Errorf("ethereum keystore has several accounts, choose one with --ethereum-account: %s",
+			strings.Join(addresses, ", "))
+	case found == nil:
+		return nil, fmt.Errorf("account %s not found in ethereum keystore, which has: %s", account,
+			strings.Join(addresses, ", "))
+	}
+
+	return found, nil
+}
+
+func readPasswordFile(path string) (string, error) {
+	passphrase, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("failed to read password file: %w", err)
+	}
+
+	return strings.TrimRight(string(passphrase), "\r\n"), nil
+}
+
 // GetEnvironment returns a common.Environment from the CLI options.
 func GetEnvironment(c *cli.Context) (env common.Environment, cfg common.Config, err error) {
 	switch c.String("env") {
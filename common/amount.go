@@ -0,0 +1,182 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	numEtherDecimals  = 18
+	numMoneroDecimals = 12
+)
+
+var (
+	numEtherUnits  = new(big.Int).Exp(big.NewInt(10), big.NewInt(numEtherDecimals), nil)
+	numMoneroUnits = new(big.Int).Exp(big.NewInt(10), big.NewInt(numMoneroDecimals), nil)
+
+	errNegativeAmount  = errors.New("amount must not be negative")
+	errInvalidAmount   = errors.New("amount must be a decimal number")
+	errTooManyDecimals = fmt.Errorf("amount must have at most %d decimal places", numEtherDecimals)
+)
+
+// Amount is an exact amount of ether or monero in standard units, with at most 18 decimal places.
+// It's encoded in JSON as a decimal string. For compatibility with older versions, which used
+// float64 amounts, it can also be decoded from a JSON number; the number's decimal representation
+// is used, so no precision is lost.
+type Amount big.Rat
+
+// NewAmount parses a decimal string, such as "0.1", into an Amount.
+func NewAmount(s string) (Amount, error) {
+	// big.Rat also accepts fractions, which may not have a finite decimal representation
+	if strings.ContainsRune(s, '/') {
+		return Amount{}, errInvalidAmount
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Amount{}, errInvalidAmount
+	}
+
+	return newAmountFromRat(r)
+}
+
+// MustNewAmount parses a decimal string into an Amount, and panics if it's invalid.
+// It's intended for constants.
+func MustNewAmount(s string) Amount {
+	a, err := NewAmount(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+func newAmountFromRat(r *big.Rat) (Amount, error) {
+	if r.Sign() < 0 {
+		return Amount{}, errNegativeAmount
+	}
+
+	// the denominator must divide 10^18, so that the amount can be represented in wei
+	if new(big.Int).Mod(numEtherUnits, r.Denom()).Sign() != 0 {
+		return Amount{}, errTooManyDecimals
+	}
+
+	return Amount(*r), nil
+}
+
+// Rat returns the amount as a *big.Rat.
+func (a Amount) Rat() *big.Rat {
+	r := big.Rat(a)
+	return new(big.Rat).Set(&r)
+}
+
+// Cmp compares a and b, returning -1 if a < b, 0 if a == b, and +1 if a > b.
+func (a Amount) Cmp(b Amount) int {
+	return a.Rat().Cmp(b.Rat())
+}
+
+// IsZero returns true if the amount is zero.
+func (a Amount) IsZero() bool {
+	return a.Rat().Sign() == 0
+}
+
+// Add returns a + b.
+func (a Amount) Add(b Amount) Amount {
+	return Amount(*new(big.Rat).Add(a.Rat(), b.Rat()))
+}
+
+// Sub returns a - b, or zero if b is greater than a, since amounts can't be negative.
+func (a Amount) Sub(b Amount) Amount {
+	if a.Cmp(b) <= 0 {
+		return Amount{}
+	}
+
+	return Amount(*new(big.Rat).Sub(a.Rat(), b.Rat()))
+}
+
+// String returns the amount as a decimal string, without trailing zeros.
+func (a Amount) String() string {
+	s := a.Rat().FloatString(numEtherDecimals)
+	if strings.ContainsRune(s, '.') {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+
+	return s
+}
+
+// MarshalJSON ...
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON ...
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	s, isNumber, err := decodeDecimalJSON(b)
+	if err != nil {
+		return err
+	}
+
+	if s == "" {
+		return nil
+	}
+
+	if !isNumber {
+		amt, parseErr := NewAmount(s)
+		if parseErr != nil {
+			return parseErr
+		}
+
+		*a = amt
+		return nil
+	}
+
+	// a legacy float64 amount may have more decimal places than can be represented,
+	// so round it down to a wei
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return errInvalidAmount
+	}
+
+	amt, err := newAmountFromRat(floorToDecimals(r, numEtherDecimals))
+	if err != nil {
+		return err
+	}
+
+	*a = amt
+	return nil
+}
+
+// decodeDecimalJSON returns the contents of a JSON string, or the text of a JSON number
+// along with isNumber=true. It returns an empty string for null.
+func decodeDecimalJSON(b []byte) (s string, isNumber bool, err error) {
+	if bytes.Equal(b, []byte("null")) {
+		return "", false, nil
+	}
+
+	if len(b) > 0 && b[0] == '"' {
+		err = json.Unmarshal(b, &s)
+		return s, false, err
+	}
+
+	// legacy float64 encoding
+	var n json.Number
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err = d.Decode(&n); err != nil {
+		return "", false, err
+	}
+
+	return n.String(), true, nil
+}
+
+// floorToDecimals rounds r down to the given number of decimal places.
+func floorToDecimals(r *big.Rat, decimals int64) *big.Rat {
+	units := new(big.Int).Exp(big.NewInt(10), big.NewInt(decimals), nil)
+	n := new(big.Int).Mul(r.Num(), units)
+	n.Quo(n, r.Denom())
+	return new(big.Rat).SetFrac(n, units)
+}
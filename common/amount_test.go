@@ -0,0 +1,120 @@
+package common
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAmount(t *testing.T) {
+	amt, err := NewAmount("0.1")
+	require.NoError(t, err)
+	require.Equal(t, "0.1", amt.String())
+	require.Equal(t, "100000000000000000", EtherToWei(amt).String())
+	require.Equal(t, uint64(100000000000), MoneroToPiconero(amt).Uint64())
+
+	amt, err = NewAmount("1.000000000000000001")
+	require.NoError(t, err)
+	require.Equal(t, "1000000000000000001", EtherToWei(amt).String())
+
+	_, err = NewAmount("0.0000000000000000001")
+	require.ErrorIs(t, err, errTooManyDecimals)
+	_, err = NewAmount("-1")
+	require.ErrorIs(t, err, errNegativeAmount)
+	_, err = NewAmount("1/3")
+	require.ErrorIs(t, err, errInvalidAmount)
+	_, err = NewAmount("abc")
+	require.ErrorIs(t, err, errInvalidAmount)
+}
+
+func TestAmount_Conversions(t *testing.T) {
+	require.Equal(t, "0.000000000001", MoneroAmount(1).AsMonero().String())
+	require.Equal(t, "1.5", MoneroAmount(1500000000000).AsMonero().String())
+
+	require.Equal(t, "0.33", EtherToWei(MustNewAmount("0.33")).AsEther().String())
+
+	// piconero is the smallest monero unit, so any smaller fraction is truncated
+	require.Equal(t, uint64(1), MoneroToPiconero(MustNewAmount("0.0000000000019")).Uint64())
+
+	// amounts that don't fit in a uint64 of piconero saturate instead of wrapping around
+	tooLarge := MustNewAmount("18446745")
+	require.Equal(t, uint64(math.MaxUint64), MoneroToPiconero(tooLarge).Uint64())
+	require.NotEqual(t, 0, MoneroToPiconero(tooLarge).AsMonero().Cmp(tooLarge))
+}
+
+func TestAmount_Arithmetic(t *testing.T) {
+	a := MustNewAmount("1.5")
+	b := MustNewAmount("0.000000000000000001")
+	require.Equal(t, "1.500000000000000001", a.Add(b).String())
+	require.Equal(t, "1.499999999999999999", a.Sub(b).String())
+	require.True(t, b.Sub(a).IsZero())
+	require.True(t, a.Sub(a).IsZero())
+
+	// the receiver isn't modified
+	require.Equal(t, "1.5", a.String())
+}
+
+func TestAmount_JSON(t *testing.T) {
+	type msg struct {
+		Amount Amount `json:"amount"`
+	}
+
+	b, err := json.Marshal(&msg{Amount: MustNewAmount("44.4")})
+	require.NoError(t, err)
+	require.Equal(t, `{"amount":"44.4"}`, string(b))
+
+	var m msg
+	require.NoError(t, json.Unmarshal(b, &m))
+	require.Equal(t, "44.4", m.Amount.String())
+
+	// legacy float64 amounts are decoded exactly as written
+	require.NoError(t, json.Unmarshal([]byte(`{"amount":0.1}`), &m))
+	require.Equal(t, "100000000000000000", EtherToWei(m.Amount).String())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"amount":1e-3}`), &m))
+	require.Equal(t, "0.001", m.Amount.String())
+
+	// excess precision in a legacy amount is rounded down to a wei
+	require.NoError(t, json.Unmarshal([]byte(`{"amount":0.33333333333333333333}`), &m))
+	require.Equal(t, "0.333333333333333333", m.Amount.String())
+
+	require.Error(t, json.Unmarshal([]byte(`{"amount":"1/3"}`), &m))
+	require.Error(t, json.Unmarshal([]byte(`{"amount":-1}`), &m))
+}
+
+func TestExchangeRate(t *testing.T) {
+	r, err := NewExchangeRate("0.05")
+	require.NoError(t, err)
+	require.Equal(t, "0.05", r.String())
+	require.Equal(t, "20", r.ToXMR(MustNewAmount("1")).String())
+	require.Equal(t, "0.05", r.ToETH(MustNewAmount("1")).String())
+
+	r, err = NewExchangeRate("1/3")
+	require.NoError(t, err)
+	require.Equal(t, "1/3", r.String())
+	require.Equal(t, "3", r.ToXMR(MustNewAmount("1")).String())
+	require.Equal(t, "0.333333333333333333", r.ToETH(MustNewAmount("1")).String())
+
+	_, err = NewExchangeRate("0")
+	require.ErrorIs(t, err, errInvalidExchangeRate)
+	_, err = NewExchangeRate("-0.1")
+	require.ErrorIs(t, err, errInvalidExchangeRate)
+
+	require.True(t, ExchangeRate{}.ToXMR(MustNewAmount("1")).IsZero())
+}
+
+func TestExchangeRate_JSON(t *testing.T) {
+	b, err := json.Marshal(MustNewExchangeRate("1/3"))
+	require.NoError(t, err)
+	require.Equal(t, `"1/3"`, string(b))
+
+	var r ExchangeRate
+	require.NoError(t, json.Unmarshal(b, &r))
+	require.Equal(t, "1/3", r.String())
+
+	// legacy float64 exchange rate
+	require.NoError(t, json.Unmarshal([]byte(`0.05`), &r))
+	require.Equal(t, "0.05", r.String())
+}
@@ -13,25 +13,36 @@ type Config struct {
 	MoneroDaemonEndpoint string
 	EthereumChainID      int64
 	Bootnodes            []string // TODO: when it's ready for users to test, add some bootnodes
+
+	// number of confirmations, including the block it's included in, that a transaction on each
+	// chain must have before we proceed to the next step of a swap
+	EthereumConfirmations uint64
+	MoneroConfirmations   uint64
 }
 
 // MainnetConfig is the mainnet ethereum and monero configuration
 var MainnetConfig = Config{
-	Basepath:             fmt.Sprintf("%s/.atomicswap/mainnet", homeDir),
-	MoneroDaemonEndpoint: "http://127.0.0.1:18081/json_rpc",
-	EthereumChainID:      MainnetChainID,
+	Basepath:              fmt.Sprintf("%s/.atomicswap/mainnet", homeDir),
+	MoneroDaemonEndpoint:  "http://127.0.0.1:18081/json_rpc",
+	EthereumChainID:       MainnetChainID,
+	EthereumConfirmations: 12,
+	MoneroConfirmations:   10,
 }
 
 // StagenetConfig is the monero stagenet and ethereum ropsten configuration
 var StagenetConfig = Config{
-	Basepath:             fmt.Sprintf("%s/.atomicswap/stagenet", homeDir),
-	MoneroDaemonEndpoint: "http://127.0.0.1:38081/json_rpc",
-	EthereumChainID:      RopstenChainID,
+	Basepath:              fmt.Sprintf("%s/.atomicswap/stagenet", homeDir),
+	MoneroDaemonEndpoint:  "http://127.0.0.1:38081/json_rpc",
+	EthereumChainID:       RopstenChainID,
+	EthereumConfirmations: 3,
+	MoneroConfirmations:   3,
 }
 
 // DevelopmentConfig is the monero and ethereum development environment configuration
 var DevelopmentConfig = Config{
-	Basepath:             fmt.Sprintf("%s/.atomicswap/dev", homeDir),
-	MoneroDaemonEndpoint: "http://127.0.0.1:18081/json_rpc",
-	EthereumChainID:      GanacheChainID,
+	Basepath:              fmt.Sprintf("%s/.atomicswap/dev", homeDir),
+	MoneroDaemonEndpoint:  "http://127.0.0.1:18081/json_rpc",
+	EthereumChainID:       GanacheChainID,
+	EthereumConfirmations: 1,
+	MoneroConfirmations:   1,
 }
@@ -22,17 +22,21 @@ const (
 	DefaultPrivKeyBob = "6cbed15c793ce57650b9877cf6fa156fbef513c4e6134f022a85b1ffdd59b2a1"
 )
 
-var (
-	numEtherUnits  = math.Pow(10, 18)
-	numMoneroUnits = math.Pow(10, 12)
-)
-
 // MoneroAmount represents some amount of piconero (the smallest denomination of monero)
 type MoneroAmount uint64
 
-// MoneroToPiconero converts an amount of standard monero and returns it as a MoneroAmount
-func MoneroToPiconero(amount float64) MoneroAmount {
-	return MoneroAmount(amount * numMoneroUnits)
+// MoneroToPiconero converts an amount of standard monero and returns it as a MoneroAmount.
+// Any precision beyond a piconero is truncated, and amounts too large for a MoneroAmount saturate at
+// its maximum value, so they don't convert back to the same amount.
+func MoneroToPiconero(amount Amount) MoneroAmount {
+	r := amount.Rat()
+	n := new(big.Int).Mul(r.Num(), numMoneroUnits)
+	n.Quo(n, r.Denom())
+	if !n.IsUint64() {
+		return MoneroAmount(math.MaxUint64)
+	}
+
+	return MoneroAmount(n.Uint64())
 }
 
 // Uint64 ...
@@ -41,8 +45,9 @@ func (a MoneroAmount) Uint64() uint64 {
 }
 
 // AsMonero converts the piconero MoneroAmount into standard units
-func (a MoneroAmount) AsMonero() float64 {
-	return float64(a) / numMoneroUnits
+func (a MoneroAmount) AsMonero() Amount {
+	r := new(big.Rat).SetFrac(new(big.Int).SetUint64(uint64(a)), numMoneroUnits)
+	return Amount(*r)
 }
 
 // EtherAmount represents some amout of ether in the smallest denomination (wei)
@@ -55,11 +60,11 @@ func NewEtherAmount(amount int64) EtherAmount {
 }
 
 // EtherToWei converts some amount of standard ether to an EtherAmount.
-func EtherToWei(amount float64) EtherAmount {
-	amt := big.NewFloat(amount)
-	mult := big.NewFloat(numEtherUnits)
-	res, _ := big.NewFloat(0).Mul(amt, mult).Int(nil)
-	return EtherAmount(*res)
+func EtherToWei(amount Amount) EtherAmount {
+	r := amount.Rat()
+	n := new(big.Int).Mul(r.Num(), numEtherUnits)
+	n.Quo(n, r.Denom())
+	return EtherAmount(*n)
 }
 
 // BigInt returns the given EtherAmount as a *big.Int
@@ -69,15 +74,27 @@ func (a EtherAmount) BigInt() *big.Int {
 }
 
 // AsEther returns the wei amount as ether
-func (a EtherAmount) AsEther() float64 {
-	wei := big.NewFloat(0).SetInt(a.BigInt())
-	mult := big.NewFloat(numEtherUnits)
-	ether := big.NewFloat(0).Quo(wei, mult)
-	res, _ := ether.Float64()
-	return res
+func (a EtherAmount) AsEther() Amount {
+	r := new(big.Rat).SetFrac(a.BigInt(), numEtherUnits)
+	return Amount(*r)
 }
 
 // String ...
 func (a EtherAmount) String() string {
 	return a.BigInt().String()
 }
+
+// TokenToUnits converts some amount of an ERC-20 token in standard units to the token's smallest
+// units, given the number of decimals the token uses. Any precision beyond the smallest unit is truncated.
+func TokenToUnits(amount Amount, decimals uint8) *big.Int {
+	r := amount.Rat()
+	n := new(big.Int).Mul(r.Num(), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	return n.Quo(n, r.Denom())
+}
+
+// TokenUnitsToAmount converts some amount of an ERC-20 token in its smallest units to standard
+// units, given the number of decimals the token uses.
+func TokenUnitsToAmount(units *big.Int, decimals uint8) Amount {
+	r := new(big.Rat).SetFrac(units, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	return Amount(*r)
+}
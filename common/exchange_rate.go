@@ -1,16 +1,110 @@
 package common
 
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+)
+
+var errInvalidExchangeRate = errors.New("exchange rate must be a positive decimal number or fraction")
+
 // ExchangeRate defines an exchange rate between ETH and XMR.
 // It is defined as the ratio of ETH:XMR that the node wishes to provide.
 // ie. an ExchangeRate of 0.1 means that the node considers 1 ETH = 10 XMR.
-type ExchangeRate float64
+//
+// It's an exact rational number. It's encoded in JSON as a decimal string if it has a finite
+// decimal representation, and as a fraction (eg. "1/3") otherwise. For compatibility with
+// older versions, it can also be decoded from a JSON number.
+type ExchangeRate big.Rat
 
-// ToXMR converts an ether amount to a monero amount with the given exchange rate
-func (r ExchangeRate) ToXMR(ethAmount float64) float64 {
-	return ethAmount / float64(r)
+// NewExchangeRate parses a decimal string (eg. "0.1") or fraction (eg. "1/3") into an ExchangeRate.
+func NewExchangeRate(s string) (ExchangeRate, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || r.Sign() <= 0 {
+		return ExchangeRate{}, errInvalidExchangeRate
+	}
+
+	return ExchangeRate(*r), nil
 }
 
-// ToETH converts a moenro amount to an eth amount with the given exchange rate
-func (r ExchangeRate) ToETH(xmrAmount float64) float64 {
-	return float64(r) * xmrAmount
+// MustNewExchangeRate parses a string into an ExchangeRate, and panics if it's invalid.
+// It's intended for constants.
+func MustNewExchangeRate(s string) ExchangeRate {
+	r, err := NewExchangeRate(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// NewExchangeRateFromRat returns the given *big.Rat as an ExchangeRate.
+func NewExchangeRateFromRat(r *big.Rat) ExchangeRate {
+	return ExchangeRate(*new(big.Rat).Set(r))
+}
+
+// Rat returns the exchange rate as a *big.Rat.
+func (r ExchangeRate) Rat() *big.Rat {
+	rat := big.Rat(r)
+	return new(big.Rat).Set(&rat)
+}
+
+// IsZero returns true if the exchange rate is unset.
+func (r ExchangeRate) IsZero() bool {
+	return r.Rat().Sign() == 0
+}
+
+// ToXMR converts an ether amount to a monero amount with the given exchange rate,
+// rounding down to a piconero.
+func (r ExchangeRate) ToXMR(ethAmount Amount) Amount {
+	if r.IsZero() {
+		return Amount{}
+	}
+
+	xmr := new(big.Rat).Quo(ethAmount.Rat(), r.Rat())
+	return Amount(*floorToDecimals(xmr, numMoneroDecimals))
+}
+
+// ToETH converts a monero amount to an eth amount with the given exchange rate,
+// rounding down to a wei.
+func (r ExchangeRate) ToETH(xmrAmount Amount) Amount {
+	eth := new(big.Rat).Mul(xmrAmount.Rat(), r.Rat())
+	return Amount(*floorToDecimals(eth, numEtherDecimals))
+}
+
+// String returns the exchange rate as a decimal string if it has a finite decimal representation,
+// and as a fraction otherwise.
+func (r ExchangeRate) String() string {
+	rat := r.Rat()
+	if amt, err := newAmountFromRat(rat); err == nil {
+		return amt.String()
+	}
+
+	return rat.RatString()
+}
+
+// MarshalJSON ...
+func (r ExchangeRate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON ...
+func (r *ExchangeRate) UnmarshalJSON(b []byte) error {
+	s, _, err := decodeDecimalJSON(b)
+	if err != nil {
+		return err
+	}
+
+	if s == "" {
+		return nil
+	}
+
+	// a swap's exchange rate is zero until it's known, so zero is allowed here
+	rat, ok := new(big.Rat).SetString(s)
+	if !ok || rat.Sign() < 0 {
+		return errInvalidExchangeRate
+	}
+
+	*r = ExchangeRate(*rat)
+	return nil
 }
@@ -1,6 +1,11 @@
 package common
 
-import "errors"
+import (
+	"errors"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
 
 // ProvidesCoin represents a coin that a swap participant can provide.
 type ProvidesCoin string
@@ -10,14 +15,43 @@ var (
 	ProvidesETH ProvidesCoin = "ETH" //nolint
 )
 
-// NewProvidesCoin converts a string to a ProvidesCoin.
+// erc20Prefix prefixes the token address in the ProvidesCoin of an ERC-20 token.
+const erc20Prefix = "ERC20:"
+
+// NewProvidesCoin converts a string to a ProvidesCoin. ERC-20 tokens are given as
+// "ERC20:" followed by the token's contract address.
 func NewProvidesCoin(s string) (ProvidesCoin, error) {
 	switch s {
 	case "XMR":
 		return ProvidesXMR, nil
 	case "ETH":
 		return ProvidesETH, nil
-	default:
-		return "", errors.New("invalid ProvidesCoin")
 	}
+
+	if strings.HasPrefix(s, erc20Prefix) && ethcommon.IsHexAddress(s[len(erc20Prefix):]) {
+		return NewProvidesERC20(ethcommon.HexToAddress(s[len(erc20Prefix):])), nil
+	}
+
+	return "", errors.New("invalid ProvidesCoin")
+}
+
+// NewProvidesERC20 returns the ProvidesCoin for the ERC-20 token with the given contract address.
+func NewProvidesERC20(token ethcommon.Address) ProvidesCoin {
+	return ProvidesCoin(erc20Prefix + token.Hex())
+}
+
+// ERC20Token returns the contract address of the token, and true if p is an ERC-20 token.
+func (p ProvidesCoin) ERC20Token() (ethcommon.Address, bool) {
+	if !strings.HasPrefix(string(p), erc20Prefix) {
+		return ethcommon.Address{}, false
+	}
+
+	return ethcommon.HexToAddress(string(p)[len(erc20Prefix):]), true
+}
+
+// IsEthereumAsset returns true if p is ether or an ERC-20 token, ie. if it's provided by
+// locking it in the swap contract.
+func (p ProvidesCoin) IsEthereumAsset() bool {
+	_, isToken := p.ERC20Token()
+	return p == ProvidesETH || isToken
 }
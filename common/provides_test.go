@@ -0,0 +1,52 @@
+package common
+
+import (
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvidesCoin(t *testing.T) {
+	token := ethcommon.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+
+	for _, s := range []string{"XMR", "ETH", "ERC20:0xdAC17F958D2ee523a2206206994597C13D831ec7"} {
+		p, err := NewProvidesCoin(s)
+		require.NoError(t, err)
+		require.Equal(t, ProvidesCoin(s), p)
+	}
+
+	// addresses are converted to their checksummed form
+	p, err := NewProvidesCoin("ERC20:0xdac17f958d2ee523a2206206994597c13d831ec7")
+	require.NoError(t, err)
+	require.Equal(t, NewProvidesERC20(token), p)
+
+	addr, ok := p.ERC20Token()
+	require.True(t, ok)
+	require.Equal(t, token, addr)
+	require.True(t, p.IsEthereumAsset())
+	require.True(t, ProvidesETH.IsEthereumAsset())
+	require.False(t, ProvidesXMR.IsEthereumAsset())
+
+	_, ok = ProvidesETH.ERC20Token()
+	require.False(t, ok)
+
+	for _, s := range []string{"", "BTC", "ERC20:", "ERC20:0x1234"} {
+		_, err = NewProvidesCoin(s)
+		require.Error(t, err)
+	}
+}
+
+func TestTokenToUnits(t *testing.T) {
+	require.Equal(t, "1500000", TokenToUnits(MustNewAmount("1.5"), 6).String())
+	require.Equal(t, "1500000000000000000", TokenToUnits(MustNewAmount("1.5"), 18).String())
+	require.Equal(t, "1000000", TokenToUnits(MustNewAmount("1.0000009"), 6).String())
+	require.Equal(t, "0", TokenToUnits(MustNewAmount("0.0000001"), 6).String())
+}
+
+func TestTokenUnitsToAmount(t *testing.T) {
+	require.Equal(t, "1.5", TokenUnitsToAmount(big.NewInt(1500000), 6).String())
+	require.Equal(t, "0.000001", TokenUnitsToAmount(big.NewInt(1), 6).String())
+	require.Equal(t, "1", TokenUnitsToAmount(TokenToUnits(MustNewAmount("1.0000009"), 6), 6).String())
+}
@@ -58,6 +58,36 @@ func (e *Error) Error() string {
 // PostRPC posts a JSON-RPC call to the given endpoint.
 func PostRPC(endpoint, method, params string) (*ServerResponse, error) {
 	data := []byte(`{"jsonrpc":"2.0","method":"` + method + `","params":` + params + `,"id":0}`)
+	body, err := post(endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var sv *ServerResponse
+	if err = json.Unmarshal(body, &sv); err != nil {
+		return nil, err
+	}
+
+	return sv, nil
+}
+
+// PostJSON posts the given request, encoded as JSON, to the given endpoint, and decodes the
+// JSON response into res. It's used for endpoints which aren't JSON-RPC, such as some of monerod's.
+func PostJSON(endpoint string, req, res interface{}) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	body, err := post(endpoint, data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, res)
+}
+
+func post(endpoint string, data []byte) ([]byte, error) {
 	buf := &bytes.Buffer{}
 	_, err := buf.Write(data)
 	if err != nil {
@@ -88,10 +118,5 @@ func PostRPC(endpoint, method, params string) (*ServerResponse, error) {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	var sv *ServerResponse
-	if err = json.Unmarshal(body, &sv); err != nil {
-		return nil, err
-	}
-
-	return sv, nil
+	return body, nil
 }
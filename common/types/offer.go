@@ -4,9 +4,11 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/noot/atomic-swap/common"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -34,9 +36,13 @@ func HexToHash(s string) (Hash, error) {
 type Offer struct {
 	ID            Hash
 	Provides      common.ProvidesCoin
-	MinimumAmount float64
-	MaximumAmount float64
+	MinimumAmount common.Amount
+	MaximumAmount common.Amount
 	ExchangeRate  common.ExchangeRate
+	Expiry        int64 `json:",omitempty"` // unix timestamp after which the offer can't be taken; zero if none
+
+	// Token is the ERC-20 token swapped for the XMR; it's the zero address if the swap is for ether
+	Token ethcommon.Address
 }
 
 // GetID returns the ID of the offer
@@ -55,13 +61,28 @@ func (o *Offer) GetID() Hash {
 	return o.ID
 }
 
+// IsExpired returns true if the offer has an expiry time, and it has passed.
+func (o *Offer) IsExpired() bool {
+	return o.Expiry != 0 && time.Now().Unix() >= o.Expiry
+}
+
 // String ...
 func (o *Offer) String() string {
-	return fmt.Sprintf("Offer ID=%s Provides=%v MinimumAmount=%v MaximumAmount=%v ExchangeRate=%v",
+	s := fmt.Sprintf("Offer ID=%s Provides=%v MinimumAmount=%v MaximumAmount=%v ExchangeRate=%v",
 		o.ID,
 		o.Provides,
 		o.MinimumAmount,
 		o.MaximumAmount,
 		o.ExchangeRate,
 	)
+
+	if o.Token != (ethcommon.Address{}) {
+		s += fmt.Sprintf(" Token=%s", o.Token)
+	}
+
+	if o.Expiry != 0 {
+		s += fmt.Sprintf(" Expiry=%s", time.Unix(o.Expiry, 0).Format(time.RFC3339))
+	}
+
+	return s
 }
@@ -55,8 +55,78 @@ func WaitForReceipt(ctx context.Context, ethclient *ethclient.Client, txHash eth
 	return nil, false
 }
 
-// WriteContractAddressToFile writes the contract address to a file in the given basepath
-func WriteContractAddressToFile(basepath, addr string) error {
+// WaitForConfirmations waits for the given transaction to be included in the chain, and then for it to
+// have the given number of confirmations, counting the block it's included in. Once it has enough, the
+// block it's included in is checked to still be canonical; if it was re-organized out of the chain, we
+// wait for the transaction to be included again. It returns an error if the transaction failed.
+func WaitForConfirmations(ctx context.Context, ec *ethclient.Client, txHash ethcommon.Hash,
+	confirmations uint64) (*ethtypes.Receipt, error) {
+	for i := 0; i < maxRetries; i++ {
+		receipt, ok := WaitForReceipt(ctx, ec, txHash)
+		if !ok {
+			return nil, fmt.Errorf("timed out waiting for transaction %s to be included in chain", txHash)
+		}
+
+		if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+			return nil, fmt.Errorf("transaction %s failed", txHash)
+		}
+
+		confirmed, err := isConfirmed(ctx, ec, receipt, confirmations)
+		if err != nil {
+			return nil, err
+		}
+
+		if confirmed {
+			return receipt, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(receiptSleepDuration):
+		}
+	}
+
+	return nil, fmt.Errorf("timed out waiting for transaction %s to be confirmed", txHash)
+}
+
+// isConfirmed returns whether the block the given receipt is from is still canonical,
+// and has the given number of confirmations.
+func isConfirmed(ctx context.Context, ec *ethclient.Client, receipt *ethtypes.Receipt,
+	confirmations uint64) (bool, error) {
+	head, err := ec.BlockNumber(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get latest block number: %w", err)
+	}
+
+	var have uint64
+	if included := receipt.BlockNumber.Uint64(); head >= included {
+		have = head - included + 1
+	}
+
+	if have < confirmations {
+		log.Infof("waiting for transaction to be confirmed: txHash=%s confirmations=%d/%d",
+			receipt.TxHash, have, confirmations)
+		return false, nil
+	}
+
+	header, err := ec.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return false, fmt.Errorf("failed to get header: %w", err)
+	}
+
+	if header.Hash() != receipt.BlockHash {
+		log.Warnf("transaction's block was re-organized out of the chain, waiting for it to be included again: txHash=%s",
+			receipt.TxHash)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// WriteContractAddressToFile writes the contract address and the ID of the swap within the contract
+// to a file in the given basepath
+func WriteContractAddressToFile(basepath, addr, swapID string) error {
 	t := time.Now().Format("2006-Jan-2-15:04:05")
 	path := fmt.Sprintf("%s-%s.txt", basepath, t)
 
@@ -72,10 +142,12 @@ func WriteContractAddressToFile(basepath, addr string) error {
 
 	type addressFileFormat struct {
 		Address string
+		SwapID  string
 	}
 
 	bz, err := json.Marshal(addressFileFormat{
 		Address: addr,
+		SwapID:  swapID,
 	})
 	if err != nil {
 		return err
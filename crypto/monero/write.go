@@ -1,34 +1,92 @@
 package mcrypto
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/crypto"
 )
 
-// WriteKeysToFile writes the given private key pair to a file within the given path.
-func WriteKeysToFile(basepath string, keys *PrivateKeyPair, env common.Environment) error {
+// ErrKeysEncrypted is returned by ReadKeysFromFile when the keys file is encrypted, but no
+// passphrase was given.
+var ErrKeysEncrypted = errors.New("keys file is encrypted, a passphrase is required")
+
+// WriteKeysToFile writes the given private key pair to a file within the given path. If a
+// passphrase is given, the file is encrypted with it; otherwise, it's written in plaintext.
+// The file is only readable by the current user.
+func WriteKeysToFile(basepath string, keys *PrivateKeyPair, env common.Environment, passphrase string) error {
 	t := time.Now().Format("2006-Jan-2-15:04:05")
 	path := fmt.Sprintf("%s-%s.key", basepath, t)
 
 	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+	if err := os.MkdirAll(dir, 0700); err != nil {
 		return err
 	}
 
-	file, err := os.Create(filepath.Clean(path))
+	bz, err := keys.Marshal(env)
 	if err != nil {
 		return err
 	}
 
-	bz, err := keys.Marshal(env)
+	if passphrase != "" {
+		bz, err = crypto.EncryptSecret(bz, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt keys: %w", err)
+		}
+	}
+
+	file, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
 	if err != nil {
 		return err
 	}
 
-	_, err = file.Write(bz)
-	return err
+	if _, err = file.Write(bz); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// ReadKeysFromFile reads a private key pair written by WriteKeysToFile, decrypting it with
+// the given passphrase if the file is encrypted.
+func ReadKeysFromFile(path string, passphrase string) (*PrivateKeyPair, error) {
+	bz, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	if crypto.IsEncryptedSecret(bz) {
+		if passphrase == "" {
+			return nil, ErrKeysEncrypted
+		}
+
+		bz, err = crypto.DecryptSecret(bz, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt keys: %w", err)
+		}
+	}
+
+	var m map[string]string
+	if err = json.Unmarshal(bz, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse keys file: %w", err)
+	}
+
+	skBytes, err := hex.DecodeString(m["PrivateSpendKey"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid private spend key: %w", err)
+	}
+
+	vkBytes, err := hex.DecodeString(m["PrivateViewKey"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid private view key: %w", err)
+	}
+
+	return NewPrivateKeyPairFromBytes(skBytes, vkBytes)
 }
@@ -0,0 +1,60 @@
+package mcrypto
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/crypto"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeKeys(t *testing.T, kp *PrivateKeyPair, passphrase string) string {
+	dir := t.TempDir()
+	err := WriteKeysToFile(filepath.Join(dir, "1", "swap-secret"), kp, common.Development, passphrase)
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "1", "swap-secret-*.key"))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(files))
+
+	info, err := os.Stat(files[0])
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	return files[0]
+}
+
+func TestWriteKeysToFile_Encrypted(t *testing.T) {
+	kp, err := GenerateKeys()
+	require.NoError(t, err)
+
+	path := writeKeys(t, kp, "passphrase")
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(bz), kp.SpendKey().Hex())
+
+	_, err = ReadKeysFromFile(path, "")
+	require.True(t, errors.Is(err, ErrKeysEncrypted))
+
+	_, err = ReadKeysFromFile(path, "wrong")
+	require.True(t, errors.Is(err, crypto.ErrWrongPassphrase))
+
+	res, err := ReadKeysFromFile(path, "passphrase")
+	require.NoError(t, err)
+	require.Equal(t, kp.SpendKey().Hex(), res.SpendKey().Hex())
+	require.Equal(t, kp.ViewKey().Hex(), res.ViewKey().Hex())
+}
+
+func TestWriteKeysToFile_Plaintext(t *testing.T) {
+	kp, err := GenerateKeys()
+	require.NoError(t, err)
+
+	path := writeKeys(t, kp, "")
+	res, err := ReadKeysFromFile(path, "")
+	require.NoError(t, err)
+	require.Equal(t, kp.SpendKey().Hex(), res.SpendKey().Hex())
+	require.Equal(t, kp.ViewKey().Hex(), res.ViewKey().Hex())
+}
@@ -0,0 +1,59 @@
+package crypto
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// ErrWrongPassphrase is returned when decrypting a secret with the wrong passphrase.
+var ErrWrongPassphrase = errors.New("could not decrypt secret with given passphrase")
+
+// encryptedSecret is the format secrets are encrypted in, which is the same as the crypto
+// section of an ethereum keystore file: the secret is encrypted with AES-128-CTR, with a key
+// derived from the passphrase with scrypt.
+type encryptedSecret struct {
+	Crypto keystore.CryptoJSON `json:"crypto"`
+}
+
+// scrypt parameters used to derive the encryption key; tests use lighter ones.
+var (
+	scryptN = keystore.StandardScryptN
+	scryptP = keystore.StandardScryptP
+)
+
+// EncryptSecret encrypts the given secret with a key derived from the given passphrase.
+func EncryptSecret(secret []byte, passphrase string) ([]byte, error) {
+	cj, err := keystore.EncryptDataV3(secret, []byte(passphrase), scryptN, scryptP)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&encryptedSecret{Crypto: cj})
+}
+
+// DecryptSecret decrypts a secret encrypted by EncryptSecret with the given passphrase.
+func DecryptSecret(encrypted []byte, passphrase string) ([]byte, error) {
+	var es encryptedSecret
+	if err := json.Unmarshal(encrypted, &es); err != nil {
+		return nil, err
+	}
+
+	secret, err := keystore.DecryptDataV3(es.Crypto, passphrase)
+	if errors.Is(err, keystore.ErrDecrypt) {
+		return nil, ErrWrongPassphrase
+	}
+
+	return secret, err
+}
+
+// IsEncryptedSecret returns whether the given data is a secret encrypted by EncryptSecret.
+func IsEncryptedSecret(data []byte) bool {
+	var es encryptedSecret
+	if err := json.Unmarshal(data, &es); err != nil {
+		return false
+	}
+
+	return es.Crypto.Cipher != "" && es.Crypto.CipherText != ""
+}
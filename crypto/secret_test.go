@@ -0,0 +1,31 @@
+package crypto
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptSecret(t *testing.T) {
+	n, p := scryptN, scryptP
+	scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+	t.Cleanup(func() {
+		scryptN, scryptP = n, p
+	})
+
+	secret := []byte("secret")
+	encrypted, err := EncryptSecret(secret, "passphrase")
+	require.NoError(t, err)
+	require.True(t, IsEncryptedSecret(encrypted))
+	require.False(t, IsEncryptedSecret([]byte(`{"PrivateSpendKey":"aa"}`)))
+	require.NotContains(t, string(encrypted), "secret")
+
+	decrypted, err := DecryptSecret(encrypted, "passphrase")
+	require.NoError(t, err)
+	require.Equal(t, secret, decrypted)
+
+	_, err = DecryptSecret(encrypted, "wrong")
+	require.True(t, errors.Is(err, ErrWrongPassphrase))
+}
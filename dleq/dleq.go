@@ -14,7 +14,8 @@ import (
 	"github.com/noot/atomic-swap/crypto/secp256k1"
 )
 
-// Interface ...
+// Interface is a DLEq prover and verifier between the ed25519 and secp256k1 curves.
+// It is implemented by *GoDLEq, *FarcasterDLEq and *FakeDLEq.
 type Interface interface {
 	Prove() (*Proof, error)
 	Verify(*Proof) (*VerifyResult, error)
@@ -62,14 +63,16 @@ func (r *VerifyResult) Secp256k1PublicKey() *secp256k1.PublicKey {
 	return r.secp256k1Pub
 }
 
-var (
-	dleqGenBinPath    = getFarcasterDLEqBinaryPath() + "dleq-gen"
-	dleqVerifyBinPath = getFarcasterDLEqBinaryPath() + "dleq-verify"
-	defaultProofPath  = "/tmp/dleq_proof"
+const (
+	dleqGenBinName    = "dleq-gen"
+	dleqVerifyBinName = "dleq-verify"
 )
 
-// TODO: this is kinda sus, make it actually find the bin better. maybe env vars?
-func getFarcasterDLEqBinaryPath() string {
+var defaultProofPath = "/tmp/dleq_proof"
+
+// DefaultFarcasterDLEqBinaryPath returns the directory containing the farcaster-dleq binaries
+// when they're built in-tree, relative to the working directory.
+func DefaultFarcasterDLEqBinaryPath() string {
 	bin := "./farcaster-dleq/target/release/dleq-gen"
 	_, err := os.Stat(bin)
 	if !errors.Is(err, os.ErrNotExist) {
@@ -86,14 +89,27 @@ func getFarcasterDLEqBinaryPath() string {
 }
 
 // FarcasterDLEq is a wrapper around the binaries in farcaster-dleq
-type FarcasterDLEq struct{}
+type FarcasterDLEq struct {
+	// BinPath is the directory containing the dleq-gen and dleq-verify binaries.
+	// If empty, DefaultFarcasterDLEqBinaryPath is used.
+	BinPath string
+}
+
+func (d *FarcasterDLEq) binPath(name string) string {
+	dir := d.BinPath
+	if dir == "" {
+		dir = DefaultFarcasterDLEqBinaryPath()
+	}
+
+	return filepath.Join(dir, name)
+}
 
 // Prove generates a new DLEq proof
 func (d *FarcasterDLEq) Prove() (*Proof, error) {
 	t := time.Now().Format("2006-Jan-2-15:04:05")
 	path := fmt.Sprintf("%s-%s", defaultProofPath, t)
 
-	cmd := exec.Command(dleqGenBinPath, path)
+	cmd := exec.Command(d.binPath(dleqGenBinName), path)
 	if err := cmd.Run(); err != nil {
 		return nil, err
 	}
@@ -126,7 +142,7 @@ func (d *FarcasterDLEq) Verify(p *Proof) (*VerifyResult, error) {
 		return nil, err
 	}
 
-	cmd := exec.Command(dleqVerifyBinPath, path)
+	cmd := exec.Command(d.binPath(dleqVerifyBinName), path)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -163,3 +179,25 @@ func (d *FarcasterDLEq) Verify(p *Proof) (*VerifyResult, error) {
 
 	return res, nil
 }
+
+// Backend names accepted by NewBackend.
+const (
+	BackendGo        = "go"
+	BackendFarcaster = "farcaster"
+)
+
+// NewBackend returns the DLEq implementation with the given name, defaulting to farcaster.
+// binPath is the directory containing the farcaster-dleq binaries, and is only used by the
+// farcaster backend.
+func NewBackend(name, binPath string) (Interface, error) {
+	switch name {
+	case "", BackendFarcaster:
+		// the native implementation isn't verified to be compatible with farcaster's proofs yet,
+		// so farcaster stays the default to interoperate with peers using it
+		return &FarcasterDLEq{BinPath: binPath}, nil
+	case BackendGo:
+		return &GoDLEq{}, nil
+	default:
+		return nil, fmt.Errorf("unknown DLEq backend %q", name)
+	}
+}
@@ -0,0 +1,59 @@
+package dleq
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	ed25519 "filippo.io/edwards25519"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/crypto/secp256k1"
+)
+
+var errInvalidFakeProof = errors.New("invalid fake DLEq proof")
+
+// FakeDLEq is an insecure DLEq backend for use in tests. Its "proof" is the secret itself,
+// so proving and verifying is fast, but the secret is revealed to the verifier.
+// It must never be used for real swaps.
+type FakeDLEq struct{}
+
+// Prove generates a new secret and returns it as the proof.
+func (d *FakeDLEq) Prove() (*Proof, error) {
+	var x [32]byte
+	if _, err := rand.Read(x[:]); err != nil {
+		return nil, err
+	}
+
+	// zero the highest four bits, as in the real proof
+	x[31] &= 0x0f
+
+	return &Proof{
+		secret: x,
+		proof:  x[:],
+	}, nil
+}
+
+// Verify returns the public keys corresponding to the secret contained in the proof.
+func (d *FakeDLEq) Verify(p *Proof) (*VerifyResult, error) {
+	if len(p.proof) != 32 {
+		return nil, errInvalidFakeProof
+	}
+
+	s, err := ed25519.NewScalar().SetCanonicalBytes(p.proof)
+	if err != nil {
+		return nil, errInvalidFakeProof
+	}
+
+	var x [32]byte
+	copy(x[:], p.proof)
+	k := new(big.Int).SetBytes(common.Reverse(x[:]))
+	if k.Sign() == 0 {
+		return nil, errInvalidFakeProof
+	}
+
+	res := &VerifyResult{}
+	copy(res.ed25519Pub[:], new(ed25519.Point).ScalarBaseMult(s).Bytes())
+	res.secp256k1Pub = secp256k1.NewPublicKey(secp256k1BasePoint.scalarMult(k).coordinates())
+	return res, nil
+}
@@ -0,0 +1,534 @@
+package dleq
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	ed25519 "filippo.io/edwards25519"
+
+	"github.com/noot/atomic-swap/crypto/secp256k1"
+)
+
+// the secret is proven bit-by-bit; it must be less than 2^numBits, so that it's a valid scalar
+// on both curves.
+const numBits = 252
+
+var (
+	errInvalidProof     = errors.New("invalid DLEq proof")
+	errSecretTooLarge   = fmt.Errorf("secret must be less than 2^%d", numBits)
+	errInvalidEd25519PK = errors.New("ed25519 public key resulting from proof has a torsion component")
+)
+
+var (
+	// ed25519AltBasePoint is monero's H, the alternate generator G_p
+	ed25519AltBasePoint = mustDecodeEd25519Point("8b655970153799af2aeadc9ff1add0ea6c7251d54154cfa92c173a0dd39c1f94")
+
+	secp256k1BasePoint    = newSecp256k1BasePoint()
+	secp256k1AltBasePoint = newSecp256k1AltBasePoint()
+
+	// ed25519 group order minus 2^252
+	ed25519OrderLowBits = mustDecodeEd25519Scalar("edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000000")
+
+	// 2^i times the basepoint of each curve, for each bit i
+	powersOfTwoOnce      sync.Once
+	ed25519PowersOfTwo   [numBits]*ed25519.Point
+	secp256k1PowersOfTwo [numBits]*secp256k1Point
+)
+
+// GoDLEq is a native implementation of the cross-group ed25519/secp256k1 DLEq proof
+// generated and verified by the binaries in farcaster-dleq, intended to be byte-compatible with it.
+//
+// The secret x is committed to bit-by-bit on both curves, using Pedersen commitments
+// C_G_i = b_i*2^i*G + r_i*G_p and C_H_i = b_i*2^i*H + s_i*H_p, where the blinders sum to zero,
+// so that the commitments sum to xG and xH. A ring signature for each bit proves that the
+// commitments on both curves open to the same bit.
+type GoDLEq struct{}
+
+// Prove generates a new secret and a DLEq proof for it
+func (d *GoDLEq) Prove() (*Proof, error) {
+	var x [32]byte
+	if _, err := rand.Read(x[:]); err != nil {
+		return nil, err
+	}
+
+	// zero the highest four bits
+	x[31] &= 0x0f
+	return proveWithSecret(x)
+}
+
+// Verify verifies a DLEq proof
+func (d *GoDLEq) Verify(p *Proof) (*VerifyResult, error) {
+	proof, err := decodeCrossGroupProof(p.proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return proof.verify()
+}
+
+// crossGroupProof is the decoded form of a DLEq proof.
+type crossGroupProof struct {
+	cG  []*ed25519.Point
+	cH  []*secp256k1Point
+	eG0 []*ed25519.Scalar
+	eH0 []*big.Int
+	a0  []*ed25519.Scalar
+	a1  []*ed25519.Scalar
+	b0  []*big.Int
+	b1  []*big.Int
+}
+
+func newCrossGroupProof() *crossGroupProof {
+	return &crossGroupProof{
+		cG:  make([]*ed25519.Point, numBits),
+		cH:  make([]*secp256k1Point, numBits),
+		eG0: make([]*ed25519.Scalar, numBits),
+		eH0: make([]*big.Int, numBits),
+		a0:  make([]*ed25519.Scalar, numBits),
+		a1:  make([]*ed25519.Scalar, numBits),
+		b0:  make([]*big.Int, numBits),
+		b1:  make([]*big.Int, numBits),
+	}
+}
+
+func proveWithSecret(x [32]byte) (*Proof, error) {
+	if x[31]&0xf0 != 0 {
+		return nil, errSecretTooLarge
+	}
+
+	p := newCrossGroupProof()
+
+	// the final blinders are chosen such that all the blinders sum to zero
+	sumR := ed25519.NewScalar()
+	sumS := new(big.Int)
+
+	for i := 0; i < numBits; i++ {
+		var (
+			r   *ed25519.Scalar
+			s   *big.Int
+			err error
+		)
+
+		if i == numBits-1 {
+			r = ed25519.NewScalar().Negate(sumR)
+			s = new(big.Int).Sub(curve.N, sumS)
+			s.Mod(s, curve.N)
+		} else {
+			r, err = randomEd25519Scalar()
+			if err != nil {
+				return nil, err
+			}
+
+			s, err = randomSecp256k1Scalar()
+			if err != nil {
+				return nil, err
+			}
+
+			sumR.Add(sumR, r)
+			sumS.Add(sumS, s)
+			sumS.Mod(sumS, curve.N)
+		}
+
+		bit := (x[i/8]>>(i%8))&1 == 1
+		p.cG[i] = new(ed25519.Point).ScalarMult(r, ed25519AltBasePoint)
+		p.cH[i] = secp256k1AltBasePoint.scalarMult(s)
+		if bit {
+			p.cG[i].Add(p.cG[i], ed25519PowerOfTwo(i))
+			p.cH[i] = p.cH[i].add(secp256k1PowerOfTwo(i))
+		}
+
+		if err = p.sign(i, bit, r, s); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Proof{
+		secret: x,
+		proof:  p.encode(),
+	}, nil
+}
+
+// sign creates the ring signature for bit i, proving that the commitments on both curves
+// are either commitments to 0 or to 2^i, given the bit and the blinders of its commitments.
+func (p *crossGroupProof) sign(i int, bit bool, r *ed25519.Scalar, s *big.Int) error {
+	j, err := randomEd25519Scalar()
+	if err != nil {
+		return err
+	}
+
+	k, err := randomSecp256k1Scalar()
+	if err != nil {
+		return err
+	}
+
+	cG, cH := p.cG[i], p.cH[i]
+	cGMinusPower := new(ed25519.Point).Subtract(cG, ed25519PowerOfTwo(i))
+	cHMinusPower := cH.sub(secp256k1PowerOfTwo(i))
+
+	jG := new(ed25519.Point).ScalarMult(j, ed25519AltBasePoint)
+	kH := secp256k1AltBasePoint.scalarMult(k)
+
+	if !bit {
+		// we know the blinders for the 0 branch, so simulate the 1 branch
+		eG1, eH1 := ringHash(cG, cH, jG, kH)
+
+		p.a1[i], err = randomEd25519Scalar()
+		if err != nil {
+			return err
+		}
+
+		p.b1[i], err = randomSecp256k1Scalar()
+		if err != nil {
+			return err
+		}
+
+		p.eG0[i], p.eH0[i] = ringHash(cG, cH,
+			ed25519RingTerm(p.a1[i], eG1, cGMinusPower),
+			secp256k1RingTerm(p.b1[i], eH1, cHMinusPower),
+		)
+
+		p.a0[i] = ed25519.NewScalar().MultiplyAdd(p.eG0[i], r, j)
+		p.b0[i] = secp256k1MultiplyAdd(p.eH0[i], s, k)
+		return nil
+	}
+
+	// we know the blinders for the 1 branch, so simulate the 0 branch
+	p.eG0[i], p.eH0[i] = ringHash(cG, cH, jG, kH)
+
+	p.a0[i], err = randomEd25519Scalar()
+	if err != nil {
+		return err
+	}
+
+	p.b0[i], err = randomSecp256k1Scalar()
+	if err != nil {
+		return err
+	}
+
+	eG1, eH1 := ringHash(cG, cH,
+		ed25519RingTerm(p.a0[i], p.eG0[i], cG),
+		secp256k1RingTerm(p.b0[i], p.eH0[i], cH),
+	)
+
+	p.a1[i] = ed25519.NewScalar().MultiplyAdd(eG1, r, j)
+	p.b1[i] = secp256k1MultiplyAdd(eH1, s, k)
+	return nil
+}
+
+func (p *crossGroupProof) verify() (*VerifyResult, error) {
+	sumG := ed25519.NewIdentityPoint()
+	sumH := &secp256k1Point{x: new(big.Int), y: new(big.Int)}
+
+	for i := 0; i < numBits; i++ {
+		cG, cH := p.cG[i], p.cH[i]
+
+		eG1, eH1 := ringHash(cG, cH,
+			ed25519RingTerm(p.a0[i], p.eG0[i], cG),
+			secp256k1RingTerm(p.b0[i], p.eH0[i], cH),
+		)
+
+		eG0, eH0 := ringHash(cG, cH,
+			ed25519RingTerm(p.a1[i], eG1, new(ed25519.Point).Subtract(cG, ed25519PowerOfTwo(i))),
+			secp256k1RingTerm(p.b1[i], eH1, cH.sub(secp256k1PowerOfTwo(i))),
+		)
+
+		if eG0.Equal(p.eG0[i]) != 1 || eH0.Cmp(p.eH0[i]) != 0 {
+			return nil, fmt.Errorf("%w: ring signature for bit %d is invalid", errInvalidProof, i)
+		}
+
+		sumG.Add(sumG, cG)
+		sumH = sumH.add(cH)
+	}
+
+	if !isTorsionFree(sumG) {
+		return nil, errInvalidEd25519PK
+	}
+
+	if sumH.isInfinity() {
+		return nil, fmt.Errorf("%w: secp256k1 public key is the point at infinity", errInvalidProof)
+	}
+
+	res := &VerifyResult{}
+	copy(res.ed25519Pub[:], sumG.Bytes())
+	res.secp256k1Pub = secp256k1.NewPublicKey(sumH.coordinates())
+	return res, nil
+}
+
+// ringHash returns the challenge for one side of a ring signature, as a scalar on each curve.
+func ringHash(cG *ed25519.Point, cH *secp256k1Point, rG *ed25519.Point,
+	rH *secp256k1Point) (*ed25519.Scalar, *big.Int) {
+	preimage := make([]byte, 0, 130)
+	preimage = append(preimage, cG.Bytes()...)
+	preimage = append(preimage, cH.bytes()...)
+	preimage = append(preimage, rG.Bytes()...)
+	preimage = append(preimage, rH.bytes()...)
+	h := sha256.Sum256(preimage)
+
+	// the ed25519 scalar is the hash interpreted as little-endian, reduced mod the group order
+	var wide [64]byte
+	copy(wide[:], h[:])
+	eG, err := ed25519.NewScalar().SetUniformBytes(wide[:])
+	if err != nil {
+		panic(err)
+	}
+
+	return eG, secp256k1ScalarFromHash(h)
+}
+
+// ed25519RingTerm returns a*G_p - e*C
+func ed25519RingTerm(a, e *ed25519.Scalar, c *ed25519.Point) *ed25519.Point {
+	aG := new(ed25519.Point).ScalarMult(a, ed25519AltBasePoint)
+	eC := new(ed25519.Point).ScalarMult(e, c)
+	return aG.Subtract(aG, eC)
+}
+
+// secp256k1RingTerm returns b*H_p - e*C
+func secp256k1RingTerm(b, e *big.Int, c *secp256k1Point) *secp256k1Point {
+	return secp256k1AltBasePoint.scalarMult(b).sub(c.scalarMult(e))
+}
+
+// ed25519PowerOfTwo returns 2^i * G
+func ed25519PowerOfTwo(i int) *ed25519.Point {
+	powersOfTwoOnce.Do(computePowersOfTwo)
+	return ed25519PowersOfTwo[i]
+}
+
+// secp256k1PowerOfTwo returns 2^i * H
+func secp256k1PowerOfTwo(i int) *secp256k1Point {
+	powersOfTwoOnce.Do(computePowersOfTwo)
+	return secp256k1PowersOfTwo[i]
+}
+
+func computePowersOfTwo() {
+	g := ed25519.NewGeneratorPoint()
+	h := secp256k1BasePoint
+	for i := 0; i < numBits; i++ {
+		ed25519PowersOfTwo[i] = g
+		secp256k1PowersOfTwo[i] = h
+		g = new(ed25519.Point).Add(g, g)
+		h = h.add(h)
+	}
+}
+
+// isTorsionFree returns true if the point is in the prime-order subgroup, ie. l*P is the identity.
+func isTorsionFree(p *ed25519.Point) bool {
+	q := new(ed25519.Point).Set(p)
+	for i := 0; i < 252; i++ {
+		q.Add(q, q)
+	}
+
+	q.Add(q, new(ed25519.Point).ScalarMult(ed25519OrderLowBits, p))
+	return q.Equal(ed25519.NewIdentityPoint()) == 1
+}
+
+// secp256k1MultiplyAdd returns x*y + z mod N
+func secp256k1MultiplyAdd(x, y, z *big.Int) *big.Int {
+	s := new(big.Int).Mul(x, y)
+	s.Add(s, z)
+	return s.Mod(s, curve.N)
+}
+
+func randomEd25519Scalar() (*ed25519.Scalar, error) {
+	var b [64]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, err
+	}
+
+	return ed25519.NewScalar().SetUniformBytes(b[:])
+}
+
+func randomSecp256k1Scalar() (*big.Int, error) {
+	for {
+		s, err := rand.Int(rand.Reader, curve.N)
+		if err != nil {
+			return nil, err
+		}
+
+		if s.Sign() != 0 {
+			return s, nil
+		}
+	}
+}
+
+func mustDecodeEd25519Point(s string) *ed25519.Point {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	p, err := new(ed25519.Point).SetBytes(b)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+func mustDecodeEd25519Scalar(s string) *ed25519.Scalar {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	sc, err := ed25519.NewScalar().SetCanonicalBytes(b)
+	if err != nil {
+		panic(err)
+	}
+
+	return sc
+}
+
+// encode returns the canonical encoding of the proof. Each field is a list of points or scalars,
+// prefixed by its length as a little-endian uint16, in the order:
+// C_G, C_H, e_G_0, e_H_0, a_0, a_1, b_0, b_1.
+// ed25519 points are compressed (32 bytes), and secp256k1 points are compressed (33 bytes).
+// ed25519 scalars are little-endian, and secp256k1 scalars are big-endian.
+func (p *crossGroupProof) encode() []byte {
+	const size = 8*2 + numBits*(32*7+33)
+	b := make([]byte, 0, size)
+
+	b = appendLength(b)
+	for _, c := range p.cG {
+		b = append(b, c.Bytes()...)
+	}
+
+	b = appendLength(b)
+	for _, c := range p.cH {
+		b = append(b, c.bytes()...)
+	}
+
+	b = appendEd25519Scalars(b, p.eG0)
+	b = appendSecp256k1Scalars(b, p.eH0)
+	b = appendEd25519Scalars(b, p.a0)
+	b = appendEd25519Scalars(b, p.a1)
+	b = appendSecp256k1Scalars(b, p.b0)
+	b = appendSecp256k1Scalars(b, p.b1)
+	return b
+}
+
+func appendLength(b []byte) []byte {
+	var l [2]byte
+	binary.LittleEndian.PutUint16(l[:], numBits)
+	return append(b, l[:]...)
+}
+
+func appendEd25519Scalars(b []byte, scalars []*ed25519.Scalar) []byte {
+	b = appendLength(b)
+	for _, s := range scalars {
+		b = append(b, s.Bytes()...)
+	}
+	return b
+}
+
+func appendSecp256k1Scalars(b []byte, scalars []*big.Int) []byte {
+	b = appendLength(b)
+	for _, s := range scalars {
+		b = append(b, encodeSecp256k1Scalar(s)...)
+	}
+	return b
+}
+
+// proofDecoder reads the fields of an encoded proof in order.
+type proofDecoder struct {
+	b   []byte
+	err error
+}
+
+func (d *proofDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+
+	if len(d.b) < n {
+		d.err = fmt.Errorf("%w: proof is too short", errInvalidProof)
+		return nil
+	}
+
+	out := d.b[:n]
+	d.b = d.b[n:]
+	return out
+}
+
+func (d *proofDecoder) readLength() {
+	l := d.next(2)
+	if d.err != nil {
+		return
+	}
+
+	if binary.LittleEndian.Uint16(l) != numBits {
+		d.err = fmt.Errorf("%w: expected %d entries per field, got %d",
+			errInvalidProof, numBits, binary.LittleEndian.Uint16(l))
+	}
+}
+
+func (d *proofDecoder) readEd25519Scalars(out []*ed25519.Scalar) {
+	d.readLength()
+	for i := range out {
+		b := d.next(32)
+		if d.err != nil {
+			return
+		}
+
+		out[i], d.err = ed25519.NewScalar().SetCanonicalBytes(b)
+	}
+}
+
+func (d *proofDecoder) readSecp256k1Scalars(out []*big.Int) {
+	d.readLength()
+	for i := range out {
+		b := d.next(32)
+		if d.err != nil {
+			return
+		}
+
+		out[i], d.err = decodeSecp256k1Scalar(b)
+	}
+}
+
+func decodeCrossGroupProof(b []byte) (*crossGroupProof, error) {
+	p := newCrossGroupProof()
+	d := &proofDecoder{b: b}
+
+	d.readLength()
+	for i := range p.cG {
+		pb := d.next(32)
+		if d.err != nil {
+			break
+		}
+
+		p.cG[i], d.err = new(ed25519.Point).SetBytes(pb)
+	}
+
+	d.readLength()
+	for i := range p.cH {
+		pb := d.next(33)
+		if d.err != nil {
+			break
+		}
+
+		p.cH[i], d.err = decodeSecp256k1Point(pb)
+	}
+
+	d.readEd25519Scalars(p.eG0)
+	d.readSecp256k1Scalars(p.eH0)
+	d.readEd25519Scalars(p.a0)
+	d.readEd25519Scalars(p.a1)
+	d.readSecp256k1Scalars(p.b0)
+	d.readSecp256k1Scalars(p.b1)
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	if len(d.b) != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", errInvalidProof, len(d.b))
+	}
+
+	return p, nil
+}
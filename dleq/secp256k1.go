@@ -0,0 +1,134 @@
+package dleq
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+var (
+	curve = btcec.S256()
+
+	errInvalidSecp256k1Point  = errors.New("invalid secp256k1 point")
+	errInvalidSecp256k1Scalar = errors.New("invalid secp256k1 scalar")
+)
+
+// secp256k1Point is a point on the secp256k1 curve, in affine coordinates.
+// The point at infinity is represented by (0, 0).
+type secp256k1Point struct {
+	x, y *big.Int
+}
+
+func newSecp256k1BasePoint() *secp256k1Point {
+	return &secp256k1Point{
+		x: new(big.Int).Set(curve.Gx),
+		y: new(big.Int).Set(curve.Gy),
+	}
+}
+
+// newSecp256k1AltBasePoint returns the alternate generator H_p, whose discrete log with
+// respect to the basepoint is unknown. Its x-coordinate is the sha256 hash of the uncompressed
+// basepoint, and its y-coordinate is even.
+func newSecp256k1AltBasePoint() *secp256k1Point {
+	g := (&btcec.PublicKey{
+		Curve: curve,
+		X:     curve.Gx,
+		Y:     curve.Gy,
+	}).SerializeUncompressed()
+	h := sha256.Sum256(g)
+
+	p, err := decodeSecp256k1Point(append([]byte{0x02}, h[:]...))
+	if err != nil {
+		panic("alternate secp256k1 basepoint is invalid")
+	}
+
+	return p
+}
+
+func decodeSecp256k1Point(b []byte) (*secp256k1Point, error) {
+	pk, err := btcec.ParsePubKey(b, curve)
+	if err != nil {
+		return nil, errInvalidSecp256k1Point
+	}
+
+	return &secp256k1Point{
+		x: pk.X,
+		y: pk.Y,
+	}, nil
+}
+
+func (p *secp256k1Point) isInfinity() bool {
+	return p.x.Sign() == 0 && p.y.Sign() == 0
+}
+
+func (p *secp256k1Point) add(q *secp256k1Point) *secp256k1Point {
+	x, y := curve.Add(p.x, p.y, q.x, q.y)
+	return &secp256k1Point{x: x, y: y}
+}
+
+func (p *secp256k1Point) sub(q *secp256k1Point) *secp256k1Point {
+	return p.add(q.negate())
+}
+
+func (p *secp256k1Point) negate() *secp256k1Point {
+	if p.isInfinity() {
+		return p
+	}
+
+	return &secp256k1Point{
+		x: new(big.Int).Set(p.x),
+		y: new(big.Int).Sub(curve.P, p.y),
+	}
+}
+
+func (p *secp256k1Point) scalarMult(k *big.Int) *secp256k1Point {
+	x, y := curve.ScalarMult(p.x, p.y, k.Bytes())
+	return &secp256k1Point{x: x, y: y}
+}
+
+func (p *secp256k1Point) equal(q *secp256k1Point) bool {
+	return p.x.Cmp(q.x) == 0 && p.y.Cmp(q.y) == 0
+}
+
+// bytes returns the 33-byte compressed encoding of the point.
+// The point at infinity is encoded as 33 zero bytes.
+func (p *secp256k1Point) bytes() []byte {
+	if p.isInfinity() {
+		return make([]byte, 33)
+	}
+
+	return (&btcec.PublicKey{
+		Curve: curve,
+		X:     p.x,
+		Y:     p.y,
+	}).SerializeCompressed()
+}
+
+// coordinates returns the 32-byte big-endian x and y coordinates of the point.
+func (p *secp256k1Point) coordinates() (x, y [32]byte) {
+	p.x.FillBytes(x[:])
+	p.y.FillBytes(y[:])
+	return x, y
+}
+
+// secp256k1ScalarFromHash reduces a 32-byte big-endian hash modulo the group order.
+func secp256k1ScalarFromHash(h [32]byte) *big.Int {
+	s := new(big.Int).SetBytes(h[:])
+	return s.Mod(s, curve.N)
+}
+
+func decodeSecp256k1Scalar(b []byte) (*big.Int, error) {
+	s := new(big.Int).SetBytes(b)
+	if s.Cmp(curve.N) >= 0 {
+		return nil, errInvalidSecp256k1Scalar
+	}
+
+	return s, nil
+}
+
+func encodeSecp256k1Scalar(s *big.Int) []byte {
+	b := make([]byte, 32)
+	return s.FillBytes(b)
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: LGPLv3
+
+pragma solidity ^0.8.5;
+
+import "./IERC20.sol";
+
+// ERC20Mock is a minimal ERC-20 token that anyone can mint, for testing SwapERC20.
+// It can take a fee on transfers, which is burnt, to test tokens which do.
+contract ERC20Mock is IERC20 {
+    uint8 public immutable override decimals;
+    uint256 public transferFee;
+
+    mapping(address => uint256) public override balanceOf;
+    mapping(address => mapping(address => uint256)) public override allowance;
+
+    constructor(uint8 _decimals) {
+        decimals = _decimals;
+    }
+
+    function mint(address to, uint256 amount) external {
+        balanceOf[to] += amount;
+    }
+
+    function setTransferFee(uint256 fee) external {
+        transferFee = fee;
+    }
+
+    function approve(address spender, uint256 amount) external override returns (bool) {
+        allowance[msg.sender][spender] = amount;
+        return true;
+    }
+
+    function transfer(address to, uint256 amount) external override returns (bool) {
+        balanceOf[msg.sender] -= amount;
+        balanceOf[to] += amount - transferFee;
+        return true;
+    }
+
+    function transferFrom(address from, address to, uint256 amount) external override returns (bool) {
+        allowance[from][msg.sender] -= amount;
+        balanceOf[from] -= amount;
+        balanceOf[to] += amount - transferFee;
+        return true;
+    }
+}
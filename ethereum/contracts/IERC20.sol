@@ -0,0 +1,14 @@
+// SPDX-License-Identifier: LGPLv3
+
+pragma solidity ^0.8.5;
+
+// the subset of the ERC-20 interface used by SwapERC20, plus decimals() from
+// the optional metadata extension, which swapd uses to convert token amounts
+interface IERC20 {
+    function decimals() external view returns (uint8);
+    function balanceOf(address account) external view returns (uint256);
+    function allowance(address owner, address spender) external view returns (uint256);
+    function approve(address spender, uint256 amount) external returns (bool);
+    function transfer(address to, uint256 amount) external returns (bool);
+    function transferFrom(address from, address to, uint256 amount) external returns (bool);
+}
@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: LGPLv3
+
+pragma solidity ^0.8.5;
+
+import "./IERC20.sol";
+import "./Secp256k1.sol";
+
+// SwapFactory holds the funds of every swap in a single contract, instead of deploying a
+// contract per swap. each swap is stored as a struct keyed by its ID, which is returned by
+// newSwap and emitted in the New event.
+contract SwapFactory is Secp256k1 {
+    enum Stage {
+        INVALID,
+        PENDING,
+        READY,
+        COMPLETED
+    }
+
+    struct Swap {
+        // swap creator, Alice
+        address payable owner;
+
+        // address allowed to claim the funds of the swap
+        address payable claimer;
+
+        // the keccak256 hash of the expected public key derived from the secret `s_b`.
+        // this public key is a point on the secp256k1 curve
+        bytes32 pubKeyClaim;
+
+        // the keccak256 hash of the expected public key derived from the secret `s_a`.
+        // this public key is a point on the secp256k1 curve
+        bytes32 pubKeyRefund;
+
+        // timestamp (set at swap creation)
+        // before which Alice can call either setReady or refund
+        uint256 timeout0;
+
+        // timestamp after which Bob cannot claim, only Alice can refund.
+        uint256 timeout1;
+
+        // the ERC-20 token locked in the swap, or the zero address if it's ether
+        address asset;
+
+        // the amount of ether or tokens locked in the swap
+        uint256 value;
+
+        // Alice sets the stage to READY when she sees the funds locked on the other chain.
+        // this prevents Bob from withdrawing funds without locking funds on the other chain first
+        Stage stage;
+    }
+
+    mapping(bytes32 => Swap) public swaps;
+
+    // number of swaps created, used to derive unique swap IDs
+    uint256 public swapCount;
+
+    event New(
+        bytes32 indexed swapID,
+        bytes32 claimKey,
+        bytes32 refundKey,
+        uint256 timeout0,
+        uint256 timeout1,
+        address asset,
+        uint256 value
+    );
+    event Ready(bytes32 indexed swapID);
+    event Claimed(bytes32 indexed swapID, bytes32 s);
+    event Refunded(bytes32 indexed swapID, bytes32 s);
+
+    // newSwap locks `_value` of `_asset` in a new swap and returns its ID. if `_asset` is the
+    // zero address, the swap is for ether and `_value` must be sent with the call; otherwise,
+    // the caller must approve this contract to spend `_value` of the token beforehand.
+    function newSwap(
+        bytes32 _pubKeyClaim,
+        bytes32 _pubKeyRefund,
+        address payable _claimer,
+        uint256 _timeoutDuration,
+        address _asset,
+        uint256 _value
+    ) external payable returns (bytes32) {
+        require(_value > 0, "value must be non-zero");
+
+        if (_asset == address(0)) {
+            require(msg.value == _value, "value not same as ether sent");
+        } else {
+            require(msg.value == 0, "cannot send ether to a token swap");
+
+            // the tokens of every swap are held together, so we must receive exactly what the
+            // swap pays out, which isn't the case for tokens taking a fee on transfers
+            uint256 balance = IERC20(_asset).balanceOf(address(this));
+            callToken(_asset, abi.encodeWithSelector(
+                IERC20.transferFrom.selector, msg.sender, address(this), _value));
+            require(
+                IERC20(_asset).balanceOf(address(this)) - balance == _value,
+                "received token amount not same as value"
+            );
+        }
+
+        bytes32 swapID = keccak256(abi.encode(address(this), swapCount));
+        swapCount++;
+
+        Swap storage swap = swaps[swapID];
+        swap.owner = payable(msg.sender);
+        swap.claimer = _claimer;
+        swap.pubKeyClaim = _pubKeyClaim;
+        swap.pubKeyRefund = _pubKeyRefund;
+        swap.timeout0 = block.timestamp + _timeoutDuration;
+        swap.timeout1 = block.timestamp + (_timeoutDuration * 2);
+        swap.asset = _asset;
+        swap.value = _value;
+        swap.stage = Stage.PENDING;
+
+        emit New(swapID, _pubKeyClaim, _pubKeyRefund, swap.timeout0, swap.timeout1, _asset, _value);
+        return swapID;
+    }
+
+    // Alice must call setReady() within t_0 once she verifies the XMR has been locked
+    function setReady(bytes32 _swapID) external {
+        Swap storage swap = swaps[_swapID];
+        require(swap.stage == Stage.PENDING, "swap is not pending");
+        require(msg.sender == swap.owner, "only the swap owner can set ready");
+        swap.stage = Stage.READY;
+        emit Ready(_swapID);
+    }
+
+    // Bob can claim if:
+    // - Alice doesn't call setReady or refund within t_0, or
+    // - Alice calls ready within t_0, in which case Bob can call claim until t_1
+    function claim(bytes32 _swapID, bytes32 _s) external {
+        Swap storage swap = swaps[_swapID];
+        require(swap.stage == Stage.PENDING || swap.stage == Stage.READY, "swap is not in progress");
+        require(msg.sender == swap.claimer, "only claimer can claim!");
+        require((block.timestamp >= swap.timeout0 || swap.stage == Stage.READY), "too early to claim!");
+        require(block.timestamp < swap.timeout1, "too late to claim!");
+
+        verifySecret(_s, swap.pubKeyClaim);
+        swap.stage = Stage.COMPLETED;
+        emit Claimed(_swapID, _s);
+
+        // send funds to caller (Bob)
+        transfer(swap.asset, swap.claimer, swap.value);
+    }
+
+    // Alice can claim a refund:
+    // - Until t_0 unless she calls setReady
+    // - After t_1, if she called setReady
+    function refund(bytes32 _swapID, bytes32 _s) external {
+        Swap storage swap = swaps[_swapID];
+        require(swap.stage == Stage.PENDING || swap.stage == Stage.READY, "swap is not in progress");
+        require(msg.sender == swap.owner, "only the swap owner can refund");
+        require(
+            block.timestamp >= swap.timeout1 || (block.timestamp < swap.timeout0 && swap.stage != Stage.READY),
+            "It's Bob's turn now, please wait!"
+        );
+
+        verifySecret(_s, swap.pubKeyRefund);
+        swap.stage = Stage.COMPLETED;
+        emit Refunded(_swapID, _s);
+
+        // send funds back to owner==caller (Alice)
+        transfer(swap.asset, swap.owner, swap.value);
+    }
+
+    function verifySecret(bytes32 _s, bytes32 pubKey) internal pure {
+        require(
+            mulVerify(uint256(_s), uint256(pubKey)),
+            "provided secret does not match the expected pubKey"
+        );
+    }
+
+    function transfer(address _asset, address payable _to, uint256 _value) internal {
+        if (_asset == address(0)) {
+            _to.transfer(_value);
+        } else {
+            callToken(_asset, abi.encodeWithSelector(IERC20.transfer.selector, _to, _value));
+        }
+    }
+
+    // some tokens, such as USDT, don't return a bool from transfer and transferFrom,
+    // so we accept an empty return value as success, as long as the token is a contract
+    function callToken(address _token, bytes memory data) internal {
+        require(_token.code.length > 0, "token is not a contract");
+        (bool success, bytes memory ret) = _token.call(data);
+        require(
+            success && (ret.length == 0 || abi.decode(ret, (bool))),
+            "token transfer failed"
+        );
+    }
+}
@@ -8,15 +8,26 @@ import (
 
 // Client represents a monero-wallet-rpc client.
 type Client interface {
-	GetAccounts() (*getAccountsResponse, error)
-	GetAddress(idx uint) (*getAddressResponse, error)
+	GetAccounts() (*GetAccountsResponse, error)
+	GetAddress(idx uint) (*GetAddressResponse, error)
 	GetBalance(idx uint) (*GetBalanceResponse, error)
 	Transfer(to mcrypto.Address, accountIdx, amount uint) (*TransferResponse, error)
 	GenerateFromKeys(kp *mcrypto.PrivateKeyPair, filename, password string, env common.Environment) error
 	GenerateViewOnlyWalletFromKeys(vk *mcrypto.PrivateViewKey, address mcrypto.Address, filename, password string) error
 	GetHeight() (uint, error)
+	GetIncomingTransfers(accountIdx uint) ([]*Transfer, error)
+	GetTransfers(req *GetTransfersRequest) (*GetTransfersResponse, error)
+	GetTransferByTxID(txID string, accountIdx uint) (*Transfer, error)
+	IncomingTransfers(transferType IncomingTransferType, accountIdx uint) ([]*IncomingTransfer, error)
+	GetTxKey(txID string) (string, error)
+	CheckTxKey(txID, txKey string, address mcrypto.Address) (*CheckTxKeyResponse, error)
+	SweepAll(to mcrypto.Address, accountIdx uint) (*SweepAllResponse, error)
+	CreateAddress(accountIdx uint, label string) (*CreateAddressResponse, error)
+	ValidateAddress(address string) (*ValidateAddressResponse, error)
+	GetVersion() (*GetVersionResponse, error)
 	Refresh() error
 	OpenWallet(filename, password string) error
+	Store() error
 	CloseWallet() error
 }
 
@@ -31,7 +42,7 @@ func NewClient(endpoint string) *client { //nolint:revive
 	}
 }
 
-func (c *client) GetAccounts() (*getAccountsResponse, error) {
+func (c *client) GetAccounts() (*GetAccountsResponse, error) {
 	return c.callGetAccounts()
 }
 
@@ -57,7 +68,7 @@ func (c *client) GenerateViewOnlyWalletFromKeys(vk *mcrypto.PrivateViewKey, addr
 	return c.callGenerateFromKeys(nil, vk, address, filename, password)
 }
 
-func (c *client) GetAddress(idx uint) (*getAddressResponse, error) {
+func (c *client) GetAddress(idx uint) (*GetAddressResponse, error) {
 	return c.callGetAddress(idx)
 }
 
@@ -84,21 +95,77 @@ func (c *client) OpenWallet(filename, password string) error {
 	return c.callOpenWallet(filename, password)
 }
 
+// Store saves the open wallet to its file.
+func (c *client) Store() error {
+	return c.callRPC("store", nil, nil)
+}
+
+// CloseWallet saves and closes the open wallet.
 func (c *client) CloseWallet() error {
-	const method = "close_wallet"
+	return c.callRPC("close_wallet", nil, nil)
+}
 
-	resp, err := rpcclient.PostRPC(c.endpoint, method, "{}")
+func (c *client) GetHeight() (uint, error) {
+	return c.callGetHeight()
+}
+
+// GetIncomingTransfers returns the transfers received by the given account which have been
+// included in the chain.
+func (c *client) GetIncomingTransfers(accountIdx uint) ([]*Transfer, error) {
+	res, err := c.callGetTransfers(&GetTransfersRequest{
+		In:           true,
+		AccountIndex: accountIdx,
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if resp.Error != nil {
-		return resp.Error
-	}
+	return res.In, nil
+}
 
-	return nil
+// CheckTxKey returns the amount sent to the given address by the given transaction, using the
+// transaction's secret key, and how many confirmations the transaction has.
+func (c *client) CheckTxKey(txID, txKey string, address mcrypto.Address) (*CheckTxKeyResponse, error) {
+	return c.callCheckTxKey(txID, txKey, address)
 }
 
-func (c *client) GetHeight() (uint, error) {
-	return c.callGetHeight()
+// GetTransfers returns the wallet's transfers selected by the given request.
+func (c *client) GetTransfers(req *GetTransfersRequest) (*GetTransfersResponse, error) {
+	return c.callGetTransfers(req)
+}
+
+// GetTransferByTxID returns the wallet's transfer in the given transaction.
+func (c *client) GetTransferByTxID(txID string, accountIdx uint) (*Transfer, error) {
+	return c.callGetTransferByTxID(txID, accountIdx)
+}
+
+// IncomingTransfers returns the outputs of the given type received by the given account.
+func (c *client) IncomingTransfers(transferType IncomingTransferType, accountIdx uint) ([]*IncomingTransfer, error) {
+	return c.callIncomingTransfers(transferType, accountIdx)
+}
+
+// GetTxKey returns the secret key of a transaction sent from the wallet, which proves
+// the amount it sent to an address.
+func (c *client) GetTxKey(txID string) (string, error) {
+	return c.callGetTxKey(txID)
+}
+
+// SweepAll sends the whole unlocked balance of the given account to the given address.
+func (c *client) SweepAll(to mcrypto.Address, accountIdx uint) (*SweepAllResponse, error) {
+	return c.callSweepAll(to, accountIdx)
+}
+
+// CreateAddress creates a new subaddress in the given account.
+func (c *client) CreateAddress(accountIdx uint, label string) (*CreateAddressResponse, error) {
+	return c.callCreateAddress(accountIdx, label)
+}
+
+// ValidateAddress checks whether the given address is valid, for any network type.
+func (c *client) ValidateAddress(address string) (*ValidateAddressResponse, error) {
+	return c.callValidateAddress(address)
+}
+
+// GetVersion returns the RPC version of monero-wallet-rpc.
+func (c *client) GetVersion() (*GetVersionResponse, error) {
+	return c.callGetVersion()
 }
@@ -2,6 +2,9 @@ package monero
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/noot/atomic-swap/common/rpcclient"
 )
@@ -9,6 +12,7 @@ import (
 // DaemonClient represents a monerod client.
 type DaemonClient interface {
 	GenerateBlocks(address string, amount uint) error
+	GetUnlockTime(txID string) (uint64, error)
 }
 
 type generateBlocksRequest struct {
@@ -44,3 +48,71 @@ func (c *client) callGenerateBlocks(address string, amount uint) error {
 
 	return nil
 }
+
+// GetUnlockTime returns the unlock time of the given transaction, which is either a block
+// height or a timestamp before which its outputs can't be spent. It's 0 for a transaction whose
+// outputs are spendable as soon as they have the usual 10 confirmations.
+func (c *client) GetUnlockTime(txID string) (uint64, error) {
+	txs, err := c.callGetTransactions([]string{txID})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(txs) != 1 {
+		return 0, fmt.Errorf("failed to find transaction %s", txID)
+	}
+
+	var tx *transactionJSON
+	if err = json.Unmarshal([]byte(txs[0].AsJSON), &tx); err != nil {
+		return 0, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+
+	return tx.UnlockTime, nil
+}
+
+type getTransactionsRequest struct {
+	TxHashes     []string `json:"txs_hashes"`
+	DecodeAsJSON bool     `json:"decode_as_json"`
+}
+
+type transactionEntry struct {
+	AsJSON string `json:"as_json"`
+	InPool bool   `json:"in_pool"`
+	TxHash string `json:"tx_hash"`
+}
+
+type getTransactionsResponse struct {
+	Status string              `json:"status"`
+	Txs    []*transactionEntry `json:"txs"`
+}
+
+// transactionJSON is the part of a transaction, decoded as JSON by monerod, that we use
+type transactionJSON struct {
+	UnlockTime uint64 `json:"unlock_time"`
+}
+
+// callGetTransactions calls monerod's get_transactions endpoint, which isn't part of its
+// JSON-RPC interface, so it's posted to the daemon's base endpoint instead of /json_rpc.
+func (c *client) callGetTransactions(txIDs []string) ([]*transactionEntry, error) {
+	endpoint := strings.TrimSuffix(c.endpoint, "/json_rpc") + "/get_transactions"
+
+	req := &getTransactionsRequest{
+		TxHashes:     txIDs,
+		DecodeAsJSON: true,
+	}
+
+	var res *getTransactionsResponse
+	if err := rpcclient.PostJSON(endpoint, req, &res); err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, errors.New("got empty response from get_transactions")
+	}
+
+	if res.Status != "OK" {
+		return nil, fmt.Errorf("failed to get transactions: status=%s", res.Status)
+	}
+
+	return res.Txs, nil
+}
@@ -78,6 +78,7 @@ type transferRequest struct {
 	Destinations []Destination `json:"destinations"`
 	AccountIndex uint          // optional
 	Priority     uint          `json:"priority"`
+	GetTxKey     bool          `json:"get_tx_key"`
 }
 
 // TransferResponse ...
@@ -101,6 +102,7 @@ func (c *client) callTransfer(destinations []Destination, accountIdx uint) (*Tra
 		Destinations: destinations,
 		AccountIndex: accountIdx,
 		Priority:     0,
+		GetTxKey:     true,
 	}
 
 	params, err := json.Marshal(req)
@@ -131,9 +133,9 @@ type getBalanceRequest struct {
 
 // GetBalanceResponse ...
 type GetBalanceResponse struct {
-	Balance         float64                  `json:"balance"`
+	Balance         uint64                   `json:"balance"`
 	BlocksToUnlock  uint                     `json:"blocks_to_unlock"`
-	UnlockedBalance float64                  `json:"unlocked_balance"`
+	UnlockedBalance uint64                   `json:"unlocked_balance"`
 	PerSubaddress   []map[string]interface{} `json:"per_subaddress"`
 }
 
@@ -170,11 +172,21 @@ type getAddressRequest struct {
 	AccountIndex uint `json:"account_index"`
 }
 
-type getAddressResponse struct {
-	Address string `json:"address"`
+// GetAddressResponse is the response to get_address
+type GetAddressResponse struct {
+	Address   string            `json:"address"`
+	Addresses []*SubaddressInfo `json:"addresses"`
+}
+
+// SubaddressInfo describes a subaddress of an account
+type SubaddressInfo struct {
+	Address      string `json:"address"`
+	AddressIndex uint   `json:"address_index"`
+	Label        string `json:"label"`
+	Used         bool   `json:"used"`
 }
 
-func (c *client) callGetAddress(idx uint) (*getAddressResponse, error) {
+func (c *client) callGetAddress(idx uint) (*GetAddressResponse, error) {
 	const method = "get_address"
 
 	req := &getAddressRequest{
@@ -195,7 +207,7 @@ func (c *client) callGetAddress(idx uint) (*getAddressResponse, error) {
 		return nil, resp.Error
 	}
 
-	var res *getAddressResponse
+	var res *GetAddressResponse
 	if err = json.Unmarshal(resp.Result, &res); err != nil {
 		return nil, err
 	}
@@ -203,11 +215,24 @@ func (c *client) callGetAddress(idx uint) (*getAddressResponse, error) {
 	return res, nil
 }
 
-type getAccountsResponse struct {
-	SubaddressAccounts []map[string]interface{} `json:"subaddress_accounts"`
+// GetAccountsResponse is the response to get_accounts
+type GetAccountsResponse struct {
+	SubaddressAccounts   []*SubaddressAccount `json:"subaddress_accounts"`
+	TotalBalance         uint64               `json:"total_balance"`
+	TotalUnlockedBalance uint64               `json:"total_unlocked_balance"`
+}
+
+// SubaddressAccount describes an account of the open wallet
+type SubaddressAccount struct {
+	AccountIndex    uint   `json:"account_index"`
+	BaseAddress     string `json:"base_address"`
+	Balance         uint64 `json:"balance"`
+	UnlockedBalance uint64 `json:"unlocked_balance"`
+	Label           string `json:"label"`
+	Tag             string `json:"tag"`
 }
 
-func (c *client) callGetAccounts() (*getAccountsResponse, error) {
+func (c *client) callGetAccounts() (*GetAccountsResponse, error) {
 	const method = "get_accounts"
 
 	resp, err := rpcclient.PostRPC(c.endpoint, method, "{}")
@@ -219,7 +244,7 @@ func (c *client) callGetAccounts() (*getAccountsResponse, error) {
 		return nil, resp.Error
 	}
 
-	var res *getAccountsResponse
+	var res *GetAccountsResponse
 	if err = json.Unmarshal(resp.Result, &res); err != nil {
 		return nil, err
 	}
@@ -280,3 +305,323 @@ func (c *client) callGetHeight() (uint, error) {
 
 	return res.Height, nil
 }
+
+// GetTransfersRequest selects the transfers returned by get_transfers
+type GetTransfersRequest struct {
+	In             bool   `json:"in"`
+	Out            bool   `json:"out"`
+	Pending        bool   `json:"pending"`
+	Failed         bool   `json:"failed"`
+	Pool           bool   `json:"pool"`
+	AccountIndex   uint   `json:"account_index"`
+	SubaddrIndices []uint `json:"subaddr_indices,omitempty"`
+}
+
+// SubaddressIndex is the index of a subaddress; major is the account index, minor the
+// subaddress' index within the account
+type SubaddressIndex struct {
+	Major uint `json:"major"`
+	Minor uint `json:"minor"`
+}
+
+// Transfer represents a transfer to or from a wallet, as returned by get_transfers
+type Transfer struct {
+	Address         string          `json:"address"`
+	Amount          uint64          `json:"amount"`
+	Confirmations   uint64          `json:"confirmations"`
+	DoubleSpendSeen bool            `json:"double_spend_seen"`
+	Fee             uint64          `json:"fee"`
+	Height          uint64          `json:"height"`
+	Locked          bool            `json:"locked"`
+	Note            string          `json:"note"`
+	PaymentID       string          `json:"payment_id"`
+	SubaddrIndex    SubaddressIndex `json:"subaddr_index"`
+	Timestamp       uint64          `json:"timestamp"`
+	TxID            string          `json:"txid"`
+	Type            string          `json:"type"`
+	UnlockTime      uint64          `json:"unlock_time"`
+}
+
+// GetTransfersResponse is the response to get_transfers; each list is only set if it was
+// selected in the request
+type GetTransfersResponse struct {
+	In      []*Transfer `json:"in"`
+	Out     []*Transfer `json:"out"`
+	Pending []*Transfer `json:"pending"`
+	Failed  []*Transfer `json:"failed"`
+	Pool    []*Transfer `json:"pool"`
+}
+
+func (c *client) callGetTransfers(req *GetTransfersRequest) (*GetTransfersResponse, error) {
+	const method = "get_transfers"
+
+	var res *GetTransfersResponse
+	if err := c.callRPC(method, req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+type checkTxKeyRequest struct {
+	TxID    string `json:"txid"`
+	TxKey   string `json:"tx_key"`
+	Address string `json:"address"`
+}
+
+// CheckTxKeyResponse is the result of checking a transaction's key against an address
+type CheckTxKeyResponse struct {
+	Confirmations uint64 `json:"confirmations"`
+	InPool        bool   `json:"in_pool"`
+	Received      uint64 `json:"received"`
+}
+
+func (c *client) callCheckTxKey(txID, txKey string, address mcrypto.Address) (*CheckTxKeyResponse, error) {
+	const method = "check_tx_key"
+
+	req := &checkTxKeyRequest{
+		TxID:    txID,
+		TxKey:   txKey,
+		Address: string(address),
+	}
+
+	var res *CheckTxKeyResponse
+	if err := c.callRPC(method, req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// callRPC calls the given method with the given request, which is sent as an empty object if nil,
+// and decodes the result into res, unless it's nil.
+func (c *client) callRPC(method string, req, res interface{}) error {
+	params := []byte("{}")
+	if req != nil {
+		var err error
+		params, err = json.Marshal(req)
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := rpcclient.PostRPC(c.endpoint, method, string(params))
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	if res == nil {
+		return nil
+	}
+
+	return json.Unmarshal(resp.Result, res)
+}
+
+type getTxKeyRequest struct {
+	TxID string `json:"txid"`
+}
+
+type getTxKeyResponse struct {
+	TxKey string `json:"tx_key"`
+}
+
+func (c *client) callGetTxKey(txID string) (string, error) {
+	const method = "get_tx_key"
+
+	var res *getTxKeyResponse
+	if err := c.callRPC(method, &getTxKeyRequest{TxID: txID}, &res); err != nil {
+		return "", err
+	}
+
+	return res.TxKey, nil
+}
+
+type getTransferByTxIDRequest struct {
+	TxID         string `json:"txid"`
+	AccountIndex uint   `json:"account_index"`
+}
+
+type getTransferByTxIDResponse struct {
+	Transfer *Transfer `json:"transfer"`
+}
+
+func (c *client) callGetTransferByTxID(txID string, accountIdx uint) (*Transfer, error) {
+	const method = "get_transfer_by_txid"
+
+	req := &getTransferByTxIDRequest{
+		TxID:         txID,
+		AccountIndex: accountIdx,
+	}
+
+	var res *getTransferByTxIDResponse
+	if err := c.callRPC(method, req, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Transfer, nil
+}
+
+// IncomingTransferType selects the outputs returned by incoming_transfers
+type IncomingTransferType string
+
+// Types of outputs returned by incoming_transfers
+const (
+	IncomingTransfersAll         IncomingTransferType = "all"
+	IncomingTransfersAvailable   IncomingTransferType = "available"
+	IncomingTransfersUnavailable IncomingTransferType = "unavailable"
+)
+
+type incomingTransfersRequest struct {
+	TransferType IncomingTransferType `json:"transfer_type"`
+	AccountIndex uint                 `json:"account_index"`
+}
+
+// IncomingTransfer is an output received by the wallet, as returned by incoming_transfers
+type IncomingTransfer struct {
+	Amount       uint64          `json:"amount"`
+	BlockHeight  uint64          `json:"block_height"`
+	Frozen       bool            `json:"frozen"`
+	GlobalIndex  uint64          `json:"global_index"`
+	KeyImage     string          `json:"key_image"`
+	PubKey       string          `json:"pubkey"`
+	Spent        bool            `json:"spent"`
+	SubaddrIndex SubaddressIndex `json:"subaddr_index"`
+	TxHash       string          `json:"tx_hash"`
+	Unlocked     bool            `json:"unlocked"`
+}
+
+type incomingTransfersResponse struct {
+	Transfers []*IncomingTransfer `json:"transfers"`
+}
+
+func (c *client) callIncomingTransfers(transferType IncomingTransferType,
+	accountIdx uint) ([]*IncomingTransfer, error) {
+	const method = "incoming_transfers"
+
+	req := &incomingTransfersRequest{
+		TransferType: transferType,
+		AccountIndex: accountIdx,
+	}
+
+	var res *incomingTransfersResponse
+	if err := c.callRPC(method, req, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Transfers, nil
+}
+
+type createAddressRequest struct {
+	AccountIndex uint   `json:"account_index"`
+	Label        string `json:"label,omitempty"`
+}
+
+// CreateAddressResponse is the response to create_address
+type CreateAddressResponse struct {
+	Address      string `json:"address"`
+	AddressIndex uint   `json:"address_index"`
+}
+
+func (c *client) callCreateAddress(accountIdx uint, label string) (*CreateAddressResponse, error) {
+	const method = "create_address"
+
+	req := &createAddressRequest{
+		AccountIndex: accountIdx,
+		Label:        label,
+	}
+
+	var res *CreateAddressResponse
+	if err := c.callRPC(method, req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+type validateAddressRequest struct {
+	Address        string `json:"address"`
+	AnyNetType     bool   `json:"any_net_type"`
+	AllowOpenalias bool   `json:"allow_openalias"`
+}
+
+// ValidateAddressResponse is the response to validate_address
+type ValidateAddressResponse struct {
+	Valid            bool   `json:"valid"`
+	Integrated       bool   `json:"integrated"`
+	Subaddress       bool   `json:"subaddress"`
+	NetType          string `json:"nettype"`
+	OpenaliasAddress string `json:"openalias_address"`
+}
+
+func (c *client) callValidateAddress(address string) (*ValidateAddressResponse, error) {
+	const method = "validate_address"
+
+	req := &validateAddressRequest{
+		Address:    address,
+		AnyNetType: true,
+	}
+
+	var res *ValidateAddressResponse
+	if err := c.callRPC(method, req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetVersionResponse is the response to get_version
+type GetVersionResponse struct {
+	Version uint32 `json:"version"`
+	Release bool   `json:"release"`
+}
+
+func (c *client) callGetVersion() (*GetVersionResponse, error) {
+	const method = "get_version"
+
+	var res *GetVersionResponse
+	if err := c.callRPC(method, nil, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+type sweepAllRequest struct {
+	Address      string `json:"address"`
+	AccountIndex uint   `json:"account_index"`
+	Priority     uint   `json:"priority"`
+	GetTxKeys    bool   `json:"get_tx_keys"`
+}
+
+// SweepAllResponse is the response to sweep_all. A sweep may be split over several
+// transactions, so each field has one entry per transaction.
+type SweepAllResponse struct {
+	AmountList []uint64 `json:"amount_list"`
+	FeeList    []uint64 `json:"fee_list"`
+	TxHashList []string `json:"tx_hash_list"`
+	TxKeyList  []string `json:"tx_key_list"`
+	WeightList []uint64 `json:"weight_list"`
+	TxBlobList []string `json:"tx_blob_list"`
+}
+
+func (c *client) callSweepAll(to mcrypto.Address, accountIdx uint) (*SweepAllResponse, error) {
+	const method = "sweep_all"
+
+	req := &sweepAllRequest{
+		Address:      string(to),
+		AccountIndex: accountIdx,
+		Priority:     0,
+		GetTxKeys:    true,
+	}
+
+	var res *SweepAllResponse
+	if err := c.callRPC(method, req, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
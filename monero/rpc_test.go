@@ -2,11 +2,15 @@ package monero
 
 import (
 	"crypto/rand"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/rpcclient"
 	mcrypto "github.com/noot/atomic-swap/crypto/monero"
 
 	"github.com/stretchr/testify/require"
@@ -24,3 +28,62 @@ func TestCallGenerateFromKeys(t *testing.T) {
 		fmt.Sprintf("test-wallet-%d", r), "")
 	require.NoError(t, err)
 }
+
+// newMockWalletRPC returns a client for a server which responds to each method with the given
+// JSON-RPC result, or with an error for unknown methods.
+func newMockWalletRPC(t *testing.T, results map[string]string) *client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		result, has := results[req.Method]
+		if !has {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":0,"error":{"code":-13,"message":"No wallet file"}}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":` + result + `}`))
+	}))
+	t.Cleanup(server.Close)
+	return NewClient(server.URL)
+}
+
+func TestClient_TypedResponses(t *testing.T) {
+	c := newMockWalletRPC(t, map[string]string{
+		"get_version":      `{"version":196613,"release":true}`,
+		"validate_address": `{"valid":true,"integrated":false,"subaddress":true,"nettype":"stagenet"}`,
+		"sweep_all":        `{"amount_list":[1000],"fee_list":[10],"tx_hash_list":["aa"],"tx_key_list":["bb"]}`,
+		"get_tx_key":       `{"tx_key":"bb"}`,
+		"store":            `{}`,
+	})
+
+	version, err := c.GetVersion()
+	require.NoError(t, err)
+	require.Equal(t, uint32(196613), version.Version)
+	require.True(t, version.Release)
+
+	valid, err := c.ValidateAddress("address")
+	require.NoError(t, err)
+	require.True(t, valid.Valid)
+	require.True(t, valid.Subaddress)
+	require.Equal(t, "stagenet", valid.NetType)
+
+	sweep, err := c.SweepAll("address", 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1000}, sweep.AmountList)
+	require.Equal(t, []string{"aa"}, sweep.TxHashList)
+	require.Equal(t, []string{"bb"}, sweep.TxKeyList)
+
+	txKey, err := c.GetTxKey("aa")
+	require.NoError(t, err)
+	require.Equal(t, "bb", txKey)
+
+	require.NoError(t, c.Store())
+
+	err = c.CloseWallet()
+	var rpcErr *rpcclient.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, rpcclient.ErrCode(-13), rpcErr.ErrorCode)
+}
@@ -1,7 +1,9 @@
 package monero
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/noot/atomic-swap/common"
@@ -11,8 +13,9 @@ import (
 )
 
 const (
-	maxRetries         = 360
-	blockSleepDuration = time.Second * 10
+	maxRetries               = 360
+	blockSleepDuration       = time.Second * 10
+	defaultSweepPollInterval = time.Minute
 )
 
 var (
@@ -43,26 +46,148 @@ func WaitForBlocks(client Client) error {
 	return fmt.Errorf("timed out waiting for next block")
 }
 
-// CreateMoneroWallet creates a monero wallet from a private keypair.
+// CreateMoneroWallet creates a monero wallet from a private keypair, which is left open.
+// It returns the wallet's address and the name of its file.
 func CreateMoneroWallet(name string, env common.Environment, client Client,
-	kpAB *mcrypto.PrivateKeyPair) (mcrypto.Address, error) {
+	kpAB *mcrypto.PrivateKeyPair) (mcrypto.Address, string, error) {
 	t := time.Now().Format("2006-Jan-2-15:04:05")
 	walletName := fmt.Sprintf("%s-%s", name, t)
 	if err := client.GenerateFromKeys(kpAB, walletName, "", env); err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	log.Info("created wallet: ", walletName)
 
 	if err := client.Refresh(); err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	balance, err := client.GetBalance(0)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
 	log.Info("wallet balance: ", balance.Balance)
-	return kpAB.Address(env), nil
+	return kpAB.Address(env), walletName, nil
+}
+
+// SweepConfig contains the configuration values for WaitAndSweep
+type SweepConfig struct {
+	Ctx        context.Context
+	Client     Client
+	WalletName string          // wallet to sweep; it must have an empty password
+	To         mcrypto.Address // address to sweep to
+
+	// WalletMu is held while the wallet being swept is open, as monero-wallet-rpc only has one
+	// wallet open at a time.
+	WalletMu sync.Locker
+
+	// Restore, if set, is called with WalletMu held after the wallet being swept is closed, to
+	// reopen the wallet that's usually open.
+	Restore func() error
+
+	// PollInterval is how often to check whether the wallet's balance is unlocked; if unset,
+	// it defaults to 1 minute.
+	PollInterval time.Duration
+}
+
+// WaitAndSweep waits for the whole balance of the given wallet to be unlocked, then sends all of it
+// to the given address. It returns the hashes of the sweep transactions.
+func WaitAndSweep(cfg *SweepConfig) ([]string, error) {
+	pollInterval := cfg.PollInterval
+	if pollInterval == 0 {
+		pollInterval = defaultSweepPollInterval
+	}
+
+	for {
+		res, err := trySweep(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		if res != nil {
+			log.Infof("swept wallet %s to %s: txs=%v amounts=%v", cfg.WalletName, cfg.To,
+				res.TxHashList, res.AmountList)
+			return res.TxHashList, nil
+		}
+
+		select {
+		case <-cfg.Ctx.Done():
+			return nil, cfg.Ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// trySweep sweeps the wallet if its whole balance is unlocked. It returns nil if it isn't yet.
+func trySweep(cfg *SweepConfig) (*SweepAllResponse, error) {
+	cfg.WalletMu.Lock()
+	defer cfg.WalletMu.Unlock()
+
+	if err := cfg.Client.OpenWallet(cfg.WalletName, ""); err != nil {
+		return nil, fmt.Errorf("failed to open wallet %s: %w", cfg.WalletName, err)
+	}
+
+	res, err := sweepIfUnlocked(cfg.Client, cfg.To)
+
+	if closeErr := cfg.Client.CloseWallet(); closeErr != nil {
+		log.Warnf("failed to close wallet %s: err=%s", cfg.WalletName, closeErr)
+	}
+
+	if cfg.Restore != nil {
+		if restoreErr := cfg.Restore(); restoreErr != nil {
+			log.Warnf("failed to reopen wallet after sweeping: err=%s", restoreErr)
+		}
+	}
+
+	return res, err
+}
+
+func sweepIfUnlocked(client Client, to mcrypto.Address) (*SweepAllResponse, error) {
+	if err := client.Refresh(); err != nil {
+		return nil, fmt.Errorf("failed to refresh wallet: %w", err)
+	}
+
+	balance, err := client.GetBalance(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	if balance.Balance == 0 || balance.UnlockedBalance < balance.Balance {
+		log.Debugf("waiting for wallet balance to unlock before sweeping: balance=%d unlocked=%d blocks=%d",
+			balance.Balance, balance.UnlockedBalance, balance.BlocksToUnlock)
+		return nil, nil
+	}
+
+	res, err := client.SweepAll(to, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sweep wallet: %w", err)
+	}
+
+	return res, nil
+}
+
+// ValidateSweepAddress checks that the given address is a valid monero address for the given
+// environment, so that swept XMR can't be sent to an address on another network.
+func ValidateSweepAddress(client Client, address mcrypto.Address, env common.Environment) error {
+	res, err := client.ValidateAddress(string(address))
+	if err != nil {
+		return fmt.Errorf("failed to validate address: %w", err)
+	}
+
+	if !res.Valid {
+		return fmt.Errorf("invalid monero address %s", address)
+	}
+
+	// development nodes run in regtest mode, which uses mainnet addresses
+	expected := "mainnet"
+	if env == common.Stagenet {
+		expected = "stagenet"
+	}
+
+	if res.NetType != expected {
+		return fmt.Errorf("address %s is for %s, expected %s", address, res.NetType, expected)
+	}
+
+	return nil
 }
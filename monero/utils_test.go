@@ -0,0 +1,58 @@
+package monero
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitAndSweep(t *testing.T) {
+	c := newMockWalletRPC(t, map[string]string{
+		"open_wallet":  `{}`,
+		"refresh":      `{}`,
+		"get_balance":  `{"balance":1000,"unlocked_balance":1000}`,
+		"sweep_all":    `{"amount_list":[990],"fee_list":[10],"tx_hash_list":["aa"],"tx_key_list":["bb"]}`,
+		"close_wallet": `{}`,
+	})
+
+	restored := false
+	txHashes, err := WaitAndSweep(&SweepConfig{
+		Ctx:        context.Background(),
+		Client:     c,
+		WalletName: "swap-wallet",
+		To:         "address",
+		WalletMu:   &sync.Mutex{},
+		Restore: func() error {
+			restored = true
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"aa"}, txHashes)
+	require.True(t, restored)
+}
+
+func TestWaitAndSweep_Locked(t *testing.T) {
+	c := newMockWalletRPC(t, map[string]string{
+		"open_wallet":  `{}`,
+		"refresh":      `{}`,
+		"get_balance":  `{"balance":1000,"unlocked_balance":0,"blocks_to_unlock":10}`,
+		"close_wallet": `{}`,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*200)
+	defer cancel()
+
+	_, err := WaitAndSweep(&SweepConfig{
+		Ctx:          ctx,
+		Client:       c,
+		WalletName:   "swap-wallet",
+		To:           "address",
+		WalletMu:     &sync.Mutex{},
+		PollInterval: time.Millisecond * 50,
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
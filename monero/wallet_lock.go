@@ -0,0 +1,61 @@
+package monero
+
+import (
+	"sync"
+)
+
+// WalletLock serializes the use of a monero-wallet-rpc instance, which only has one wallet open at
+// a time, between everything using it, eg. the Alice and Bob instances of a swap daemon. It tracks
+// the main wallet, which is the one usually open, so that it's reopened after another wallet, such
+// as a swap wallet, has been used.
+type WalletLock struct {
+	mu     sync.Mutex
+	client Client
+
+	mainWallet, mainPassword string
+}
+
+// NewWalletLock returns a new *WalletLock for the monero-wallet-rpc instance of the given client.
+func NewWalletLock(client Client) *WalletLock {
+	return &WalletLock{
+		client: client,
+	}
+}
+
+// Lock locks the wallet, so that the open wallet isn't changed until it's unlocked.
+func (l *WalletLock) Lock() {
+	l.mu.Lock()
+}
+
+// Unlock unlocks the wallet.
+func (l *WalletLock) Unlock() {
+	l.mu.Unlock()
+}
+
+// MainWallet returns the name of the main wallet, or an empty string if it isn't set. It must be
+// called with the lock held.
+func (l *WalletLock) MainWallet() string {
+	return l.mainWallet
+}
+
+// SetMainWallet opens the given wallet, and sets it as the main wallet. It must be called with the
+// lock held.
+func (l *WalletLock) SetMainWallet(name, password string) error {
+	_ = l.client.CloseWallet()
+	if err := l.client.OpenWallet(name, password); err != nil {
+		return err
+	}
+
+	l.mainWallet, l.mainPassword = name, password
+	return nil
+}
+
+// RestoreMainWallet closes the open wallet, if any, and reopens the main wallet if it's set. It
+// must be called with the lock held, after using another wallet.
+func (l *WalletLock) RestoreMainWallet() error {
+	if l.mainWallet == "" {
+		return nil
+	}
+
+	return l.SetMainWallet(l.mainWallet, l.mainPassword)
+}
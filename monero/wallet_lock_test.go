@@ -0,0 +1,26 @@
+package monero
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletLock(t *testing.T) {
+	c := newMockWalletRPC(t, map[string]string{
+		"open_wallet":  `{}`,
+		"close_wallet": `{}`,
+	})
+
+	l := NewWalletLock(c)
+	l.Lock()
+	defer l.Unlock()
+
+	// there's nothing to restore until the main wallet is set
+	require.NoError(t, l.RestoreMainWallet())
+	require.Equal(t, "", l.MainWallet())
+
+	require.NoError(t, l.SetMainWallet("main", "password"))
+	require.Equal(t, "main", l.MainWallet())
+	require.NoError(t, l.RestoreMainWallet())
+}
@@ -27,11 +27,12 @@ type discovery struct {
 	dht         *dual.DHT
 	h           libp2phost.Host
 	rd          *libp2pdiscovery.RoutingDiscovery
-	provides    []common.ProvidesCoin
+	handler     Handler // may be nil, if we don't make offers
 	advertiseCh chan struct{}
 }
 
-func newDiscovery(ctx context.Context, h libp2phost.Host, bnsFunc func() []peer.AddrInfo) (*discovery, error) {
+func newDiscovery(ctx context.Context, h libp2phost.Host, bnsFunc func() []peer.AddrInfo,
+	handler Handler) (*discovery, error) {
 	dhtOpts := []dual.Option{
 		dual.DHTOption(kaddht.BootstrapPeersFunc(bnsFunc)),
 		dual.DHTOption(kaddht.Mode(kaddht.ModeAutoServer)),
@@ -49,7 +50,8 @@ func newDiscovery(ctx context.Context, h libp2phost.Host, bnsFunc func() []peer.
 		dht:         dht,
 		h:           h,
 		rd:          rd,
-		advertiseCh: make(chan struct{}),
+		handler:     handler,
+		advertiseCh: make(chan struct{}, 1),
 	}, nil
 }
 
@@ -71,6 +73,35 @@ func (d *discovery) stop() error {
 	return d.dht.Close()
 }
 
+// provides returns the coins provided by our current offers.
+func (d *discovery) provides() []common.ProvidesCoin {
+	if d.handler == nil {
+		return nil
+	}
+
+	var provides []common.ProvidesCoin
+	seen := make(map[common.ProvidesCoin]struct{})
+	for _, o := range d.handler.GetOffers() {
+		if _, has := seen[o.Provides]; has {
+			continue
+		}
+
+		seen[o.Provides] = struct{}{}
+		provides = append(provides, o.Provides)
+	}
+
+	return provides
+}
+
+// readvertise triggers an advertisement of our current offers, without waiting for it to complete.
+func (d *discovery) readvertise() {
+	select {
+	case d.advertiseCh <- struct{}{}:
+	default:
+		// an advertisement is already pending
+	}
+}
+
 func (d *discovery) advertise() {
 	ttl := initialAdvertisementTimeout
 
@@ -82,8 +113,10 @@ func (d *discovery) advertise() {
 			return
 		}
 
-		for _, provides := range d.provides {
-			ttl, err = d.rd.Advertise(d.ctx, string(provides))
+		// the set of offers may have changed since the last advertisement
+		provides := d.provides()
+		for _, coin := range provides {
+			ttl, err = d.rd.Advertise(d.ctx, string(coin))
 			if err != nil {
 				log.Debugf("failed to advertise in the DHT: err=%s", err)
 				ttl = tryAdvertiseTimeout
@@ -91,7 +124,7 @@ func (d *discovery) advertise() {
 			}
 		}
 
-		if len(d.provides) == 0 {
+		if len(provides) == 0 {
 			ttl, err = d.rd.Advertise(d.ctx, "")
 			if err != nil {
 				log.Debugf("failed to advertise in the DHT: err=%s", err)
@@ -106,8 +139,6 @@ func (d *discovery) advertise() {
 	for {
 		select {
 		case <-d.advertiseCh:
-			// TODO: check current offers, as this may change
-			d.provides = []common.ProvidesCoin{common.ProvidesXMR}
 			doAdvertise()
 		case <-time.After(ttl):
 			doAdvertise()
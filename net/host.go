@@ -41,7 +41,7 @@ type Host interface {
 
 // MessageSender is implemented by a Host
 type MessageSender interface {
-	SendSwapMessage(Message) error
+	SendSwapMessage(id uint64, msg Message) error
 }
 
 type host struct {
@@ -54,10 +54,9 @@ type host struct {
 	discovery *discovery
 	handler   Handler
 
-	// swap instance info
-	swapMu     sync.Mutex
-	swapState  SwapState
-	swapStream libp2pnetwork.Stream
+	// swaps currently in progress, keyed by swap ID
+	swapMu sync.Mutex
+	swaps  map[uint64]*swap
 
 	queryMu  sync.Mutex
 	queryBuf []byte
@@ -122,10 +121,11 @@ func NewHost(cfg *Config) (*host, error) { //nolint:revive
 		h:          h,
 		handler:    cfg.Handler,
 		bootnodes:  bns,
+		swaps:      make(map[uint64]*swap),
 		queryBuf:   make([]byte, 2048),
 	}
 
-	hst.discovery, err = newDiscovery(ourCtx, h, hst.getBootnodes)
+	hst.discovery, err = newDiscovery(ourCtx, h, hst.getBootnodes, cfg.Handler)
 	if err != nil {
 		return nil, err
 	}
@@ -170,8 +170,10 @@ func (h *host) Stop() error {
 	return nil
 }
 
+// Advertise re-advertises our current offers in the DHT. It should be called when the set of
+// offers changes.
 func (h *host) Advertise() {
-	h.discovery.advertiseCh <- struct{}{}
+	h.discovery.readvertise()
 }
 
 func (h *host) Addresses() []string {
@@ -188,16 +190,17 @@ func (h *host) Discover(provides common.ProvidesCoin, searchTime time.Duration)
 	return h.discovery.discover(provides, searchTime)
 }
 
-// SendSwapMessage sends a message to the peer who we're currently doing a swap with.
-func (h *host) SendSwapMessage(msg Message) error {
+// SendSwapMessage sends a message to the peer who we're doing the swap with the given ID with.
+func (h *host) SendSwapMessage(id uint64, msg Message) error {
 	h.swapMu.Lock()
-	defer h.swapMu.Unlock()
+	sw, has := h.swaps[id]
+	h.swapMu.Unlock()
 
-	if h.swapStream == nil {
-		return errors.New("no swap currently happening")
+	if !has {
+		return fmt.Errorf("no swap with ID %d currently happening", id)
 	}
 
-	return h.writeToStream(h.swapStream, msg)
+	return sw.write(h, msg)
 }
 
 func (h *host) getBootnodes() []peer.AddrInfo {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/noot/atomic-swap/common/types"
@@ -19,10 +20,11 @@ const (
 )
 
 // Handler handles swap initiation messages.
-// It is implemented by *bob.bob
+// It is implemented by *alice.Instance and *bob.Instance, and by *protocol.Handler, which passes
+// each swap to whichever of them made the offer being taken.
 type Handler interface {
 	GetOffers() []*types.Offer
-	HandleInitiateMessage(msg *SendKeysMessage) (s SwapState, resp Message, err error)
+	HandleInitiateMessage(who peer.ID, msg *SendKeysMessage) (s SwapState, resp Message, err error)
 }
 
 // SwapState handles incoming protocol messages for an initiated protocol.
@@ -36,14 +38,45 @@ type SwapState interface {
 	ID() uint64
 }
 
-func (h *host) Initiate(who peer.AddrInfo, msg *SendKeysMessage, s SwapState) error {
+// swap is a swap that's currently in progress, along with the stream to the counterparty.
+type swap struct {
+	swapState SwapState
+	stream    libp2pnetwork.Stream
+
+	// writes to the stream can happen from the protocol goroutine as well as
+	// from timeout goroutines within the SwapState
+	writeMu sync.Mutex
+}
+
+func (sw *swap) write(h *host, msg Message) error {
+	sw.writeMu.Lock()
+	defer sw.writeMu.Unlock()
+	return h.writeToStream(sw.stream, msg)
+}
+
+func (h *host) addSwap(s SwapState, stream libp2pnetwork.Stream) (*swap, error) {
 	h.swapMu.Lock()
 	defer h.swapMu.Unlock()
 
-	if h.swapState != nil {
-		return errors.New("already have ongoing swap")
+	if _, has := h.swaps[s.ID()]; has {
+		return nil, fmt.Errorf("already have ongoing swap with ID %d", s.ID())
 	}
 
+	sw := &swap{
+		swapState: s,
+		stream:    stream,
+	}
+	h.swaps[s.ID()] = sw
+	return sw, nil
+}
+
+func (h *host) removeSwap(id uint64) {
+	h.swapMu.Lock()
+	defer h.swapMu.Unlock()
+	delete(h.swaps, id)
+}
+
+func (h *host) Initiate(who peer.AddrInfo, msg *SendKeysMessage, s SwapState) error {
 	ctx, cancel := context.WithTimeout(h.ctx, protocolTimeout)
 	defer cancel()
 
@@ -60,13 +93,20 @@ func (h *host) Initiate(who peer.AddrInfo, msg *SendKeysMessage, s SwapState) er
 		"opened protocol stream, peer=", who.ID,
 	)
 
-	if err := h.writeToStream(stream, msg); err != nil {
+	sw, err := h.addSwap(s, stream)
+	if err != nil {
+		_ = stream.Close()
+		return err
+	}
+
+	if err := sw.write(h, msg); err != nil {
 		log.Warnf("failed to send initial SendKeysMessage to peer: err=%s", err)
+		h.removeSwap(s.ID())
+		_ = stream.Close()
 		return err
 	}
 
-	h.swapState = s
-	go h.handleProtocolStreamInner(stream)
+	go h.handleProtocolStreamInner(stream, sw)
 	return nil
 }
 
@@ -76,26 +116,20 @@ func (h *host) handleProtocolStream(stream libp2pnetwork.Stream) {
 		return
 	}
 
-	h.swapMu.Lock()
-	defer h.swapMu.Unlock()
-
-	if h.swapState != nil {
-		log.Debug("failed to handling incoming swap stream, already have ongoing swap")
-	}
-
-	h.handleProtocolStreamInner(stream)
+	h.handleProtocolStreamInner(stream, nil)
 }
 
 // handleProtocolStreamInner is called to handle a protocol stream, in both ingoing and outgoing cases.
-func (h *host) handleProtocolStreamInner(stream libp2pnetwork.Stream) {
+// For incoming streams, sw is nil until the initiating SendKeysMessage has been handled.
+func (h *host) handleProtocolStreamInner(stream libp2pnetwork.Stream, sw *swap) {
 	defer func() {
 		log.Debugf("closing stream: peer=%s protocol=%s", stream.Conn().RemotePeer(), stream.Protocol())
 		_ = stream.Close()
-		if h.swapState != nil {
-			if err := h.swapState.ProtocolExited(); err != nil {
-				log.Errorf("failed to exit protocol: err=%s", err)
+		if sw != nil {
+			if err := sw.swapState.ProtocolExited(); err != nil {
+				log.Errorf("failed to exit protocol: id=%d err=%s", sw.swapState.ID(), err)
 			}
-			h.swapState = nil
+			h.removeSwap(sw.swapState.ID())
 		}
 	}()
 
@@ -119,12 +153,18 @@ func (h *host) handleProtocolStreamInner(stream libp2pnetwork.Stream) {
 			"received message from peer, peer=", stream.Conn().RemotePeer(), " type=", msg.Type(),
 		)
 
+		if rm, ok := msg.(*SwapRejected); ok {
+			log.Warnf("swap rejected by peer: peer=%s reason=%s message=%s",
+				stream.Conn().RemotePeer(), rm.Reason, rm.Message)
+			return
+		}
+
 		var (
 			resp Message
 			done bool
 		)
 
-		if h.swapState == nil {
+		if sw == nil {
 			im, ok := msg.(*SendKeysMessage)
 			if !ok {
 				log.Warnf("failed to handle protocol message: message was not SendKeysMessage")
@@ -132,17 +172,28 @@ func (h *host) handleProtocolStreamInner(stream libp2pnetwork.Stream) {
 			}
 
 			var s SwapState
-			s, resp, err = h.handler.HandleInitiateMessage(im)
+			s, resp, err = h.handler.HandleInitiateMessage(stream.Conn().RemotePeer(), im)
 			if err != nil {
 				log.Warnf("failed to handle protocol message: err=%s", err)
+				if rm := rejection(err); rm != nil {
+					_ = h.writeToStream(stream, rm)
+				}
 				return
 			}
 
-			h.swapState = s
+			sw, err = h.addSwap(s, stream)
+			if err != nil {
+				log.Warnf("failed to handle protocol message: err=%s", err)
+				_ = s.ProtocolExited()
+				return
+			}
 		} else {
-			resp, done, err = h.swapState.HandleProtocolMessage(msg)
+			resp, done, err = sw.swapState.HandleProtocolMessage(msg)
 			if err != nil {
 				log.Warnf("failed to handle protocol message: err=%s", err)
+				if rm := rejection(err); rm != nil {
+					_ = sw.write(h, rm)
+				}
 				return
 			}
 		}
@@ -151,7 +202,7 @@ func (h *host) handleProtocolStreamInner(stream libp2pnetwork.Stream) {
 			continue
 		}
 
-		if err := h.writeToStream(stream, resp); err != nil {
+		if err := sw.write(h, resp); err != nil {
 			log.Warnf("failed to send response to peer: err=%s", err)
 			return
 		}
@@ -162,3 +213,17 @@ func (h *host) handleProtocolStreamInner(stream libp2pnetwork.Stream) {
 		}
 	}
 }
+
+// rejection returns the SwapRejected message to send to the counterparty if err is a *RejectError,
+// or nil otherwise.
+func rejection(err error) *SwapRejected {
+	var rerr *RejectError
+	if !errors.As(err, &rerr) {
+		return nil
+	}
+
+	return &SwapRejected{
+		Reason:  rerr.Reason,
+		Message: rerr.Message,
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/noot/atomic-swap/common"
 	"github.com/noot/atomic-swap/common/types"
 )
 
@@ -19,6 +20,8 @@ const (
 	NotifyReadyType
 	NotifyClaimedType
 	NotifyRefundType
+	SwapRejectedType
+	NotifyKeysAcceptedType
 )
 
 func (t MessageType) String() string {
@@ -35,6 +38,10 @@ func (t MessageType) String() string {
 		return "NotifyClaimed"
 	case NotifyRefundType:
 		return "NotifyRefund"
+	case SwapRejectedType:
+		return "SwapRejected"
+	case NotifyKeysAcceptedType:
+		return "NotifyKeysAccepted"
 	default:
 		return "unknown"
 	}
@@ -89,6 +96,18 @@ func decodeMessage(b []byte) (Message, error) {
 			return nil, err
 		}
 		return m, nil
+	case SwapRejectedType:
+		var m *SwapRejected
+		if err := json.Unmarshal(b[1:], &m); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case NotifyKeysAcceptedType:
+		var m *NotifyKeysAccepted
+		if err := json.Unmarshal(b[1:], &m); err != nil {
+			return nil, err
+		}
+		return m, nil
 	default:
 		return nil, errors.New("invalid message type")
 	}
@@ -127,7 +146,7 @@ func (m *QueryResponse) Type() MessageType {
 // SendKeysMessage is sent by both parties to each other to initiate the protocol
 type SendKeysMessage struct {
 	OfferID            string
-	ProvidedAmount     float64
+	ProvidedAmount     common.Amount
 	PublicSpendKey     string
 	PublicViewKey      string
 	PrivateViewKey     string
@@ -165,10 +184,11 @@ func (m *SendKeysMessage) Type() MessageType {
 	return SendKeysMessageType
 }
 
-// NotifyContractDeployed is sent by Alice to Bob after deploying the swap contract
-// and locking her ether in it
+// NotifyContractDeployed is sent by Alice to Bob after creating the swap in the swap contract
+// and locking her ether in it. The swap is identified by its ID within the contract.
 type NotifyContractDeployed struct {
 	Address string
+	SwapID  string
 }
 
 // String ...
@@ -191,9 +211,12 @@ func (m *NotifyContractDeployed) Type() MessageType {
 	return NotifyContractDeployedType
 }
 
-// NotifyXMRLock is sent by Bob to Alice after locking his XMR.
+// NotifyXMRLock is sent by Bob to Alice after locking his XMR. It includes the hash and secret
+// key of the lock transaction, so that Alice can check the amount sent to the address.
 type NotifyXMRLock struct {
 	Address string
+	TxHash  string
+	TxKey   string
 }
 
 // String ...
@@ -216,6 +239,30 @@ func (m *NotifyXMRLock) Type() MessageType {
 	return NotifyXMRLockType
 }
 
+// NotifyKeysAccepted is sent by Bob to Alice when Bob took Alice's offer, after receiving and
+// verifying her keys. It tells Alice to go ahead and deploy the contract.
+type NotifyKeysAccepted struct{}
+
+// String ...
+func (m *NotifyKeysAccepted) String() string {
+	return "NotifyKeysAccepted"
+}
+
+// Encode ...
+func (m *NotifyKeysAccepted) Encode() ([]byte, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{byte(NotifyKeysAcceptedType)}, b...), nil
+}
+
+// Type ...
+func (m *NotifyKeysAccepted) Type() MessageType {
+	return NotifyKeysAcceptedType
+}
+
 // NotifyReady is sent by Alice to Bob after calling Ready() on the contract.
 type NotifyReady struct{}
 
@@ -288,3 +335,30 @@ func (m *NotifyRefund) Encode() ([]byte, error) {
 func (m *NotifyRefund) Type() MessageType {
 	return NotifyRefundType
 }
+
+// SwapRejected is sent by either party when it rejects the swap, for example because the
+// counterparty's amounts don't match the offer. The stream is closed after it's sent.
+type SwapRejected struct {
+	Reason  RejectReason
+	Message string
+}
+
+// String ...
+func (m *SwapRejected) String() string {
+	return fmt.Sprintf("SwapRejected Reason=%s Message=%s", m.Reason, m.Message)
+}
+
+// Encode ...
+func (m *SwapRejected) Encode() ([]byte, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{byte(SwapRejectedType)}, b...), nil
+}
+
+// Type ...
+func (m *SwapRejected) Type() MessageType {
+	return SwapRejectedType
+}
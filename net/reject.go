@@ -0,0 +1,63 @@
+package net
+
+import (
+	"fmt"
+)
+
+// RejectReason is the reason a swap was rejected.
+type RejectReason byte
+
+const (
+	// RejectUnknown is used when no more specific reason applies.
+	RejectUnknown RejectReason = iota
+	// RejectOfferUnavailable means the offer doesn't exist, or is already being taken.
+	RejectOfferUnavailable
+	// RejectAmountOutOfRange means the amount is outside of the offer's minimum and maximum.
+	RejectAmountOutOfRange
+	// RejectAmountMismatch means the counterparty's amount doesn't match the offer's exchange rate.
+	RejectAmountMismatch
+	// RejectDeclined means the user declined the swap, or didn't confirm it in time.
+	RejectDeclined
+)
+
+// String ...
+func (r RejectReason) String() string {
+	switch r {
+	case RejectOfferUnavailable:
+		return "offer unavailable"
+	case RejectAmountOutOfRange:
+		return "amount out of range"
+	case RejectAmountMismatch:
+		return "amount mismatch"
+	case RejectDeclined:
+		return "declined"
+	default:
+		return "unknown"
+	}
+}
+
+// RejectError is an error which rejects a swap. When a Handler or SwapState returns a RejectError,
+// it's sent to the counterparty as a SwapRejected message before the stream is closed.
+type RejectError struct {
+	Reason  RejectReason
+	Message string
+}
+
+// NewRejectError returns a new *RejectError with a formatted message.
+func NewRejectError(reason RejectReason, format string, args ...interface{}) *RejectError {
+	return &RejectError{
+		Reason:  reason,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// Error ...
+func (e *RejectError) Error() string {
+	return fmt.Sprintf("swap rejected (%s): %s", e.Reason, e.Message)
+}
+
+// Is returns true if target is a *RejectError with the same reason.
+func (e *RejectError) Is(target error) bool {
+	t, ok := target.(*RejectError)
+	return ok && t.Reason == e.Reason
+}
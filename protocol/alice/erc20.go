@@ -0,0 +1,89 @@
+package alice
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/noot/atomic-swap/common"
+	pcommon "github.com/noot/atomic-swap/protocol"
+	"github.com/noot/atomic-swap/swap-contract"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// etherDecimals is the number of decimals of ether, ie. the number of decimals of wei in an ether.
+const etherDecimals = 18
+
+// balanceOf returns our balance of the given ERC-20 token, or of ether if token is the zero
+// address, along with the given amount of it converted to its smallest units, and the number of
+// decimals it uses.
+func (a *Instance) balanceOf(token ethcommon.Address, amount common.Amount) (balance, units *big.Int,
+	decimals uint8, err error) {
+	if token == (ethcommon.Address{}) {
+		balance, err = a.ethClient.BalanceAt(a.ctx, a.callOpts.From, nil)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		return balance, common.EtherToWei(amount).BigInt(), etherDecimals, nil
+	}
+
+	erc20, err := swap.NewIERC20Caller(token, a.ethClient)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	decimals, err = erc20.Decimals(a.callOpts)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to get decimals of token %s: %w", token, err)
+	}
+
+	balance, err = erc20.BalanceOf(a.callOpts, a.callOpts.From)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to get balance of token %s: %w", token, err)
+	}
+
+	return balance, common.TokenToUnits(amount, decimals), decimals, nil
+}
+
+// lockERC20 creates a new swap in the swap contract and locks `amount` of the given token in it.
+// The contract transfers the tokens to itself when the swap is created, so we first approve it to
+// do so. It returns the ID of the swap.
+func (s *swapState) lockERC20(token ethcommon.Address, amount common.Amount) (ethcommon.Hash, error) {
+	balance, units, _, err := s.alice.balanceOf(token, amount)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+
+	if balance.Cmp(units) < 0 {
+		return ethcommon.Hash{}, errors.New("token balance lower than amount to be provided")
+	}
+
+	address, _, err := s.alice.getSwapFactory()
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+
+	erc20, err := swap.NewIERC20(token, s.alice.ethClient)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+
+	_, err = s.sendTx(pcommon.UrgencyLow, func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+		tx, err := erc20.Approve(opts, address, units) //nolint:govet
+		if err != nil {
+			return nil, err
+		}
+
+		log.Debugf("approving SwapFactory.sol to transfer tokens, amount=%s txHash=%s", units, tx.Hash())
+		return tx, nil
+	})
+	if err != nil {
+		return ethcommon.Hash{}, fmt.Errorf("failed to approve token transfer: %w", err)
+	}
+
+	return s.newSwap(token, units)
+}
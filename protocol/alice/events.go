@@ -0,0 +1,55 @@
+package alice
+
+import (
+	pswap "github.com/noot/atomic-swap/protocol/swap"
+	"github.com/noot/atomic-swap/swap-contract"
+)
+
+// watchContract starts watching the swap contract for our swap's events, starting at the given
+// block, so that we claim our monero once Bob claims, even if he never notifies us.
+func (s *swapState) watchContract(fromBlock uint64) error {
+	w, err := swap.NewWatcher(&swap.WatcherConfig{
+		Ctx:       s.ctx,
+		Client:    s.alice.ethClient,
+		Address:   s.contractAddr,
+		SwapID:    s.contractSwapID,
+		FromBlock: fromBlock,
+	})
+	if err != nil {
+		return err
+	}
+
+	go s.handleContractEvents(w.Start())
+	return nil
+}
+
+func (s *swapState) handleContractEvents(events <-chan *swap.Event) {
+	for ev := range events {
+		if ev.Type == swap.EventClaimed {
+			// stop waiting to refund first, as we may be doing so while holding the lock
+			s.setClaimed()
+		}
+
+		if err := s.handleContractEvent(ev); err != nil {
+			log.Errorf("failed to handle contract event: id=%d event=%s err=%s", s.ID(), ev.Type, err)
+		}
+	}
+}
+
+func (s *swapState) handleContractEvent(ev *swap.Event) error {
+	s.Lock()
+	defer s.Unlock()
+
+	// the swap may have already completed, eg. upon a network message from Bob
+	if s.ctx.Err() != nil || s.info.Status() != pswap.Ongoing {
+		return nil
+	}
+
+	if ev.Type != swap.EventClaimed {
+		return nil
+	}
+
+	log.Infof("swap claimed in contract: id=%d tx=%s", s.ID(), ev.Log.TxHash)
+	_, err := s.handleClaimed(ev.Secret, ev.Log.TxHash.String())
+	return err
+}
@@ -2,18 +2,27 @@ package alice
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"errors"
+	"fmt"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 
 	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/rpcclient"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+	"github.com/noot/atomic-swap/dleq"
 	"github.com/noot/atomic-swap/monero"
 	"github.com/noot/atomic-swap/net"
-	"github.com/noot/atomic-swap/protocol/swap"
+	pcommon "github.com/noot/atomic-swap/protocol"
+	pswap "github.com/noot/atomic-swap/protocol/swap"
+	"github.com/noot/atomic-swap/swap-contract"
 
 	logging "github.com/ipfs/go-log"
 )
@@ -30,22 +39,56 @@ type Instance struct {
 	env      common.Environment
 	basepath string
 
+	// passphrase the swap secrets we write to the basepath and the swap database are encrypted
+	// with; if empty, they're written in plaintext
+	secretsPassphrase string
+
 	client monero.Client
 
-	ethPrivKey *ecdsa.PrivateKey
-	ethClient  *ethclient.Client
-	callOpts   *bind.CallOpts
-	chainID    *big.Int
-	gasPrice   *big.Int
-	gasLimit   uint64
+	// used to check the unlock time of Bob's XMR lock; nil if no monerod endpoint was configured
+	daemonClient monero.DaemonClient
+
+	signer    pcommon.Signer
+	ethClient *ethclient.Client
+	callOpts  *bind.CallOpts
+	chainID   *big.Int
+	gasPrice  *big.Int
+	gasLimit  uint64
+
+	// sends our transactions, replacing them with higher fees if they're stuck
+	txManager *pcommon.TxManager
+
+	// confirmations our ethereum transactions, and Bob's XMR lock, must have before we proceed
+	ethConfirmations uint64
+	xmrConfirmations uint64
+
+	// default address to sweep the XMR we receive to; it can be changed for each swap
+	sweepAddress mcrypto.Address
+
+	dleq dleq.Interface
+
+	// SwapFactory contract that our swaps are created in; deployed on first use if not configured
+	contractMu   sync.Mutex
+	contract     *swap.SwapFactory
+	contractAddr ethcommon.Address
+
+	// if set, each swap must be confirmed by the user before our funds are locked
+	manualConfirm  bool
+	confirmTimeout time.Duration
 
 	net net.MessageSender
 
-	// non-nil if a swap is currently happening, nil otherwise
-	swapMu    sync.Mutex
-	swapState *swapState
+	offerManager *pcommon.OfferManager
+
+	// swaps currently in progress, keyed by swap ID
+	swapMu     sync.Mutex
+	swapStates map[uint64]*swapState
 
-	swapManager *swap.Manager
+	// monero-wallet-rpc only has one wallet open at a time, so swaps must not switch the open
+	// wallet concurrently
+	walletLock *monero.WalletLock
+
+	swapManager *pswap.Manager
 }
 
 // Config contains the configuration values for a new Alice instance.
@@ -53,22 +96,62 @@ type Config struct {
 	Ctx                  context.Context
 	Basepath             string
 	MoneroWalletEndpoint string
+	MoneroDaemonEndpoint string // used to check the unlock time of Bob's XMR lock, which is skipped if unset
 	EthereumEndpoint     string
 	EthereumPrivateKey   string
+	Signer               pcommon.Signer // signs our transactions; used instead of EthereumPrivateKey if set
 	Environment          common.Environment
 	ChainID              int64
 	GasPrice             *big.Int
 	GasLimit             uint64
-	SwapManager          *swap.Manager
+	SwapManager          *pswap.Manager
+	SwapContractAddress  ethcommon.Address // SwapFactory contract to use; a new one is deployed if zero
+	DLEq                 dleq.Interface    // defaults to the farcaster-dleq binaries if nil
+	ManualConfirm        bool              // require swaps to be confirmed with swap_confirm before locking funds
+	ConfirmTimeout       time.Duration     // defaults to protocol.DefaultConfirmTimeout if zero
+
+	// confirmations our ethereum transactions, and Bob's XMR lock, must have before we
+	// proceed; each defaults to 1 if zero
+	EthereumConfirmations uint64
+	MoneroConfirmations   uint64
+
+	// FeeOracle suggests the fees for our transactions, unless a fixed gas price is set; if nil,
+	// fees are based on the ethereum node's suggestions, capped by MaxFeePerGas and
+	// MaxPriorityFeePerGas (in wei) if they're set. They're only used if TxManager is nil.
+	FeeOracle            pcommon.FeeOracle
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+
+	// TxManager sends our transactions; it must be shared by every instance sending transactions
+	// from the same account, so that they don't use the same nonces. If nil, a new one is created.
+	TxManager *pcommon.TxManager
+
+	// SecretsPassphrase encrypts the swap secrets we write to the basepath and the swap database,
+	// so that they can only be used, eg. to recover a swap, with the passphrase; if empty, they're
+	// written in plaintext.
+	SecretsPassphrase string
+
+	// SweepAddress is the address the XMR we receive is swept to once it's unlocked, unless
+	// changed for a swap; if empty, it's left in the swap wallet.
+	SweepAddress mcrypto.Address
+
+	// WalletLock must be shared by every instance using the same monero-wallet-rpc endpoint, so
+	// that they don't change its open wallet while another is using it. If nil, a new one is created.
+	WalletLock *monero.WalletLock
 }
 
 // NewInstance returns a new instance of Alice.
 // It accepts an endpoint to a monero-wallet-rpc instance where Alice will generate
 // the account in which the XMR will be deposited.
 func NewInstance(cfg *Config) (*Instance, error) {
-	pk, err := crypto.HexToECDSA(cfg.EthereumPrivateKey)
-	if err != nil {
-		return nil, err
+	signer := cfg.Signer
+	if signer == nil {
+		pk, err := crypto.HexToECDSA(cfg.EthereumPrivateKey) //nolint:govet
+		if err != nil {
+			return nil, err
+		}
+
+		signer = pcommon.NewEthereumKey(pk)
 	}
 
 	ec, err := ethclient.Dial(cfg.EthereumEndpoint)
@@ -76,25 +159,259 @@ func NewInstance(cfg *Config) (*Instance, error) {
 		return nil, err
 	}
 
-	pub := pk.Public().(*ecdsa.PublicKey)
+	txManager := cfg.TxManager
+	if txManager == nil {
+		txManager = pcommon.NewTxManager(&pcommon.TxManagerConfig{
+			Client:               ec,
+			FeeOracle:            cfg.FeeOracle,
+			From:                 signer.Address(),
+			Signer:               signer,
+			ChainID:              big.NewInt(cfg.ChainID),
+			MaxFeePerGas:         cfg.MaxFeePerGas,
+			MaxPriorityFeePerGas: cfg.MaxPriorityFeePerGas,
+		})
+	}
+
+	d := cfg.DLEq
+	if d == nil {
+		d = &dleq.FarcasterDLEq{}
+	}
+
+	ethConfirmations, xmrConfirmations := cfg.EthereumConfirmations, cfg.MoneroConfirmations
+	if ethConfirmations == 0 {
+		ethConfirmations = 1
+	}
+
+	if xmrConfirmations == 0 {
+		xmrConfirmations = 1
+	}
+
+	var daemonClient monero.DaemonClient
+	if cfg.MoneroDaemonEndpoint != "" {
+		daemonClient = monero.NewClient(cfg.MoneroDaemonEndpoint)
+	} else {
+		log.Warn("no monero daemon endpoint provided, the unlock time of locked XMR won't be checked")
+	}
+
+	client := monero.NewClient(cfg.MoneroWalletEndpoint)
+	if cfg.SweepAddress != "" {
+		if err = monero.ValidateSweepAddress(client, cfg.SweepAddress, cfg.Environment); err != nil {
+			return nil, err
+		}
+	}
+
+	walletLock := cfg.WalletLock
+	if walletLock == nil {
+		walletLock = monero.NewWalletLock(client)
+	}
+
+	chainID := big.NewInt(cfg.ChainID)
+	contractAddr := cfg.SwapContractAddress
+	if (contractAddr == ethcommon.Address{}) && cfg.SwapManager != nil {
+		contractAddr, err = getStoredSwapFactory(cfg.Ctx, ec, cfg.SwapManager, chainID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var contract *swap.SwapFactory
+	if (contractAddr != ethcommon.Address{}) {
+		contract, err = swap.NewSwapFactory(contractAddr, ec)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// TODO: check that Alice's monero-wallet-cli endpoint has wallet-dir configured
 	return &Instance{
-		ctx:        cfg.Ctx,
-		basepath:   cfg.Basepath,
-		env:        cfg.Environment,
-		ethPrivKey: pk,
-		ethClient:  ec,
-		client:     monero.NewClient(cfg.MoneroWalletEndpoint),
+		ctx:               cfg.Ctx,
+		basepath:          cfg.Basepath,
+		secretsPassphrase: cfg.SecretsPassphrase,
+		env:               cfg.Environment,
+		signer:            signer,
+		ethClient:         ec,
+		client:            client,
+		daemonClient:      daemonClient,
+		walletLock:        walletLock,
 		callOpts: &bind.CallOpts{
-			From:    crypto.PubkeyToAddress(*pub),
+			From:    signer.Address(),
 			Context: cfg.Ctx,
 		},
-		chainID:     big.NewInt(cfg.ChainID),
-		swapManager: cfg.SwapManager,
+		chainID:          chainID,
+		gasPrice:         cfg.GasPrice,
+		gasLimit:         cfg.GasLimit,
+		txManager:        txManager,
+		ethConfirmations: ethConfirmations,
+		xmrConfirmations: xmrConfirmations,
+		sweepAddress:     cfg.SweepAddress,
+		dleq:             d,
+		contract:         contract,
+		contractAddr:     contractAddr,
+		manualConfirm:    cfg.ManualConfirm,
+		confirmTimeout:   cfg.ConfirmTimeout,
+		swapManager:      cfg.SwapManager,
+		offerManager:     pcommon.NewOfferManager(),
+		swapStates:       make(map[uint64]*swapState),
 	}, nil
 }
 
+// getStoredSwapFactory returns the address of the SwapFactory contract we deployed previously on
+// the chain, or the zero address if we haven't, or it's no longer deployed, eg. on a development
+// chain which was restarted.
+func getStoredSwapFactory(ctx context.Context, ec *ethclient.Client, sm *pswap.Manager,
+	chainID *big.Int) (ethcommon.Address, error) {
+	address, err := sm.GetSwapFactoryAddress(chainID)
+	if err != nil {
+		return ethcommon.Address{}, fmt.Errorf("failed to get SwapFactory.sol address: %w", err)
+	}
+
+	if (address == ethcommon.Address{}) {
+		return address, nil
+	}
+
+	err = swap.CheckSwapFactoryContract(ctx, ec, address)
+	if err != nil && !errors.Is(err, swap.ErrNotSwapFactory) {
+		return ethcommon.Address{}, err
+	}
+
+	if err != nil {
+		log.Warnf("not reusing previously deployed SwapFactory.sol: address=%s err=%s", address, err)
+		return ethcommon.Address{}, nil
+	}
+
+	log.Infof("reusing previously deployed SwapFactory.sol: address=%s", address)
+	return address, nil
+}
+
+// getSwapFactory returns the SwapFactory contract that swaps are created in, deploying it
+// if we weren't configured with one.
+func (a *Instance) getSwapFactory() (ethcommon.Address, *swap.SwapFactory, error) {
+	a.contractMu.Lock()
+	defer a.contractMu.Unlock()
+
+	if a.contract != nil {
+		return a.contractAddr, a.contract, nil
+	}
+
+	txOpts := a.signer.TransactOpts(a.chainID)
+	txOpts.GasPrice = a.gasPrice
+	txOpts.GasLimit = a.gasLimit
+
+	// replacements of the deployment transaction have the same nonce, so deploy the contract
+	// to the same address
+	var (
+		address  ethcommon.Address
+		contract *swap.SwapFactory
+	)
+	receipt, err := a.txManager.Send(a.ctx, txOpts, pcommon.UrgencyLow, time.Time{},
+		func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+			var (
+				tx  *ethtypes.Transaction
+				err error
+			)
+			address, tx, contract, err = swap.DeploySwapFactory(opts, a.ethClient)
+			if err != nil {
+				return nil, err
+			}
+
+			log.Debugf("deploying SwapFactory.sol, txHash=%s", tx.Hash())
+			return tx, nil
+		})
+	if err != nil {
+		return ethcommon.Address{}, nil, fmt.Errorf("failed to deploy SwapFactory.sol: %w", err)
+	}
+
+	if _, err = common.WaitForConfirmations(a.ctx, a.ethClient, receipt.TxHash, a.ethConfirmations); err != nil {
+		return ethcommon.Address{}, nil, fmt.Errorf("failed to deploy SwapFactory.sol: %w", err)
+	}
+
+	log.Infof("deployed SwapFactory.sol: address=%s", address)
+	if a.swapManager != nil {
+		if err = a.swapManager.WriteSwapFactoryAddress(a.chainID, address); err != nil {
+			log.Warnf("failed to store SwapFactory.sol address, pass --contract-address=%s to reuse it: err=%s",
+				address, err)
+		}
+	}
+
+	a.contract = contract
+	a.contractAddr = address
+	return address, contract, nil
+}
+
+// checkTxKeyWallet is the wallet opened to check Bob's XMR lock transactions if no other wallet
+// is open. It's a view-only wallet for random keys, which is reused for every swap.
+const checkTxKeyWallet = "alice-check-tx-key-wallet"
+
+// walletNotOpenErrCode is the error code returned by monero-wallet-rpc if no wallet is open
+const walletNotOpenErrCode = -13
+
+// checkTxKey checks the amount sent to the given address by the given transaction. monero-wallet-rpc
+// requires a wallet to be open to do this, although the wallet isn't used, so one is opened if needed.
+func (a *Instance) checkTxKey(txID, txKey string, address mcrypto.Address) (*monero.CheckTxKeyResponse, error) {
+	a.walletLock.Lock()
+	defer a.walletLock.Unlock()
+
+	res, err := a.client.CheckTxKey(txID, txKey, address)
+	var rpcErr *rpcclient.Error
+	if !errors.As(err, &rpcErr) || rpcErr.ErrorCode != walletNotOpenErrCode {
+		return res, err
+	}
+
+	if err = a.client.OpenWallet(checkTxKeyWallet, ""); err != nil {
+		kp, err := mcrypto.GenerateKeys() //nolint:govet
+		if err != nil {
+			return nil, err
+		}
+
+		err = a.client.GenerateViewOnlyWalletFromKeys(kp.ViewKey(), kp.Address(a.env), checkTxKeyWallet, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate wallet to check transactions: %w", err)
+		}
+	}
+
+	res, err = a.client.CheckTxKey(txID, txKey, address)
+	if err != nil {
+		return nil, err
+	}
+
+	// switch back to the main wallet, if another instance has set one
+	if err = a.walletLock.RestoreMainWallet(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ValidateSweepAddress checks that XMR can be swept to the given address.
+func (a *Instance) ValidateSweepAddress(address mcrypto.Address) error {
+	return monero.ValidateSweepAddress(a.client, address, a.env)
+}
+
+// startSweep sweeps the XMR received in the given swap wallet to the swap's sweep address in the
+// background, once it's unlocked. It does nothing if the swap has no sweep address.
+func (a *Instance) startSweep(info *pswap.Info, walletName string) {
+	if info.SweepAddress() == "" {
+		return
+	}
+
+	go func() {
+		txHashes, err := monero.WaitAndSweep(&monero.SweepConfig{
+			Ctx:        a.ctx,
+			Client:     a.client,
+			WalletName: walletName,
+			To:         info.SweepAddress(),
+			WalletMu:   a.walletLock,
+			Restore:    a.walletLock.RestoreMainWallet,
+		})
+		if err != nil {
+			log.Errorf("failed to sweep XMR: id=%d wallet=%s err=%s", info.ID(), walletName, err)
+			return
+		}
+
+		info.SetSweepTxHashes(txHashes)
+	}()
+}
+
 // SetMessageSender sets the Instance's net.MessageSender interface.
 func (a *Instance) SetMessageSender(n net.MessageSender) {
 	a.net = n
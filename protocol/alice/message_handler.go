@@ -30,7 +30,26 @@ func (s *swapState) HandleProtocolMessage(msg net.Message) (net.Message, bool, e
 
 	switch msg := msg.(type) {
 	case *net.SendKeysMessage:
-		resp, err := s.handleSendKeysMessage(msg)
+		// we took Bob's offer, and this is his response to our keys
+		if err := s.handleSendKeysMessage(msg); err != nil {
+			return nil, true, err
+		}
+
+		if s.alice.manualConfirm {
+			if err := pcommon.AwaitConfirmation(s.ctx, s.info, s.alice.confirmTimeout); err != nil {
+				return nil, true, err
+			}
+		}
+
+		resp, err := s.lockETH()
+		if err != nil {
+			return nil, true, err
+		}
+
+		return resp, false, nil
+	case *net.NotifyKeysAccepted:
+		// Bob took our offer, and has accepted our keys
+		resp, err := s.lockETH()
 		if err != nil {
 			return nil, true, err
 		}
@@ -44,15 +63,15 @@ func (s *swapState) HandleProtocolMessage(msg net.Message) (net.Message, bool, e
 
 		return out, false, nil
 	case *net.NotifyClaimed:
-		address, err := s.handleNotifyClaimed(msg.TxHash)
-		if err != nil {
-			log.Error("failed to create monero address: err=", err)
+		// we may have already claimed upon seeing the contract's Claimed event
+		if s.info.Status() != pswap.Ongoing {
+			return nil, true, nil
+		}
+
+		if _, err := s.handleNotifyClaimed(msg.TxHash); err != nil {
 			return nil, true, err
 		}
 
-		close(s.claimedCh)
-		log.Info("successfully created monero wallet from our secrets: address=", address)
-		s.info.SetStatus(pswap.Success)
 		return nil, true, nil
 	default:
 		return nil, false, errors.New("unexpected message type")
@@ -67,25 +86,28 @@ func (s *swapState) checkMessageType(msg net.Message) error {
 	return nil
 }
 
-func (s *swapState) handleSendKeysMessage(msg *net.SendKeysMessage) (net.Message, error) {
-	// TODO: get user to confirm amount they will receive!!
-	s.info.SetReceivedAmount(msg.ProvidedAmount)
-	log.Infof(color.New(color.Bold).Sprintf("you will be receiving %v XMR", msg.ProvidedAmount))
-
-	exchangeRate := msg.ProvidedAmount / s.info.ProvidedAmount()
-	s.info.SetExchangeRate(common.ExchangeRate(exchangeRate))
-
+// handleSendKeysMessage verifies and stores Bob's keys.
+func (s *swapState) handleSendKeysMessage(msg *net.SendKeysMessage) error {
 	if msg.PublicSpendKey == "" || msg.PrivateViewKey == "" {
-		return nil, errMissingKeys
+		return errMissingKeys
 	}
 
 	if msg.EthAddress == "" {
-		return nil, errMissingAddress
+		return errMissingAddress
+	}
+
+	// the amount Bob provides must be what the offer's exchange rate gives for our ETH
+	if msg.ProvidedAmount.Cmp(s.info.ReceivedAmount()) != 0 {
+		return net.NewRejectError(net.RejectAmountMismatch,
+			"expected to receive %s XMR, but counterparty is providing %s XMR",
+			s.info.ReceivedAmount(), msg.ProvidedAmount)
 	}
 
+	log.Infof(color.New(color.Bold).Sprintf("you will be receiving %v XMR", msg.ProvidedAmount))
+
 	vk, err := mcrypto.NewPrivateViewKeyFromHex(msg.PrivateViewKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate Bob's private view keys: %w", err)
+		return fmt.Errorf("failed to generate Bob's private view keys: %w", err)
 	}
 
 	s.bobAddress = ethcommon.HexToAddress(msg.EthAddress)
@@ -94,22 +116,51 @@ func (s *swapState) handleSendKeysMessage(msg *net.SendKeysMessage) (net.Message
 
 	sk, err := mcrypto.NewPublicKeyFromHex(msg.PublicSpendKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate Bob's public spend key: %w", err)
+		return fmt.Errorf("failed to generate Bob's public spend key: %w", err)
 	}
 
 	// verify counterparty's DLEq proof and ensure the resulting secp256k1 key is correct
-	secp256k1Pub, err := pcommon.VerifyKeysAndProof(msg.DLEqProof, msg.Secp256k1PublicKey)
+	secp256k1Pub, err := pcommon.VerifyKeysAndProof(s.alice.dleq, msg.DLEqProof, msg.Secp256k1PublicKey)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	s.setBobKeys(sk, vk, secp256k1Pub)
-	address, err := s.deployAndLockETH(s.providedAmountInWei())
+	s.info.SetStage(pswap.StageKeysExchanged, "")
+	return nil
+}
+
+// lockETH creates our swap in the swap contract and locks our ETH, or ERC-20 tokens, in it, once
+// both sides have each other's keys. It returns the message notifying Bob of the swap.
+func (s *swapState) lockETH() (net.Message, error) {
+	// the swap's events are all emitted from here on, so we watch for them from here
+	fromBlock, err := s.alice.ethClient.BlockNumber(s.ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to deploy contract: %w", err)
+		return nil, fmt.Errorf("failed to get latest block number: %w", err)
 	}
 
-	log.Info("deployed Swap contract, waiting for XMR to be locked: contract address=", address)
+	var id ethcommon.Hash
+	if token, ok := s.info.Provides().ERC20Token(); ok {
+		id, err = s.lockERC20(token, s.info.ProvidedAmount())
+	} else {
+		id, err = s.lockEther(s.providedAmountInWei())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create swap in contract: %w", err)
+	}
+
+	log.Infof("created swap in contract, waiting for XMR to be locked: contract address=%s swap ID=%s",
+		s.contractAddr, id)
+
+	// our ETH is now locked, so the swap must be resumable from here on
+	s.nextExpectedMessage = &net.NotifyXMRLock{}
+	if err = s.checkpoint(); err != nil {
+		log.Errorf("failed to checkpoint swap: err=%s", err)
+	}
+
+	if err = s.watchContract(fromBlock); err != nil {
+		return nil, fmt.Errorf("failed to watch swap contract: %w", err)
+	}
 
 	// set t0 and t1
 	// TODO: these sometimes fail with "attempting to unmarshall an empty string while arguments are expected"
@@ -117,6 +168,10 @@ func (s *swapState) handleSendKeysMessage(msg *net.SendKeysMessage) (net.Message
 		return nil, err
 	}
 
+	if err = s.checkpoint(); err != nil {
+		log.Errorf("failed to checkpoint swap: err=%s", err)
+	}
+
 	// start goroutine to check that Bob locks before t_0
 	go func() {
 		const timeoutBuffer = time.Minute * 5
@@ -136,7 +191,7 @@ func (s *swapState) handleSendKeysMessage(msg *net.SendKeysMessage) (net.Message
 			log.Infof("got our ETH back: tx hash=%s", txhash)
 
 			// send NotifyRefund msg
-			if err := s.alice.net.SendSwapMessage(&net.NotifyRefund{
+			if err := s.alice.net.SendSwapMessage(s.ID(), &net.NotifyRefund{
 				TxHash: txhash.String(),
 			}); err != nil {
 				log.Errorf("failed to send refund message: err=%s", err)
@@ -147,10 +202,9 @@ func (s *swapState) handleSendKeysMessage(msg *net.SendKeysMessage) (net.Message
 
 	}()
 
-	s.nextExpectedMessage = &net.NotifyXMRLock{}
-
 	out := &net.NotifyContractDeployed{
-		Address: address.String(),
+		Address: s.contractAddr.String(),
+		SwapID:  id.String(),
 	}
 
 	return out, nil
@@ -160,6 +214,11 @@ func (s *swapState) handleNotifyXMRLock(msg *net.NotifyXMRLock) (net.Message, er
 	if msg.Address == "" {
 		return nil, errors.New("got empty address for locked XMR")
 	}
+
+	if msg.TxHash == "" || msg.TxKey == "" {
+		return nil, errors.New("got empty transaction hash or key for locked XMR")
+	}
+
 	// check that XMR was locked in expected account, and confirm amount
 	vk := mcrypto.SumPrivateViewKeys(s.bobPrivateViewKey, s.privkeys.ViewKey())
 	sk := mcrypto.SumPublicKeys(s.bobPublicSpendKey, s.pubkeys.SpendKey())
@@ -169,73 +228,14 @@ func (s *swapState) handleNotifyXMRLock(msg *net.NotifyXMRLock) (net.Message, er
 		return nil, fmt.Errorf("address received in message does not match expected address")
 	}
 
-	t := time.Now().Format("2006-Jan-2-15:04:05")
-	walletName := fmt.Sprintf("alice-viewonly-wallet-%s", t)
-	log.Debugf("generating view-only wallet to check funds: %s", walletName)
-	if err := s.alice.client.GenerateViewOnlyWalletFromKeys(vk, kp.Address(s.alice.env), walletName, ""); err != nil {
-		return nil, fmt.Errorf("failed to generate view-only wallet to verify locked XMR: %w", err)
-	}
-
-	log.Debugf("generated view-only wallet to check funds: %s", walletName)
-
-	if s.alice.env != common.Development {
-		// wait for 2 new blocks, otherwise balance might be 0
-		// TODO: check transaction hash
-		if err := monero.WaitForBlocks(s.alice.client); err != nil {
-			return nil, err
-		}
-
-		if err := monero.WaitForBlocks(s.alice.client); err != nil {
-			return nil, err
-		}
-	}
-
-	if err := s.alice.client.Refresh(); err != nil {
-		return nil, fmt.Errorf("failed to refresh client: %w", err)
-	}
-
-	accounts, err := s.alice.client.GetAccounts()
+	err := s.checkLockedXMR(msg.TxHash, msg.TxKey, kp.Address(s.alice.env))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get accounts: %w", err)
-	}
-
-	var (
-		balance *monero.GetBalanceResponse
-	)
-
-	for i, acc := range accounts.SubaddressAccounts {
-		addr, ok := acc["base_address"].(string)
-		if !ok {
-			panic("address is not a string!")
-		}
-
-		if mcrypto.Address(addr) == kp.Address(s.alice.env) {
-			balance, err = s.alice.client.GetBalance(uint(i))
-			if err != nil {
-				return nil, fmt.Errorf("failed to get balance: %w", err)
-			}
-
-			break
-		}
-	}
-
-	if balance == nil {
-		return nil, fmt.Errorf("failed to find account with address %s", kp.Address(s.alice.env))
-	}
-
-	log.Debugf("checking locked wallet, address=%s balance=%v", kp.Address(s.alice.env), balance.Balance)
-
-	// TODO: also check that the balance isn't unlocked only after an unreasonable amount of blocks
-	if balance.Balance < float64(s.receivedAmountInPiconero()) {
-		return nil, fmt.Errorf("locked XMR amount is less than expected: got %v, expected %v",
-			balance.Balance, float64(s.receivedAmountInPiconero()))
-	}
-
-	if err := s.alice.client.CloseWallet(); err != nil {
-		return nil, fmt.Errorf("failed to close wallet: %w", err)
+		return nil, err
 	}
 
 	close(s.xmrLockedCh)
+	s.info.SetXMRLock(kp.Address(s.alice.env), msg.TxHash)
+	s.info.SetStage(pswap.StageXMRLocked, msg.TxHash)
 
 	if err := s.ready(); err != nil {
 		return nil, fmt.Errorf("failed to call Ready: %w", err)
@@ -264,7 +264,7 @@ func (s *swapState) handleNotifyXMRLock(msg *net.NotifyXMRLock) (net.Message, er
 			log.Infof("got our ETH back: tx hash=%s", txhash)
 
 			// send NotifyRefund msg
-			if err = s.alice.net.SendSwapMessage(&net.NotifyRefund{
+			if err = s.alice.net.SendSwapMessage(s.ID(), &net.NotifyRefund{
 				TxHash: txhash.String(),
 			}); err != nil {
 				log.Errorf("failed to send refund message: err=%s", err)
@@ -275,9 +275,72 @@ func (s *swapState) handleNotifyXMRLock(msg *net.NotifyXMRLock) (net.Message, er
 	}()
 
 	s.nextExpectedMessage = &net.NotifyClaimed{}
+	if err = s.checkpoint(); err != nil {
+		log.Errorf("failed to checkpoint swap: err=%s", err)
+	}
+
 	return &net.NotifyReady{}, nil
 }
 
+// checkLockedXMR checks, using the secret key of Bob's lock transaction, that the expected amount
+// of XMR was sent to the given address, and that the transaction has enough confirmations.
+// The transaction is checked again after each new block, so it's only accepted if it's still in the
+// chain with enough confirmations.
+func (s *swapState) checkLockedXMR(txID, txKey string, address mcrypto.Address) error {
+	expected := uint64(s.receivedAmountInPiconero())
+	for blocks := uint64(0); ; blocks++ {
+		res, err := s.alice.checkTxKey(txID, txKey, address)
+		if err != nil {
+			return fmt.Errorf("failed to check XMR lock transaction: %w", err)
+		}
+
+		log.Debugf("checking XMR lock transaction, tx=%s received=%v expected=%v confirmations=%d in_pool=%v",
+			txID, res.Received, expected, res.Confirmations, res.InPool)
+
+		if res.Received < expected {
+			return fmt.Errorf("locked XMR amount is less than expected: got %v, expected %v",
+				res.Received, expected)
+		}
+
+		if !res.InPool && res.Confirmations >= s.alice.xmrConfirmations {
+			break
+		}
+
+		// in development, blocks are only generated on demand, so we don't wait for them.
+		// otherwise, we give the lock transaction a couple of blocks to be included in the chain.
+		if s.alice.env == common.Development || blocks >= s.alice.xmrConfirmations+2 {
+			return fmt.Errorf("XMR lock transaction has %d confirmations, expected %d",
+				res.Confirmations, s.alice.xmrConfirmations)
+		}
+
+		if err = monero.WaitForBlocks(s.alice.client); err != nil {
+			return err
+		}
+	}
+
+	return s.checkUnlockTime(txID)
+}
+
+// checkUnlockTime checks that the outputs of the XMR lock transaction aren't locked for longer than
+// usual, as otherwise we wouldn't be able to spend the XMR once we claim it.
+func (s *swapState) checkUnlockTime(txID string) error {
+	if s.alice.daemonClient == nil {
+		log.Warnf("not checking unlock time of XMR lock transaction, no monero daemon endpoint: tx=%s", txID)
+		return nil
+	}
+
+	unlockTime, err := s.alice.daemonClient.GetUnlockTime(txID)
+	if err != nil {
+		return fmt.Errorf("failed to get unlock time of XMR lock transaction: %w", err)
+	}
+
+	if unlockTime != 0 {
+		return fmt.Errorf("XMR lock transaction has unlock time %d, expected 0", unlockTime)
+	}
+
+	return nil
+}
+
 // handleNotifyClaimed handles Bob's reveal after he calls Claim().
 // it calls `createMoneroWallet` to create Alice's wallet, allowing her to own the XMR.
 func (s *swapState) handleNotifyClaimed(txHash string) (mcrypto.Address, error) {
@@ -286,14 +349,11 @@ func (s *swapState) handleNotifyClaimed(txHash string) (mcrypto.Address, error)
 		return "", errors.New("failed check Claim transaction receipt")
 	}
 
-	if len(receipt.Logs) == 0 {
-		return "", errors.New("claim transaction has no logs")
-	}
-
-	skB, err := swap.GetSecretFromLog(receipt.Logs[0], "Claimed")
+	// the transaction is given by Bob, so it may not be a claim of our swap
+	skB, err := swap.GetSecretFromLogs(s.contractAddr, s.contractSwapID, receipt.Logs, "Claimed")
 	if err != nil {
-		return "", fmt.Errorf("failed to get secret from log: %w", err)
+		return "", fmt.Errorf("failed to get secret from claim transaction: %w", err)
 	}
 
-	return s.claimMonero(skB)
+	return s.handleClaimed(skB, txHash)
 }
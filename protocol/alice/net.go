@@ -2,11 +2,17 @@ package alice
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
 
 	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
 	"github.com/noot/atomic-swap/net"
+	pcommon "github.com/noot/atomic-swap/protocol"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/fatih/color" //nolint:misspell
+	"github.com/libp2p/go-libp2p-core/peer"
 )
 
 // Provides returns common.ProvidesETH
@@ -15,39 +21,166 @@ func (a *Instance) Provides() common.ProvidesCoin {
 }
 
 // InitiateProtocol is called when an RPC call is made from the user to initiate a swap.
-// The input units are ether that we will provide.
-func (a *Instance) InitiateProtocol(providesAmount float64) (net.SwapState, error) {
-	if err := a.initiate(common.EtherToWei(providesAmount)); err != nil {
+// The input units are ether that we will provide, or the offer's ERC-20 token if it has one.
+// The amount of XMR we expect to receive is determined by the offer's exchange rate, and must
+// be within the offer's limits.
+func (a *Instance) InitiateProtocol(providesAmount common.Amount, offer *types.Offer,
+	counterparty peer.ID) (net.SwapState, error) {
+	if offer.Provides != common.ProvidesXMR {
+		return nil, errors.New("offer must provide XMR")
+	}
+
+	if offer.IsExpired() {
+		return nil, errors.New("offer has expired")
+	}
+
+	if providesAmount.IsZero() {
+		return nil, errors.New("provided amount must be greater than zero")
+	}
+
+	receivedAmount := offer.ExchangeRate.ToXMR(providesAmount)
+	if receivedAmount.Cmp(offer.MinimumAmount) < 0 {
+		return nil, fmt.Errorf("amount to be received is less than offer minimum: got %s, minimum %s",
+			receivedAmount, offer.MinimumAmount)
+	}
+
+	if receivedAmount.Cmp(offer.MaximumAmount) > 0 {
+		return nil, fmt.Errorf("amount to be received is more than offer maximum: got %s, maximum %s",
+			receivedAmount, offer.MaximumAmount)
+	}
+
+	s, err := a.initiate(offer.Token, providesAmount, receivedAmount, offer.GetID(), counterparty)
+	if err != nil {
 		return nil, err
 	}
 
-	return a.swapState, nil
+	return s, nil
 }
 
-func (a *Instance) initiate(providesAmount common.EtherAmount) error {
+// initiate starts a swap in which we provide the given ERC-20 token, or ether if token is the
+// zero address.
+func (a *Instance) initiate(token ethcommon.Address, providesAmount, receivedAmount common.Amount,
+	offerID types.Hash, counterparty peer.ID) (*swapState, error) {
 	a.swapMu.Lock()
 	defer a.swapMu.Unlock()
 
-	if a.swapState != nil {
-		return errors.New("protocol already in progress")
-	}
-
-	balance, err := a.ethClient.BalanceAt(a.ctx, a.callOpts.From, nil)
+	balance, units, decimals, err := a.balanceOf(token, providesAmount)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// check user's balance and that they actually have what they will provide
-	if balance.Cmp(providesAmount.BigInt()) <= 0 {
-		return errors.New("balance lower than amount to be provided")
+	provides := common.ProvidesETH
+	if token != (ethcommon.Address{}) {
+		provides = common.NewProvidesERC20(token)
+		if balance.Cmp(units) < 0 {
+			return nil, errors.New("token balance lower than amount to be provided")
+		}
+	} else if balance.Cmp(units) <= 0 {
+		return nil, errors.New("balance lower than amount to be provided")
 	}
 
-	a.swapState, err = newSwapState(a, providesAmount)
+	// we provide the amount in the asset's smallest units, so any precision beyond them is dropped
+	s, err := newSwapState(a, provides, common.TokenUnitsToAmount(units, decimals))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	log.Info(color.New(color.Bold).Sprintf("**initiated swap with ID=%d**", a.swapState.info.ID()))
+	s.info.SetOfferID(offerID)
+	s.info.SetCounterparty(counterparty)
+	s.info.SetReceivedAmount(receivedAmount)
+	s.info.SetExchangeRate(common.NewExchangeRateFromRat(
+		new(big.Rat).Quo(receivedAmount.Rat(), s.info.ProvidedAmount().Rat()),
+	))
+	a.swapStates[s.ID()] = s
+
+	log.Info(color.New(color.Bold).Sprintf("**initiated swap with ID=%d**", s.ID()))
 	log.Info(color.New(color.Bold).Sprint("DO NOT EXIT THIS PROCESS OR FUNDS MAY BE LOST!"))
-	return nil
+	return s, nil
+}
+
+func (a *Instance) removeSwapState(id uint64) {
+	a.swapMu.Lock()
+	defer a.swapMu.Unlock()
+	delete(a.swapStates, id)
+}
+
+// HandleInitiateMessage is called when we receive a network message from a peer that they wish to
+// initiate a swap by taking one of our offers. We reply with our keys, and lock our ETH, or the
+// offer's ERC-20 token, once the peer has accepted them.
+func (a *Instance) HandleInitiateMessage(who peer.ID, msg *net.SendKeysMessage) (net.SwapState, net.Message, error) {
+	str := color.New(color.Bold).Sprintf("**incoming take of offer %s with provided amount %v**",
+		msg.OfferID,
+		msg.ProvidedAmount,
+	)
+	log.Info(str)
+
+	// get offer and determine expected amount
+	id, err := types.HexToHash(msg.OfferID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	offer := a.offerManager.GetOffer(id)
+	if offer == nil {
+		return nil, nil, net.NewRejectError(net.RejectOfferUnavailable, "failed to find offer with given ID")
+	}
+
+	if msg.ProvidedAmount.IsZero() {
+		return nil, nil, net.NewRejectError(net.RejectAmountOutOfRange, "amount provided by taker must not be zero")
+	}
+
+	// the taker provides XMR, which can't be locked with more precision than a piconero, or more
+	// piconero than fit in a uint64
+	if common.MoneroToPiconero(msg.ProvidedAmount).AsMonero().Cmp(msg.ProvidedAmount) != 0 {
+		return nil, nil, net.NewRejectError(net.RejectAmountOutOfRange,
+			"amount provided by taker isn't a whole number of piconero that fits in a uint64: %s XMR", msg.ProvidedAmount)
+	}
+
+	providedAmount := offer.ExchangeRate.ToETH(msg.ProvidedAmount)
+
+	if providedAmount.Cmp(offer.MinimumAmount) < 0 {
+		return nil, nil, net.NewRejectError(net.RejectAmountOutOfRange,
+			"amount provided by taker is too low for offer: %s %s, minimum %s",
+			providedAmount, offer.Provides, offer.MinimumAmount)
+	}
+
+	if providedAmount.Cmp(offer.MaximumAmount) > 0 {
+		return nil, nil, net.NewRejectError(net.RejectAmountOutOfRange,
+			"amount provided by taker is too high for offer: %s %s, maximum %s",
+			providedAmount, offer.Provides, offer.MaximumAmount)
+	}
+
+	s, err := a.initiate(offer.Token, providedAmount, msg.ProvidedAmount, id, who)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// reserve the amount, so that concurrent swaps can't take more than the offer has available
+	if err = a.offerManager.Reserve(id, s.ID(), providedAmount); err != nil {
+		_ = s.ProtocolExited()
+		return nil, nil, err
+	}
+
+	if err = s.handleSendKeysMessage(msg); err != nil {
+		_ = s.ProtocolExited()
+		return nil, nil, err
+	}
+
+	if a.manualConfirm {
+		if err = pcommon.AwaitConfirmation(s.ctx, s.info, a.confirmTimeout); err != nil {
+			_ = s.ProtocolExited()
+			return nil, nil, err
+		}
+	}
+
+	resp, err := s.SendKeysMessage()
+	if err != nil {
+		_ = s.ProtocolExited()
+		return nil, nil, err
+	}
+
+	// we made the offer, so we wait for the taker to accept our keys before locking our ETH
+	s.nextExpectedMessage = &net.NotifyKeysAccepted{}
+	return s, resp, nil
 }
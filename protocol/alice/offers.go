@@ -0,0 +1,72 @@
+package alice
+
+import (
+	"errors"
+
+	"github.com/noot/atomic-swap/common/types"
+	pcommon "github.com/noot/atomic-swap/protocol"
+)
+
+// checkBalance returns an error if our balance of ether, or of the offer's ERC-20 token, doesn't
+// cover the offer's maximum amount.
+func (a *Instance) checkBalance(o *types.Offer) error {
+	balance, required, _, err := a.balanceOf(o.Token, o.MaximumAmount)
+	if err != nil {
+		return err
+	}
+
+	if balance.Cmp(required) < 0 {
+		return errors.New("balance is less than maximum offer amount")
+	}
+
+	return nil
+}
+
+// MakeOffer makes a new swap offer.
+func (a *Instance) MakeOffer(o *types.Offer) error {
+	if err := a.checkBalance(o); err != nil {
+		return err
+	}
+
+	if err := a.offerManager.PutOffer(o); err != nil {
+		return err
+	}
+
+	log.Infof("created new offer: %v", o)
+	return nil
+}
+
+// UpdateOffer replaces the terms of the offer with the same ID as o. The offer keeps its ID,
+// so takers who already know of it can still take it, on the new terms.
+func (a *Instance) UpdateOffer(o *types.Offer) error {
+	if a.offerManager.GetOffer(o.ID) == nil {
+		return pcommon.ErrOfferNotFound
+	}
+
+	if err := a.checkBalance(o); err != nil {
+		return err
+	}
+
+	if err := a.offerManager.PutOffer(o); err != nil {
+		return err
+	}
+
+	log.Infof("updated offer: %v", o)
+	return nil
+}
+
+// CancelOffer removes the offer with the given ID, so that it can no longer be taken.
+// Swaps which have already been initiated from the offer aren't affected.
+func (a *Instance) CancelOffer(id types.Hash) error {
+	if !a.offerManager.DeleteOffer(id) {
+		return pcommon.ErrOfferNotFound
+	}
+
+	log.Infof("cancelled offer: id=%s", id)
+	return nil
+}
+
+// GetOffers returns all current offers.
+func (a *Instance) GetOffers() []*types.Offer {
+	return a.offerManager.GetOffers()
+}
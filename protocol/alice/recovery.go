@@ -3,36 +3,26 @@ package alice
 import (
 	"context"
 	"errors"
-	"fmt"
 
-	eth "github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 
 	mcrypto "github.com/noot/atomic-swap/crypto/monero"
 	"github.com/noot/atomic-swap/dleq"
-	"github.com/noot/atomic-swap/swap-contract"
 )
 
-var claimedTopic = ethcommon.HexToHash("0xeddf608ef698454af2fb41c1df7b7e5154ff0d46969f895e0f39c7dfe7e6380a")
-
 var (
 	errNoClaimLogsFound = errors.New("no Claimed logs found")
 )
 
 type recoveryState struct {
-	ss           *swapState
-	contractAddr ethcommon.Address
+	ss *swapState
 }
 
-// NewRecoveryState returns a new *bob.recoveryState,
-// which has methods to either claim ether or reclaim monero from an initiated swap.
+// NewRecoveryState returns a new *alice.recoveryState, which has methods to either claim monero
+// or refund ether from the swap with the given ID in the swap contract.
 func NewRecoveryState(a *Instance, secret *mcrypto.PrivateSpendKey,
-	contractAddr ethcommon.Address) (*recoveryState, error) { //nolint:revive
-	txOpts, err := bind.NewKeyedTransactorWithChainID(a.ethPrivKey, a.chainID)
-	if err != nil {
-		return nil, err
-	}
+	contractAddr ethcommon.Address, contractSwapID ethcommon.Hash) (*recoveryState, error) { //nolint:revive
+	txOpts := a.signer.TransactOpts(a.chainID)
 
 	kp, err := secret.AsPrivateKeyPair()
 	if err != nil {
@@ -62,7 +52,7 @@ func NewRecoveryState(a *Instance, secret *mcrypto.PrivateSpendKey,
 		ss: s,
 	}
 
-	if err := rs.setContract(contractAddr); err != nil {
+	if err := rs.ss.setContract(contractAddr, contractSwapID); err != nil {
 		return nil, err
 	}
 
@@ -85,34 +75,18 @@ type RecoveryResult struct {
 // ClaimOrRecover either claims ether or recovers monero by creating a wallet.
 // It returns a *RecoveryResult.
 func (rs *recoveryState) ClaimOrRefund() (*RecoveryResult, error) {
-	// check if Bob claimed
-	skA, err := rs.filterForClaim()
-	if !errors.Is(err, errNoClaimLogsFound) && err != nil {
-		return nil, err
+	// check if Bob claimed, and if so, let's get our monero
+	res, err := rs.claim()
+	if !errors.Is(err, errNoClaimLogsFound) {
+		return res, err
 	}
 
-	// if Bob claimed, let's get our monero
-	if skA != nil {
-		vkA, err := skA.View() //nolint:govet
-		if err != nil {
-			return nil, err
-		}
-
-		rs.ss.setBobKeys(skA.Public(), vkA, nil)
-
-		addr, err := rs.ss.claimMonero(skA)
-		if err != nil {
-			return nil, err
-		}
-
-		return &RecoveryResult{
-			Claimed:       true,
-			MoneroAddress: addr,
-		}, nil
+	// otherwise, let's try to refund, unless Bob claims while we wait to be able to
+	txHash, err := rs.ss.tryRefund()
+	if errors.Is(err, errSwapClaimed) {
+		return rs.claim()
 	}
 
-	// otherwise, let's try to refund
-	txHash, err := rs.ss.tryRefund()
 	if err != nil {
 		return nil, err
 	}
@@ -123,31 +97,30 @@ func (rs *recoveryState) ClaimOrRefund() (*RecoveryResult, error) {
 	}, nil
 }
 
-// setContract sets the contract in which Alice has locked her ETH.
-func (rs *recoveryState) setContract(address ethcommon.Address) error {
-	var err error
-	rs.contractAddr = address
-	rs.ss.contract, err = swap.NewSwap(address, rs.ss.alice.ethClient)
-	return err
-}
+// claim claims our monero if Bob has claimed, or returns errNoClaimLogsFound otherwise.
+func (rs *recoveryState) claim() (*RecoveryResult, error) {
+	skA, err := rs.ss.filterForClaim()
+	if err != nil {
+		return nil, err
+	}
 
-func (rs *recoveryState) filterForClaim() (*mcrypto.PrivateSpendKey, error) {
-	logs, err := rs.ss.alice.ethClient.FilterLogs(rs.ss.ctx, eth.FilterQuery{
-		Addresses: []ethcommon.Address{rs.contractAddr},
-		Topics:    [][]ethcommon.Hash{{claimedTopic}},
-	})
+	vkA, err := skA.View()
 	if err != nil {
-		return nil, fmt.Errorf("failed to filter logs: %w", err)
+		return nil, err
 	}
 
-	if len(logs) == 0 {
-		return nil, errNoClaimLogsFound
+	// Bob's keys are only known if we're resuming a swap, rather than recovering it from its secret
+	if rs.ss.bobPublicSpendKey == nil {
+		rs.ss.setBobKeys(skA.Public(), vkA, nil)
 	}
 
-	sa, err := swap.GetSecretFromLog(&logs[0], "Claimed")
+	addr, err := rs.ss.claimMonero(skA)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get secret from log: %w", err)
+		return nil, err
 	}
 
-	return sa, nil
+	return &RecoveryResult{
+		Claimed:       true,
+		MoneroAddress: addr,
+	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/dleq"
 
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
@@ -11,7 +12,7 @@ import (
 
 func newTestRecoveryState(t *testing.T) *recoveryState {
 	inst, s := newTestInstance(t)
-	akp, err := generateKeys()
+	akp, err := generateKeys(&dleq.FakeDLEq{})
 	require.NoError(t, err)
 
 	s.privkeys = akp.PrivateKeyPair
@@ -21,10 +22,10 @@ func newTestRecoveryState(t *testing.T) *recoveryState {
 
 	s.setBobKeys(s.pubkeys.SpendKey(), s.privkeys.ViewKey(), akp.Secp256k1PublicKey)
 	s.bobAddress = inst.callOpts.From
-	addr, err := s.deployAndLockETH(common.NewEtherAmount(1))
+	id, err := s.lockEther(common.NewEtherAmount(1))
 	require.NoError(t, err)
 
-	rs, err := NewRecoveryState(inst, s.privkeys.SpendKey(), addr)
+	rs, err := NewRecoveryState(inst, s.privkeys.SpendKey(), s.contractAddr, id)
 	require.NoError(t, err)
 	return rs
 }
@@ -34,13 +35,13 @@ func TestClaimOrRefund_Claim(t *testing.T) {
 	// claim the monero.
 	rs := newTestRecoveryState(t)
 
-	// call swap.Ready()
+	// call swap.SetReady()
 	err := rs.ss.ready()
 	require.NoError(t, err)
 
 	// call swap.Claim()
 	sc := rs.ss.getSecret()
-	_, err = rs.ss.contract.Claim(rs.ss.txOpts, sc)
+	_, err = rs.ss.contract.Claim(rs.ss.txOpts, rs.ss.contractSwapID, sc)
 	require.NoError(t, err)
 
 	// assert we can claim the monero
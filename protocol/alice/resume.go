@@ -0,0 +1,260 @@
+package alice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/noot/atomic-swap/crypto"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+	"github.com/noot/atomic-swap/crypto/secp256k1"
+	"github.com/noot/atomic-swap/dleq"
+	"github.com/noot/atomic-swap/net"
+	pswap "github.com/noot/atomic-swap/protocol/swap"
+
+	"github.com/fatih/color" //nolint:misspell
+)
+
+// checkpoint writes the current protocol state to the swap database, so that the swap
+// can be resumed if swapd exits before the swap completes.
+func (s *swapState) checkpoint() error {
+	if s.info == nil || s.privkeys == nil {
+		return nil
+	}
+
+	cp := &pswap.Checkpoint{
+		FromBlock:           s.newSwapBlock,
+		ContractAddress:     s.contractAddr,
+		ContractSwapID:      s.contractSwapID,
+		Timeout0:            s.t0,
+		Timeout1:            s.t1,
+		NextExpectedMessage: s.nextExpectedMessage.Type(),
+	}
+
+	if (s.contractSwapID == ethcommon.Hash{}) && (s.newSwapTxHash != ethcommon.Hash{}) {
+		// our swap is being created, after which we wait for Bob to lock his XMR
+		cp.NewSwapTxHash = s.newSwapTxHash
+		cp.NextExpectedMessage = net.NotifyXMRLockType
+	}
+
+	if s.alice.secretsPassphrase == "" {
+		cp.PrivateSpendKey = s.privkeys.SpendKey().Hex()
+	} else {
+		// deriving the encryption key is slow, so the spend key is only encrypted once
+		if s.encryptedSpendKey == nil {
+			encrypted, err := crypto.EncryptSecret(s.privkeys.SpendKeyBytes(), s.alice.secretsPassphrase)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt private spend key: %w", err)
+			}
+
+			s.encryptedSpendKey = encrypted
+		}
+
+		cp.EncryptedPrivateSpendKey = s.encryptedSpendKey
+	}
+
+	if s.bobPublicSpendKey != nil {
+		cp.CounterpartyPublicSpendKey = s.bobPublicSpendKey.Hex()
+	}
+
+	if s.bobPrivateViewKey != nil {
+		cp.CounterpartyViewKey = s.bobPrivateViewKey.Hex()
+	}
+
+	if s.bobSecp256k1PublicKey != nil {
+		cp.CounterpartySecp256k1Key = s.bobSecp256k1PublicKey.String()
+	}
+
+	return s.alice.swapManager.WriteCheckpoint(s.ID(), cp)
+}
+
+// ResumeSwaps finds the swaps where we provided ETH, or an ERC-20 token, that were interrupted by
+// swapd exiting, and drives each of them to completion in the background by either claiming the
+// XMR or refunding the ETH. The counterparty does not need to be online.
+func (a *Instance) ResumeSwaps() error {
+	for _, info := range a.swapManager.GetInterruptedSwaps() {
+		if !info.Provides().IsEthereumAsset() {
+			continue
+		}
+
+		cp, err := a.swapManager.GetCheckpoint(info.ID())
+		if err != nil {
+			return err
+		}
+
+		// we exited before any funds were locked
+		if cp == nil || cp.NextExpectedMessage == net.SendKeysMessageType {
+			log.Infof("swap was interrupted before any funds were locked, aborting: id=%d", info.ID())
+			info.SetStatus(pswap.Aborted)
+			continue
+		}
+
+		s, err := newSwapStateFromCheckpoint(a, info, cp)
+		if err != nil {
+			log.Errorf("failed to resume swap: id=%d err=%s", info.ID(), err)
+			continue
+		}
+
+		log.Info(color.New(color.Bold).Sprintf("**resuming swap with ID=%d**", info.ID()))
+		a.swapManager.ResumeSwap(info.ID())
+		go s.resume()
+	}
+
+	return nil
+}
+
+func newSwapStateFromCheckpoint(a *Instance, info *pswap.Info, cp *pswap.Checkpoint) (*swapState, error) {
+	txOpts := a.signer.TransactOpts(a.chainID)
+	txOpts.GasPrice = a.gasPrice
+	txOpts.GasLimit = a.gasLimit
+
+	b, err := cp.SpendKeyBytes(a.secretsPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get private spend key: %w", err)
+	}
+
+	sk, err := mcrypto.NewPrivateSpendKey(b)
+	if err != nil {
+		return nil, err
+	}
+
+	kp, err := sk.AsPrivateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	var sc [32]byte
+	copy(sc[:], sk.Bytes())
+
+	ctx, cancel := context.WithCancel(a.ctx)
+	s := &swapState{
+		ctx:               ctx,
+		cancel:            cancel,
+		alice:             a,
+		info:              info,
+		txOpts:            txOpts,
+		privkeys:          kp,
+		pubkeys:           kp.PublicKeyPair(),
+		encryptedSpendKey: cp.EncryptedPrivateSpendKey,
+		dleqProof:         dleq.NewProofWithSecret(sc),
+		t0:                cp.Timeout0,
+		t1:                cp.Timeout1,
+		newSwapBlock:      cp.FromBlock,
+		xmrLockedCh:       make(chan struct{}),
+		claimedCh:         make(chan struct{}),
+	}
+
+	switch cp.NextExpectedMessage {
+	case net.NotifyXMRLockType:
+		s.nextExpectedMessage = &net.NotifyXMRLock{}
+	case net.NotifyClaimedType:
+		s.nextExpectedMessage = &net.NotifyClaimed{}
+	default:
+		return nil, fmt.Errorf("cannot resume swap expecting message %s", cp.NextExpectedMessage)
+	}
+
+	if cp.CounterpartyPublicSpendKey != "" && cp.CounterpartyViewKey != "" {
+		bobSpendKey, err := mcrypto.NewPublicKeyFromHex(cp.CounterpartyPublicSpendKey)
+		if err != nil {
+			return nil, err
+		}
+
+		bobViewKey, err := mcrypto.NewPrivateViewKeyFromHex(cp.CounterpartyViewKey)
+		if err != nil {
+			return nil, err
+		}
+
+		var bobSecp256k1Key *secp256k1.PublicKey
+		if cp.CounterpartySecp256k1Key != "" {
+			bobSecp256k1Key, err = secp256k1.NewPublicKeyFromHex(cp.CounterpartySecp256k1Key)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		s.setBobKeys(bobSpendKey, bobViewKey, bobSecp256k1Key)
+	}
+
+	// we exited before our swap's creation was confirmed, so its ID is only known once it is
+	if (cp.ContractSwapID == ethcommon.Hash{}) {
+		s.contractAddr = cp.ContractAddress
+		s.newSwapTxHash = cp.NewSwapTxHash
+		return s, nil
+	}
+
+	if err = s.setContract(cp.ContractAddress, cp.ContractSwapID); err != nil {
+		return nil, err
+	}
+
+	if err = s.setTimeouts(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// resumeNewSwap gets the contract, and the ID of our swap in it, from the transaction creating it,
+// for a swap which was interrupted before the transaction was confirmed.
+func (s *swapState) resumeNewSwap() error {
+	if (s.newSwapTxHash == ethcommon.Hash{}) {
+		return errors.New("transaction creating the swap is unknown")
+	}
+
+	if _, err := s.getNewSwapID(); err != nil {
+		return err
+	}
+
+	if err := s.setTimeouts(); err != nil {
+		return err
+	}
+
+	if err := s.checkpoint(); err != nil {
+		log.Errorf("failed to checkpoint swap: err=%s", err)
+	}
+
+	return nil
+}
+
+// resume drives a swap that was reloaded from a checkpoint to completion.
+func (s *swapState) resume() {
+	defer s.cancel()
+	defer s.alice.swapManager.CompleteOngoingSwap(s.ID())
+
+	// the contract's events are only used to stop waiting to refund if Bob claims; the lock is held
+	// until the swap completes, so the claim is handled here rather than by the event handler.
+	s.Lock()
+	defer s.Unlock()
+
+	if (s.contractSwapID == ethcommon.Hash{}) {
+		if err := s.resumeNewSwap(); err != nil {
+			log.Errorf("failed to get ID of resumed swap: id=%d err=%s", s.ID(), err)
+			return
+		}
+	}
+
+	// the swap's events are all emitted after it's created; if the checkpoint was written by a
+	// version of swapd which didn't record its block, we watch from the start of the chain
+	if err := s.watchContract(s.newSwapBlock); err != nil {
+		log.Errorf("failed to watch swap contract: id=%d err=%s", s.ID(), err)
+	}
+
+	res, err := (&recoveryState{ss: s}).ClaimOrRefund()
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.Errorf("failed to complete resumed swap: id=%d err=%s", s.ID(), err)
+		}
+		return
+	}
+
+	if res.Claimed {
+		s.info.SetStage(pswap.StageClaimed, "")
+		s.info.SetStatus(pswap.Success)
+		log.Infof("claimed monero for resumed swap: id=%d address=%s", s.ID(), res.MoneroAddress)
+		return
+	}
+
+	// refund has set the swap's status
+	log.Infof("refunded ether for resumed swap: id=%d tx hash=%s", s.ID(), res.TxHash)
+}
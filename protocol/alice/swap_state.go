@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"sync"
 	"time"
 
@@ -18,14 +19,18 @@ import (
 	pswap "github.com/noot/atomic-swap/protocol/swap"
 	"github.com/noot/atomic-swap/swap-contract"
 
+	eth "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/fatih/color" //nolint:misspell
 )
 
 var (
 	errMissingKeys    = errors.New("did not receive Bob's public spend or private view key")
 	errMissingAddress = errors.New("did not receive Bob's address")
+	errSwapClaimed    = errors.New("swap was claimed by Bob")
+	errInvalidSecret  = errors.New("secret doesn't correspond to Bob's public spend key")
 )
 
 // swapState is an instance of a swap. it holds the info needed for the swap,
@@ -44,16 +49,27 @@ type swapState struct {
 	privkeys     *mcrypto.PrivateKeyPair
 	pubkeys      *mcrypto.PublicKeyPair
 
+	// our private spend key encrypted with the secrets passphrase, once it's been checkpointed
+	encryptedSpendKey []byte
+
 	// Bob's keys for this session
 	bobPublicSpendKey     *mcrypto.PublicKey
 	bobPrivateViewKey     *mcrypto.PrivateViewKey
 	bobSecp256k1PublicKey *secp256k1.PublicKey
 	bobAddress            ethcommon.Address
 
-	// swap contract and timeouts in it; set once contract is deployed
-	contract *swap.Swap
-	t0, t1   time.Time
-	txOpts   *bind.TransactOpts
+	// swap contract, the ID of our swap in it, and its timeouts; set once the swap is created
+	contract       *swap.SwapFactory
+	contractAddr   ethcommon.Address
+	contractSwapID ethcommon.Hash
+	t0, t1         time.Time
+	txOpts         *bind.TransactOpts
+
+	// the transaction creating our swap, once it's sent; the swap's ID is in its receipt
+	newSwapTxHash ethcommon.Hash
+
+	// the block our swap was created in, once it's confirmed
+	newSwapBlock uint64
 
 	// next expected network message
 	nextExpectedMessage net.Message // TODO: change to type?
@@ -61,18 +77,21 @@ type swapState struct {
 	// channels
 	xmrLockedCh chan struct{}
 	claimedCh   chan struct{}
+	claimedOnce sync.Once
 }
 
-func newSwapState(a *Instance, providesAmount common.EtherAmount) (*swapState, error) {
-	txOpts, err := bind.NewKeyedTransactorWithChainID(a.ethPrivKey, a.chainID)
-	if err != nil {
-		return nil, err
+func newSwapState(a *Instance, provides common.ProvidesCoin, providesAmount common.Amount) (*swapState, error) {
+	// we can't complete a swap without signing transactions, so don't start one while our signer is locked
+	if a.signer.Locked() {
+		return nil, pcommon.ErrKeyLocked
 	}
 
+	txOpts := a.signer.TransactOpts(a.chainID)
 	txOpts.GasPrice = a.gasPrice
 	txOpts.GasLimit = a.gasLimit
 
-	info := pswap.NewInfo(common.ProvidesETH, providesAmount.AsEther(), 0, 0, pswap.Ongoing)
+	info := pswap.NewInfo(provides, providesAmount, common.Amount{}, common.ExchangeRate{}, pswap.Ongoing)
+	info.SetSweepAddress(a.sweepAddress)
 	if err := a.swapManager.AddSwap(info); err != nil {
 		return nil, err
 	}
@@ -99,6 +118,7 @@ func (s *swapState) SendKeysMessage() (*net.SendKeysMessage, error) {
 	}
 
 	return &net.SendKeysMessage{
+		ProvidedAmount:     s.info.ProvidedAmount(),
 		PublicSpendKey:     s.pubkeys.SpendKey().Hex(),
 		PublicViewKey:      s.pubkeys.ViewKey().Hex(),
 		DLEqProof:          hex.EncodeToString(s.dleqProof.Proof()),
@@ -107,7 +127,7 @@ func (s *swapState) SendKeysMessage() (*net.SendKeysMessage, error) {
 }
 
 // ReceivedAmount returns the amount received, or expected to be received, at the end of the swap
-func (s *swapState) ReceivedAmount() float64 {
+func (s *swapState) ReceivedAmount() common.Amount {
 	return s.info.ReceivedAmount()
 }
 
@@ -124,6 +144,17 @@ func (s *swapState) ID() uint64 {
 	return s.info.ID()
 }
 
+// settleOffer updates the available amount of our offer, if the swap took one of our offers,
+// once the swap has finished.
+func (s *swapState) settleOffer() {
+	switch s.info.Status() {
+	case pswap.Success:
+		s.alice.offerManager.Fill(s.info.OfferID(), s.ID())
+	case pswap.Aborted, pswap.Refunded:
+		s.alice.offerManager.Release(s.info.OfferID(), s.ID())
+	}
+}
+
 // ProtocolExited is called by the network when the protocol stream closes.
 // If it closes prematurely, we need to perform recovery.
 func (s *swapState) ProtocolExited() error {
@@ -133,8 +164,9 @@ func (s *swapState) ProtocolExited() error {
 	defer func() {
 		// stop all running goroutines
 		s.cancel()
-		s.alice.swapState = nil
-		s.alice.swapManager.CompleteOngoingSwap()
+		s.alice.removeSwapState(s.ID())
+		s.alice.swapManager.CompleteOngoingSwap(s.ID())
+		s.settleOffer()
 	}()
 
 	if s.info.Status() == pswap.Success {
@@ -144,52 +176,72 @@ func (s *swapState) ProtocolExited() error {
 	}
 
 	switch s.nextExpectedMessage.(type) {
-	case *net.SendKeysMessage:
+	case *net.SendKeysMessage, *net.NotifyKeysAccepted:
 		// we are fine, as we only just initiated the protocol.
 		s.info.SetStatus(pswap.Aborted)
 		return errors.New("swap cancelled early, but before any locking happened")
 	case *net.NotifyXMRLock:
 		// we already deployed the contract, so we should call Refund().
-		txHash, err := s.tryRefund()
-		if err != nil {
-			s.info.SetStatus(pswap.Aborted)
-			log.Errorf("failed to refund: err=%s", err)
-			return err
-		}
-
-		s.info.SetStatus(pswap.Refunded)
-		log.Infof("refunded ether: transaction hash=%s", txHash)
+		return s.refundOrClaim()
 	case *net.NotifyClaimed:
 		// the XMR has been locked, but the ETH hasn't been claimed.
-		// we should also refund in this case.
-		txHash, err := s.tryRefund()
+		// we should also refund in this case, unless Bob claims before we can.
+		return s.refundOrClaim()
+	default:
+		log.Errorf("unexpected nextExpectedMessage in ProtocolExited: type=%T", s.nextExpectedMessage)
+		s.info.SetStatus(pswap.Aborted)
+		return errors.New("unexpected message type")
+	}
+}
+
+// refundOrClaim refunds our ETH once we're able to. If Bob claims it while we're waiting to do so,
+// we claim the XMR instead.
+func (s *swapState) refundOrClaim() error {
+	txHash, err := s.tryRefund()
+	if errors.Is(err, errSwapClaimed) {
+		skB, err := s.filterForClaim() //nolint:govet
 		if err != nil {
-			s.info.SetStatus(pswap.Aborted)
-			log.Errorf("failed to refund: err=%s", err)
 			return err
 		}
 
-		s.info.SetStatus(pswap.Refunded)
-		log.Infof("refunded ether: transaction hash=%s", txHash)
-	default:
-		log.Errorf("unexpected nextExpectedMessage in ProtocolExited: type=%T", s.nextExpectedMessage)
+		_, err = s.handleClaimed(skB, "")
+		return err
+	}
+
+	if err != nil {
 		s.info.SetStatus(pswap.Aborted)
-		return errors.New("unexpected message type")
+		log.Errorf("failed to refund: err=%s", err)
+		return err
 	}
 
+	// refund has set the swap's status
+	log.Infof("refunded ether: transaction hash=%s", txHash)
 	return nil
 }
 
+// tryRefund refunds our ETH, waiting until t1 to do so if the swap is ready or t0 has passed.
+// If Bob claims while we're waiting, it returns errSwapClaimed.
 func (s *swapState) tryRefund() (ethcommon.Hash, error) {
 	untilT0 := time.Until(s.t0)
 	untilT1 := time.Until(s.t1)
 
-	// TODO: also check if IsReady == true
+	info, err := s.contract.Swaps(s.alice.callOpts, s.contractSwapID)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
 
-	if untilT0 > 0 && untilT1 < 0 {
-		// we've passed t0 but aren't past t1 yet, so we need to wait until t1
+	isReady := info.Stage == swap.StageReady
+	if (untilT0 < 0 || isReady) && untilT1 > 0 {
+		// we can only refund before t0 if the contract isn't ready, otherwise
+		// we need to wait until t1
 		log.Infof("waiting until time %s to refund", s.t1)
-		<-time.After(untilT1)
+		select {
+		case <-s.ctx.Done():
+			return ethcommon.Hash{}, s.ctx.Err()
+		case <-s.claimedCh:
+			return ethcommon.Hash{}, errSwapClaimed
+		case <-time.After(untilT1 + time.Second):
+		}
 	}
 
 	return s.refund()
@@ -206,31 +258,20 @@ func (s *swapState) setTimeouts() error {
 
 	// TODO: add maxRetries
 	for {
-		log.Debug("attempting to fetch t0 from contract")
-
-		st0, err := s.contract.Timeout0(s.alice.callOpts)
-		if err != nil {
-			time.Sleep(time.Second * 10)
-			continue
-		}
-
-		s.t0 = time.Unix(st0.Int64(), 0)
-		break
-	}
-
-	for {
-		log.Debug("attempting to fetch t1 from contract")
+		log.Debug("attempting to fetch t0 and t1 from contract")
 
-		st1, err := s.contract.Timeout1(s.alice.callOpts)
+		info, err := s.contract.Swaps(s.alice.callOpts, s.contractSwapID)
 		if err != nil {
 			time.Sleep(time.Second * 10)
 			continue
 		}
 
-		s.t1 = time.Unix(st1.Int64(), 0)
+		s.t0 = time.Unix(info.Timeout0.Int64(), 0)
+		s.t1 = time.Unix(info.Timeout1.Int64(), 0)
 		break
 	}
 
+	s.info.SetTimeouts(s.t0, s.t1)
 	return nil
 }
 
@@ -239,7 +280,7 @@ func (s *swapState) generateAndSetKeys() error {
 		return nil
 	}
 
-	keysAndProof, err := generateKeys()
+	keysAndProof, err := generateKeys(s.alice.dleq)
 	if err != nil {
 		return err
 	}
@@ -250,7 +291,7 @@ func (s *swapState) generateAndSetKeys() error {
 	s.pubkeys = keysAndProof.PublicKeyPair
 
 	fp := fmt.Sprintf("%s/%d/alice-secret", s.alice.basepath, s.info.ID())
-	if err := mcrypto.WriteKeysToFile(fp, s.privkeys, s.alice.env); err != nil {
+	if err := mcrypto.WriteKeysToFile(fp, s.privkeys, s.alice.env, s.alice.secretsPassphrase); err != nil {
 		return err
 	}
 
@@ -259,8 +300,8 @@ func (s *swapState) generateAndSetKeys() error {
 
 // generateKeys generates Alice's monero spend and view keys (S_b, V_b), a secp256k1 public key,
 // and a DLEq proof proving that the two keys correspond.
-func generateKeys() (*pcommon.KeysAndProof, error) {
-	return pcommon.GenerateKeysAndProof()
+func generateKeys(d dleq.Interface) (*pcommon.KeysAndProof, error) {
+	return pcommon.GenerateKeysAndProof(d)
 }
 
 // getSecret secrets returns the current secret scalar used to unlock funds from the contract.
@@ -271,6 +312,17 @@ func (s *swapState) getSecret() [32]byte {
 	return sc
 }
 
+// sendTx sends the transaction built by send with the given urgency, and returns its receipt once
+// it's included in the chain. If it's stuck, it's replaced with higher fees.
+func (s *swapState) sendTx(urgency pcommon.Urgency, send pcommon.SendTxFunc) (*ethtypes.Receipt, error) {
+	// a swap resumed when swapd starts may need to send transactions before our key is unlocked
+	if err := pcommon.WaitForUnlock(s.ctx, s.alice.signer); err != nil {
+		return nil, err
+	}
+
+	return s.alice.txManager.Send(s.ctx, s.txOpts, urgency, time.Time{}, send)
+}
+
 // setBobKeys sets Bob's public spend key (to be stored in the contract) and Bob's
 // private view key (used to check XMR balance before calling Ready())
 func (s *swapState) setBobKeys(sk *mcrypto.PublicKey, vk *mcrypto.PrivateViewKey, secp256k1Pub *secp256k1.PublicKey) {
@@ -279,70 +331,150 @@ func (s *swapState) setBobKeys(sk *mcrypto.PublicKey, vk *mcrypto.PrivateViewKey
 	s.bobSecp256k1PublicKey = secp256k1Pub
 }
 
-// deployAndLockETH deploys an instance of the Swap contract and locks `amount` ether in it.
-func (s *swapState) deployAndLockETH(amount common.EtherAmount) (ethcommon.Address, error) {
+// lockEther creates a new swap in the swap contract and locks `amount` ether in it.
+// It returns the ID of the swap.
+func (s *swapState) lockEther(amount common.EtherAmount) (ethcommon.Hash, error) {
+	return s.newSwap(ethcommon.Address{}, amount.BigInt())
+}
+
+// newSwap creates a new swap in the SwapFactory contract, locking `value` of the given asset in it,
+// which is ether if the asset is the zero address. ERC-20 tokens must already be approved for the
+// contract to transfer. It returns the ID of the swap.
+func (s *swapState) newSwap(asset ethcommon.Address, value *big.Int) (ethcommon.Hash, error) {
 	if s.pubkeys == nil {
-		return ethcommon.Address{}, errors.New("public keys aren't set")
+		return ethcommon.Hash{}, errors.New("public keys aren't set")
 	}
 
 	if s.bobPublicSpendKey == nil || s.bobPrivateViewKey == nil {
-		return ethcommon.Address{}, errors.New("bob's keys aren't set")
+		return ethcommon.Hash{}, errors.New("bob's keys aren't set")
+	}
+
+	address, contract, err := s.alice.getSwapFactory()
+	if err != nil {
+		return ethcommon.Hash{}, err
 	}
 
 	cmtAlice := s.secp256k1Pub.Keccak256()
 	cmtBob := s.bobSecp256k1PublicKey.Keccak256()
 
-	s.txOpts.Value = amount.BigInt()
-	defer func() {
-		s.txOpts.Value = nil
-	}()
+	s.contractAddr = address
+	receipt, err := s.sendTx(pcommon.UrgencyLow, func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+		if (asset == ethcommon.Address{}) {
+			opts.Value = value
+		}
 
-	address, tx, swap, err := swap.DeploySwap(s.txOpts, s.alice.ethClient,
-		cmtBob, cmtAlice, s.bobAddress, defaultTimeoutDuration)
+		tx, err := contract.NewSwap(opts, cmtBob, cmtAlice, s.bobAddress, defaultTimeoutDuration, asset, value)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Debugf("creating swap in SwapFactory.sol, asset=%s value=%s txHash=%s", asset, value, tx.Hash())
+
+		// our funds may be locked once the transaction is sent, so the swap must be resumable from
+		// here on; the transaction may be replaced, so each one sent is checkpointed
+		s.newSwapTxHash = tx.Hash()
+		if err = s.checkpoint(); err != nil {
+			log.Errorf("failed to checkpoint swap: err=%s", err)
+		}
+
+		return tx, nil
+	})
 	if err != nil {
-		return ethcommon.Address{}, fmt.Errorf("failed to deploy Swap.sol: %w", err)
+		return ethcommon.Hash{}, fmt.Errorf("failed to create swap in SwapFactory.sol: %w", err)
+	}
+
+	// the transaction included may be one replaced later, so it's checkpointed before waiting for
+	// it to be confirmed, which can take a while
+	s.newSwapTxHash = receipt.TxHash
+	if err = s.checkpoint(); err != nil {
+		log.Errorf("failed to checkpoint swap: err=%s", err)
 	}
 
-	log.Debugf("deploying Swap.sol, amount=%s txHash=%s", amount, tx.Hash())
-	if _, ok := common.WaitForReceipt(s.ctx, s.alice.ethClient, tx.Hash()); !ok {
-		return ethcommon.Address{}, errors.New("failed to deploy Swap.sol")
+	id, err := s.getNewSwapID()
+	if err != nil {
+		return ethcommon.Hash{}, err
 	}
 
 	fp := fmt.Sprintf("%s/%d/contractaddress", s.alice.basepath, s.info.ID())
-	if err = common.WriteContractAddressToFile(fp, address.String()); err != nil {
-		return ethcommon.Address{}, fmt.Errorf("failed to write contract address to file: %w", err)
+	if err = common.WriteContractAddressToFile(fp, address.String(), id.String()); err != nil {
+		return ethcommon.Hash{}, fmt.Errorf("failed to write contract address to file: %w", err)
+	}
+
+	return id, nil
+}
+
+// getNewSwapID waits for the transaction creating our swap to be confirmed, and sets the contract,
+// and the ID of the swap in it, from its receipt.
+func (s *swapState) getNewSwapID() (ethcommon.Hash, error) {
+	txHash := s.newSwapTxHash
+	receipt, err := common.WaitForConfirmations(s.ctx, s.alice.ethClient, txHash, s.alice.ethConfirmations)
+	if err != nil {
+		return ethcommon.Hash{}, fmt.Errorf("failed to create swap in SwapFactory.sol: %w", err)
 	}
 
-	balance, err := s.alice.ethClient.BalanceAt(s.ctx, address, nil)
+	id, err := swap.GetIDFromLogs(s.contractAddr, receipt.Logs)
 	if err != nil {
-		return ethcommon.Address{}, err
+		return ethcommon.Hash{}, err
 	}
 
-	log.Debug("contract balance: ", balance)
+	if err = s.setContract(s.contractAddr, id); err != nil {
+		return ethcommon.Hash{}, err
+	}
 
-	s.contract = swap
-	return address, nil
+	s.newSwapBlock = receipt.BlockNumber.Uint64()
+	s.info.SetContractAddress(s.contractAddr)
+	s.info.SetContractSwapID(id)
+	s.info.SetStage(pswap.StageETHLocked, txHash.String())
+	return id, nil
 }
 
-// ready calls the Ready() method on the Swap contract, indicating to Bob he has until time t_1 to
+// ready calls the SetReady() method on the swap contract for our swap, indicating to Bob he has until time t_1 to
 // call Claim(). Ready() should only be called once Alice sees Bob lock his XMR.
 // If time t_0 has passed, there is no point of calling Ready().
 func (s *swapState) ready() error {
-	tx, err := s.contract.SetReady(s.txOpts)
+	urgency := pcommon.DeadlineUrgency(pcommon.UrgencyNormal, s.t0)
+	receipt, err := s.sendTx(urgency, func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+		return s.contract.SetReady(opts, s.contractSwapID)
+	})
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to set swap to ready in SwapFactory.sol: %w", err)
 	}
 
-	if _, ok := common.WaitForReceipt(s.ctx, s.alice.ethClient, tx.Hash()); !ok {
-		return errors.New("failed to set IsReady to true in Swap.sol")
+	txHash := receipt.TxHash
+	if _, err = common.WaitForConfirmations(s.ctx, s.alice.ethClient, txHash, s.alice.ethConfirmations); err != nil {
+		return fmt.Errorf("failed to set swap to ready in SwapFactory.sol: %w", err)
 	}
 
+	s.info.SetStage(pswap.StageContractReady, txHash.String())
 	return nil
 }
 
-// refund calls the Refund() method in the Swap contract, revealing Alice's secret
-// and returns to her the ether in the contract.
-// If time t_1 passes and Claim() has not been called, Alice should call Refund().
+// filterForClaim returns Bob's secret if he has called Claim() on the contract.
+func (s *swapState) filterForClaim() (*mcrypto.PrivateSpendKey, error) {
+	logs, err := s.alice.ethClient.FilterLogs(s.ctx, eth.FilterQuery{
+		Addresses: []ethcommon.Address{s.contractAddr},
+		Topics:    [][]ethcommon.Hash{{swap.ClaimedTopic}, {s.contractSwapID}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter logs: %w", err)
+	}
+
+	if len(logs) == 0 {
+		return nil, errNoClaimLogsFound
+	}
+
+	sb, err := swap.GetSecretFromLog(&logs[0], "Claimed")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret from log: %w", err)
+	}
+
+	return sb, nil
+}
+
+// refund calls the Refund() method in the swap contract for our swap, revealing Alice's secret
+// and returning to her the ether, or tokens, locked in it.
+// If time t_1 passes and Claim() has not been called, Alice should call Refund(). Once the refund is
+// included, it sets the swap's stage and status to refunded.
 func (s *swapState) refund() (ethcommon.Hash, error) {
 	if s.contract == nil {
 		return ethcommon.Hash{}, errors.New("contract is nil")
@@ -350,30 +482,86 @@ func (s *swapState) refund() (ethcommon.Hash, error) {
 
 	sc := s.getSecret()
 
+	// we refund either to abort the swap before t0, when Bob would be able to claim, or because
+	// Bob hasn't claimed by t1; either way, we want our funds back as soon as possible.
 	log.Infof("attempting to call Refund()...")
-	tx, err := s.contract.Refund(s.txOpts, sc)
+	receipt, err := s.sendTx(pcommon.UrgencyHigh, func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+		return s.contract.Refund(opts, s.contractSwapID, sc)
+	})
 	if err != nil {
-		return ethcommon.Hash{}, err
+		return ethcommon.Hash{}, fmt.Errorf("failed to call Refund in SwapFactory.sol: %w", err)
 	}
 
-	if _, ok := common.WaitForReceipt(s.ctx, s.alice.ethClient, tx.Hash()); !ok {
-		return ethcommon.Hash{}, errors.New("failed to call Refund in Swap.sol")
+	s.info.SetStage(pswap.StageRefunded, receipt.TxHash.String())
+	s.info.SetStatus(pswap.Refunded)
+	return receipt.TxHash, nil
+}
+
+// setClaimed signals that Bob has claimed, so that we stop waiting to refund.
+func (s *swapState) setClaimed() {
+	s.claimedOnce.Do(func() {
+		close(s.claimedCh)
+	})
+}
+
+// handleClaimed claims the XMR using Bob's secret, which he revealed by calling Claim() in the
+// transaction with the given hash, completing the swap.
+func (s *swapState) handleClaimed(skB *mcrypto.PrivateSpendKey, txHash string) (mcrypto.Address, error) {
+	address, err := s.claimMonero(skB)
+	if err != nil {
+		log.Error("failed to create monero address: err=", err)
+		return "", err
 	}
 
-	s.info.SetStatus(pswap.Refunded)
-	return tx.Hash(), nil
+	s.setClaimed()
+	s.info.SetStage(pswap.StageClaimed, txHash)
+	log.Info("successfully created monero wallet from our secrets: address=", address)
+	s.info.SetStatus(pswap.Success)
+	return address, nil
 }
 
 func (s *swapState) claimMonero(skB *mcrypto.PrivateSpendKey) (mcrypto.Address, error) {
+	if s.bobPublicSpendKey == nil || s.bobPrivateViewKey == nil {
+		return "", errMissingKeys
+	}
+
+	// the secret is from a log or message we didn't create, so make sure it's really Bob's;
+	// otherwise, the wallet wouldn't contain the XMR he locked
+	if skB.Public().Hex() != s.bobPublicSpendKey.Hex() {
+		return "", errInvalidSecret
+	}
+
 	skAB := mcrypto.SumPrivateSpendKeys(skB, s.privkeys.SpendKey())
 	vkAB := mcrypto.SumPrivateViewKeys(s.bobPrivateViewKey, s.privkeys.ViewKey())
 	kpAB := mcrypto.NewPrivateKeyPair(skAB, vkAB)
 
 	// write keys to file in case something goes wrong
 	fp := fmt.Sprintf("%s/%d/swap-secret", s.alice.basepath, s.info.ID())
-	if err := mcrypto.WriteKeysToFile(fp, kpAB, s.alice.env); err != nil {
+	if err := mcrypto.WriteKeysToFile(fp, kpAB, s.alice.env, s.alice.secretsPassphrase); err != nil {
+		return "", err
+	}
+
+	s.alice.walletLock.Lock()
+	defer s.alice.walletLock.Unlock()
+	address, walletName, err := monero.CreateMoneroWallet("alice-swap-wallet", s.alice.env, s.alice.client, kpAB)
+	if err != nil {
 		return "", err
 	}
 
-	return monero.CreateMoneroWallet("alice-swap-wallet", s.alice.env, s.alice.client, kpAB)
+	// switch back to the main wallet, if any, so that other swaps can continue to use it
+	if err = s.alice.walletLock.RestoreMainWallet(); err != nil {
+		return "", err
+	}
+
+	s.alice.startSweep(s.info, walletName)
+	return address, nil
+}
+
+// setContract sets the contract, and the ID of the swap in it, in which Alice has locked her ETH.
+func (s *swapState) setContract(address ethcommon.Address, id ethcommon.Hash) error {
+	var err error
+	s.contractAddr = address
+	s.contractSwapID = id
+	s.contract, err = swap.NewSwapFactory(address, s.alice.ethClient)
+	return err
 }
@@ -8,12 +8,15 @@ import (
 	"time"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/noot/atomic-swap/common"
 	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+	"github.com/noot/atomic-swap/dleq"
 	"github.com/noot/atomic-swap/monero"
 	"github.com/noot/atomic-swap/net"
 	pcommon "github.com/noot/atomic-swap/protocol"
 	pswap "github.com/noot/atomic-swap/protocol/swap"
+	"github.com/noot/atomic-swap/swap-contract"
 
 	logging "github.com/ipfs/go-log"
 	"github.com/stretchr/testify/require"
@@ -25,36 +28,45 @@ type mockNet struct {
 	msg net.Message
 }
 
-func (n *mockNet) SendSwapMessage(msg net.Message) error {
+func (n *mockNet) SendSwapMessage(_ uint64, msg net.Message) error {
 	n.msg = msg
 	return nil
 }
 
+func newTestSwapManager(t *testing.T) *pswap.Manager {
+	sm, err := pswap.NewManager(memorydb.New())
+	require.NoError(t, err)
+	return sm
+}
+
 func newTestInstance(t *testing.T) (*Instance, *swapState) {
 	cfg := &Config{
 		Ctx:                  context.Background(),
 		Basepath:             "/tmp/alice",
 		MoneroWalletEndpoint: common.DefaultAliceMoneroEndpoint,
+		MoneroDaemonEndpoint: common.DefaultMoneroDaemonEndpoint,
 		EthereumEndpoint:     common.DefaultEthEndpoint,
 		EthereumPrivateKey:   common.DefaultPrivKeyAlice,
 		Environment:          common.Development,
 		ChainID:              common.MainnetConfig.EthereumChainID,
-		SwapManager:          pswap.NewManager(),
+		SwapManager:          newTestSwapManager(t),
+		DLEq:                 &dleq.FakeDLEq{},
 	}
 
 	alice, err := NewInstance(cfg)
 	require.NoError(t, err)
-	swapState, err := newSwapState(alice, common.NewEtherAmount(1))
+	swapState, err := newSwapState(alice, common.ProvidesETH, common.NewEtherAmount(1).AsEther())
 	require.NoError(t, err)
-	swapState.info.SetReceivedAmount(1)
+	swapState.info.SetReceivedAmount(common.MustNewAmount("1"))
 	return alice, swapState
 }
 
 func newTestBobSendKeysMessage(t *testing.T) (*net.SendKeysMessage, *pcommon.KeysAndProof) {
-	keysAndProof, err := pcommon.GenerateKeysAndProof()
+	keysAndProof, err := pcommon.GenerateKeysAndProof(&dleq.FakeDLEq{})
 	require.NoError(t, err)
 
 	msg := &net.SendKeysMessage{
+		ProvidedAmount:     common.MustNewAmount("1"),
 		PublicSpendKey:     keysAndProof.PublicKeyPair.SpendKey().Hex(),
 		PrivateViewKey:     keysAndProof.PrivateKeyPair.ViewKey().Hex(),
 		DLEqProof:          hex.EncodeToString(keysAndProof.DLEqProof.Proof()),
@@ -123,10 +135,39 @@ func TestSwapState_HandleProtocolMessage_SendKeysMessage_Refund(t *testing.T) {
 	require.NotNil(t, s.alice.net.(*mockNet).msg)
 	require.Equal(t, net.NotifyRefundType, s.alice.net.(*mockNet).msg.Type())
 
-	// check balance of contract is 0
-	balance, err := s.alice.ethClient.BalanceAt(s.ctx, ethcommon.HexToAddress(cdMsg.Address), nil)
+	// check the swap was refunded
+	require.Equal(t, s.contractAddr.String(), cdMsg.Address)
+	info, err := s.contract.Swaps(s.alice.callOpts, ethcommon.HexToHash(cdMsg.SwapID))
+	require.NoError(t, err)
+	require.Equal(t, swap.StageCompleted, info.Stage)
+}
+
+// lockXMR sends the given amount of XMR to the given address from the Bob test wallet, and returns
+// the message Bob sends to notify us of the lock.
+func lockXMR(t *testing.T, address mcrypto.Address, amount common.MoneroAmount) *net.NotifyXMRLock {
+	c := monero.NewClient(common.DefaultBobMoneroEndpoint)
+	err := c.OpenWallet("test-wallet", "")
+	require.NoError(t, err)
+
+	bobAddr, err := c.GetAddress(0)
 	require.NoError(t, err)
-	require.Equal(t, int64(0), balance.Int64())
+
+	daemonClient := monero.NewClient(common.DefaultMoneroDaemonEndpoint)
+	_ = daemonClient.GenerateBlocks(bobAddr.Address, 121)
+
+	err = c.Refresh()
+	require.NoError(t, err)
+
+	res, err := c.Transfer(address, 0, uint(amount))
+	require.NoError(t, err)
+
+	_ = daemonClient.GenerateBlocks(bobAddr.Address, 1)
+
+	return &net.NotifyXMRLock{
+		Address: string(address),
+		TxHash:  res.TxHash,
+		TxKey:   res.TxKey,
+	}
 }
 
 func TestSwapState_NotifyXMRLock(t *testing.T) {
@@ -137,22 +178,18 @@ func TestSwapState_NotifyXMRLock(t *testing.T) {
 	err := s.generateAndSetKeys()
 	require.NoError(t, err)
 
-	bobKeysAndProof, err := generateKeys()
+	bobKeysAndProof, err := generateKeys(&dleq.FakeDLEq{})
 	require.NoError(t, err)
 
 	s.setBobKeys(bobKeysAndProof.PublicKeyPair.SpendKey(), bobKeysAndProof.PrivateKeyPair.ViewKey(),
 		bobKeysAndProof.Secp256k1PublicKey)
 
-	_, err = s.deployAndLockETH(common.NewEtherAmount(1))
+	_, err = s.lockEther(common.NewEtherAmount(1))
 	require.NoError(t, err)
 
-	s.info.SetReceivedAmount(0)
+	s.info.SetReceivedAmount(common.MustNewAmount("0.000000033333"))
 	kp := mcrypto.SumSpendAndViewKeys(bobKeysAndProof.PublicKeyPair, s.pubkeys)
-	xmrAddr := kp.Address(common.Mainnet)
-
-	msg := &net.NotifyXMRLock{
-		Address: string(xmrAddr),
-	}
+	msg := lockXMR(t, kp.Address(common.Mainnet), s.receivedAmountInPiconero())
 
 	resp, done, err := s.HandleProtocolMessage(msg)
 	require.NoError(t, err)
@@ -179,22 +216,18 @@ func TestSwapState_NotifyXMRLock_Refund(t *testing.T) {
 	err := s.generateAndSetKeys()
 	require.NoError(t, err)
 
-	bobKeysAndProof, err := generateKeys()
+	bobKeysAndProof, err := generateKeys(&dleq.FakeDLEq{})
 	require.NoError(t, err)
 
 	s.setBobKeys(bobKeysAndProof.PublicKeyPair.SpendKey(), bobKeysAndProof.PrivateKeyPair.ViewKey(),
 		bobKeysAndProof.Secp256k1PublicKey)
 
-	contractAddr, err := s.deployAndLockETH(common.NewEtherAmount(1))
+	id, err := s.lockEther(common.NewEtherAmount(1))
 	require.NoError(t, err)
 
-	s.info.SetReceivedAmount(0)
+	s.info.SetReceivedAmount(common.MustNewAmount("0.000000033333"))
 	kp := mcrypto.SumSpendAndViewKeys(bobKeysAndProof.PublicKeyPair, s.pubkeys)
-	xmrAddr := kp.Address(common.Mainnet)
-
-	msg := &net.NotifyXMRLock{
-		Address: string(xmrAddr),
-	}
+	msg := lockXMR(t, kp.Address(common.Mainnet), s.receivedAmountInPiconero())
 
 	resp, done, err := s.HandleProtocolMessage(msg)
 	require.NoError(t, err)
@@ -209,10 +242,39 @@ func TestSwapState_NotifyXMRLock_Refund(t *testing.T) {
 	require.NotNil(t, s.alice.net.(*mockNet).msg)
 	require.Equal(t, net.NotifyRefundType, s.alice.net.(*mockNet).msg.Type())
 
-	// check balance of contract is 0
-	balance, err := s.alice.ethClient.BalanceAt(s.ctx, contractAddr, nil)
+	// check the swap was refunded
+	info, err := s.contract.Swaps(s.alice.callOpts, id)
+	require.NoError(t, err)
+	require.Equal(t, swap.StageCompleted, info.Stage)
+}
+
+// test that Alice stops waiting to refund once Bob claims in the contract, even if he never notifies her
+func TestSwapState_ClaimedEvent(t *testing.T) {
+	_, s := newTestInstance(t)
+	defer s.cancel()
+
+	err := s.generateAndSetKeys()
+	require.NoError(t, err)
+
+	// use our own keys as Bob's, so that we can claim as him
+	s.setBobKeys(s.pubkeys.SpendKey(), s.privkeys.ViewKey(), s.secp256k1Pub)
+	s.bobAddress = s.alice.callOpts.From
+	_, err = s.lockEther(common.NewEtherAmount(1))
+	require.NoError(t, err)
+	err = s.setTimeouts()
+	require.NoError(t, err)
+	err = s.watchContract(0)
+	require.NoError(t, err)
+
+	// once the swap is ready, we need to wait until t1 to refund
+	err = s.ready()
 	require.NoError(t, err)
-	require.Equal(t, uint64(0), balance.Uint64())
+
+	_, err = s.contract.Claim(s.txOpts, s.contractSwapID, s.getSecret())
+	require.NoError(t, err)
+
+	_, err = s.tryRefund()
+	require.ErrorIs(t, err, errSwapClaimed)
 }
 
 func TestSwapState_NotifyClaimed(t *testing.T) {
@@ -249,12 +311,12 @@ func TestSwapState_NotifyClaimed(t *testing.T) {
 	daemonClient := monero.NewClient(common.DefaultMoneroDaemonEndpoint)
 	_ = daemonClient.GenerateBlocks(bobAddr.Address, 257)
 
-	s.info.SetReceivedAmount(33333)
+	s.info.SetReceivedAmount(common.MustNewAmount("0.000000033333"))
 	kp := mcrypto.SumSpendAndViewKeys(bobKeysAndProof.PublicKeyPair, s.pubkeys)
 	xmrAddr := kp.Address(common.Mainnet)
 
 	// lock xmr
-	_, err = s.alice.client.Transfer(xmrAddr, 0, uint(s.info.ReceivedAmount()))
+	lockTx, err := s.alice.client.Transfer(xmrAddr, 0, uint(s.receivedAmountInPiconero()))
 	require.NoError(t, err)
 	t.Log("transferred to account", xmrAddr)
 
@@ -267,6 +329,8 @@ func TestSwapState_NotifyClaimed(t *testing.T) {
 
 	lmsg := &net.NotifyXMRLock{
 		Address: string(xmrAddr),
+		TxHash:  lockTx.TxHash,
+		TxKey:   lockTx.TxKey,
 	}
 
 	resp, done, err = s.HandleProtocolMessage(lmsg)
@@ -279,12 +343,12 @@ func TestSwapState_NotifyClaimed(t *testing.T) {
 	require.NoError(t, err)
 
 	// simulate bob calling claim
-	// call swap.Swap.Claim() w/ b.privkeys.sk, revealing Bob's secret spend key
+	// call swap.SwapFactory.Claim() w/ b.privkeys.sk, revealing Bob's secret spend key
 	secret := bobKeysAndProof.PrivateKeyPair.SpendKeyBytes()
 	var sc [32]byte
 	copy(sc[:], common.Reverse(secret))
 
-	tx, err := s.contract.Claim(s.txOpts, sc)
+	tx, err := s.contract.Claim(s.txOpts, s.contractSwapID, sc)
 	require.NoError(t, err)
 
 	cmsg := &net.NotifyClaimed{
@@ -298,3 +362,56 @@ func TestSwapState_NotifyClaimed(t *testing.T) {
 
 	// check that wallet was generated
 }
+
+func TestSwapState_ClaimMonero_InvalidSecret(t *testing.T) {
+	bobKeys, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	otherKeys, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	ourKeys, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+
+	s := &swapState{alice: &Instance{}, privkeys: ourKeys}
+	s.setBobKeys(bobKeys.SpendKey().Public(), bobKeys.ViewKey(), nil)
+
+	_, err = s.claimMonero(otherKeys.SpendKey())
+	require.ErrorIs(t, err, errInvalidSecret)
+}
+
+func TestSwapState_Checkpoint_NewSwap(t *testing.T) {
+	sm := newTestSwapManager(t)
+	info := pswap.NewInfo(common.ProvidesETH, common.MustNewAmount("1"), common.MustNewAmount("10"),
+		common.MustNewExchangeRate("0.1"), pswap.Ongoing)
+	require.NoError(t, sm.AddSwap(info))
+
+	kp, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+
+	// the swap is checkpointed as soon as the transaction creating it is sent, before its ID is known
+	s := &swapState{
+		alice:               &Instance{swapManager: sm},
+		info:                info,
+		privkeys:            kp,
+		contractAddr:        ethcommon.Address{1},
+		newSwapTxHash:       ethcommon.Hash{2},
+		nextExpectedMessage: &net.SendKeysMessage{},
+	}
+	require.NoError(t, s.checkpoint())
+
+	cp, err := sm.GetCheckpoint(info.ID())
+	require.NoError(t, err)
+	require.Equal(t, net.NotifyXMRLockType, cp.NextExpectedMessage)
+	require.Equal(t, s.contractAddr, cp.ContractAddress)
+	require.Equal(t, s.newSwapTxHash, cp.NewSwapTxHash)
+	require.Equal(t, ethcommon.Hash{}, cp.ContractSwapID)
+
+	// once the ID is known, the transaction isn't needed to resume the swap
+	s.contractSwapID = ethcommon.Hash{3}
+	s.nextExpectedMessage = &net.NotifyXMRLock{}
+	require.NoError(t, s.checkpoint())
+
+	cp, err = sm.GetCheckpoint(info.ID())
+	require.NoError(t, err)
+	require.Equal(t, s.contractSwapID, cp.ContractSwapID)
+	require.Equal(t, ethcommon.Hash{}, cp.NewSwapTxHash)
+}
@@ -0,0 +1,65 @@
+package bob
+
+import (
+	"github.com/noot/atomic-swap/net"
+	pswap "github.com/noot/atomic-swap/protocol/swap"
+	"github.com/noot/atomic-swap/swap-contract"
+)
+
+// watchContract starts watching the swap contract for our swap's events, starting at the given
+// block, so that we claim once Alice sets the swap to ready, or reclaim our monero once she
+// refunds, even if she never notifies us.
+func (s *swapState) watchContract(fromBlock uint64) error {
+	w, err := swap.NewWatcher(&swap.WatcherConfig{
+		Ctx:       s.ctx,
+		Client:    s.bob.ethClient,
+		Address:   s.contractAddr,
+		SwapID:    s.contractSwapID,
+		FromBlock: fromBlock,
+	})
+	if err != nil {
+		return err
+	}
+
+	go s.handleContractEvents(w.Start())
+	return nil
+}
+
+func (s *swapState) handleContractEvents(events <-chan *swap.Event) {
+	for ev := range events {
+		if err := s.handleContractEvent(ev); err != nil {
+			log.Errorf("failed to handle contract event: id=%d event=%s err=%s", s.ID(), ev.Type, err)
+		}
+	}
+}
+
+func (s *swapState) handleContractEvent(ev *swap.Event) error {
+	s.Lock()
+	defer s.Unlock()
+
+	// the swap may have already completed, eg. upon a network message from Alice
+	if s.ctx.Err() != nil || s.info.Status() != pswap.Ongoing {
+		return nil
+	}
+
+	switch ev.Type {
+	case swap.EventReady:
+		log.Infof("swap set to ready in contract: id=%d tx=%s", s.ID(), ev.Log.TxHash)
+		txHash, err := s.handleReady(ev.Log.TxHash)
+		if err != nil {
+			return err
+		}
+
+		// let Alice know, in case she's still waiting for us
+		if err := s.bob.net.SendSwapMessage(s.ID(), &net.NotifyClaimed{
+			TxHash: txHash.String(),
+		}); err != nil {
+			log.Warnf("failed to send NotifyClaimed message: err=%s", err)
+		}
+	case swap.EventRefunded:
+		log.Infof("swap refunded in contract: id=%d tx=%s", s.ID(), ev.Log.TxHash)
+		return s.handleRefundSecret(ev.Secret, ev.Log.TxHash.String())
+	}
+
+	return nil
+}
@@ -2,10 +2,10 @@ package bob
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"errors"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
@@ -13,8 +13,11 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 
 	"github.com/noot/atomic-swap/common"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+	"github.com/noot/atomic-swap/dleq"
 	"github.com/noot/atomic-swap/monero"
 	"github.com/noot/atomic-swap/net"
+	pcommon "github.com/noot/atomic-swap/protocol"
 	"github.com/noot/atomic-swap/protocol/swap"
 
 	logging "github.com/ipfs/go-log"
@@ -31,25 +34,48 @@ type Instance struct {
 	env      common.Environment
 	basepath string
 
-	client                     monero.Client
-	daemonClient               monero.DaemonClient
-	walletFile, walletPassword string
+	// passphrase the swap secrets we write to the basepath and the swap database are encrypted
+	// with; if empty, they're written in plaintext
+	secretsPassphrase string
+
+	client       monero.Client
+	daemonClient monero.DaemonClient
+
+	// monero-wallet-rpc only has one wallet open at a time, so swaps must not switch the open
+	// wallet concurrently; the lock also tracks our main wallet, which holds our XMR
+	walletLock *monero.WalletLock
 
 	ethClient  *ethclient.Client
-	ethPrivKey *ecdsa.PrivateKey
+	signer     pcommon.Signer
 	callOpts   *bind.CallOpts
 	ethAddress ethcommon.Address
 	chainID    *big.Int
 	gasPrice   *big.Int
 	gasLimit   uint64
 
+	// sends our transactions, replacing them with higher fees if they're stuck
+	txManager *pcommon.TxManager
+
+	// confirmations Alice's swap must have in the contract before we lock our XMR
+	ethConfirmations uint64
+
+	// default address to sweep reclaimed XMR to; it can be changed for each swap
+	sweepAddress mcrypto.Address
+
+	dleq dleq.Interface
+
+	// if set, each swap must be confirmed by the user before our funds are locked
+	manualConfirm  bool
+	confirmTimeout time.Duration
+
 	net net.MessageSender
 
-	offerManager *offerManager
+	offerManager *pcommon.OfferManager
 	swapManager  *swap.Manager
 
-	swapMu    sync.Mutex
-	swapState *swapState
+	// swaps currently in progress, keyed by swap ID
+	swapMu     sync.Mutex
+	swapStates map[uint64]*swapState
 }
 
 // Config contains the configuration values for a new Bob instance.
@@ -61,11 +87,40 @@ type Config struct {
 	WalletFile, WalletPassword string
 	EthereumEndpoint           string
 	EthereumPrivateKey         string
+	Signer                     pcommon.Signer // signs our transactions; used instead of EthereumPrivateKey if set
 	Environment                common.Environment
 	ChainID                    int64
 	GasPrice                   *big.Int
 	SwapManager                *swap.Manager
 	GasLimit                   uint64
+	DLEq                       dleq.Interface // defaults to the farcaster-dleq binaries if nil
+	ManualConfirm              bool           // require swaps to be confirmed with swap_confirm before locking funds
+	ConfirmTimeout             time.Duration  // defaults to protocol.DefaultConfirmTimeout if zero
+	EthereumConfirmations      uint64         // confirmations Alice's swap must have; defaults to 1 if zero
+
+	// FeeOracle suggests the fees for our transactions, unless a fixed gas price is set; if nil,
+	// fees are based on the ethereum node's suggestions, capped by MaxFeePerGas and
+	// MaxPriorityFeePerGas (in wei) if they're set. They're only used if TxManager is nil.
+	FeeOracle            pcommon.FeeOracle
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+
+	// TxManager sends our transactions; it must be shared by every instance sending transactions
+	// from the same account, so that they don't use the same nonces. If nil, a new one is created.
+	TxManager *pcommon.TxManager
+
+	// SecretsPassphrase encrypts the swap secrets we write to the basepath and the swap database,
+	// so that they can only be used, eg. to recover a swap, with the passphrase; if empty, they're
+	// written in plaintext.
+	SecretsPassphrase string
+
+	// SweepAddress is the address XMR we reclaim after a refund is swept to once it's unlocked,
+	// unless changed for a swap; if empty, it's left in the swap wallet.
+	SweepAddress mcrypto.Address
+
+	// WalletLock must be shared by every instance using the same monero-wallet-rpc endpoint, so
+	// that they don't change its open wallet while another is using it. If nil, a new one is created.
+	WalletLock *monero.WalletLock
 }
 
 // NewInstance returns a new *bob.Instance.
@@ -75,9 +130,14 @@ func NewInstance(cfg *Config) (*Instance, error) {
 		return nil, errors.New("environment is development, must provide monero daemon endpoint")
 	}
 
-	pk, err := crypto.HexToECDSA(cfg.EthereumPrivateKey)
-	if err != nil {
-		return nil, err
+	signer := cfg.Signer
+	if signer == nil {
+		pk, err := crypto.HexToECDSA(cfg.EthereumPrivateKey) //nolint:govet
+		if err != nil {
+			return nil, err
+		}
+
+		signer = pcommon.NewEthereumKey(pk)
 	}
 
 	ec, err := ethclient.Dial(cfg.EthereumEndpoint)
@@ -85,21 +145,57 @@ func NewInstance(cfg *Config) (*Instance, error) {
 		return nil, err
 	}
 
-	pub := pk.Public().(*ecdsa.PublicKey)
-	addr := crypto.PubkeyToAddress(*pub)
+	addr := signer.Address()
+
+	txManager := cfg.TxManager
+	if txManager == nil {
+		txManager = pcommon.NewTxManager(&pcommon.TxManagerConfig{
+			Client:               ec,
+			FeeOracle:            cfg.FeeOracle,
+			From:                 addr,
+			Signer:               signer,
+			ChainID:              big.NewInt(cfg.ChainID),
+			MaxFeePerGas:         cfg.MaxFeePerGas,
+			MaxPriorityFeePerGas: cfg.MaxPriorityFeePerGas,
+		})
+	}
+
+	d := cfg.DLEq
+	if d == nil {
+		d = &dleq.FarcasterDLEq{}
+	}
 
 	// monero-wallet-rpc client
 	walletClient := monero.NewClient(cfg.MoneroWalletEndpoint)
 
+	walletLock := cfg.WalletLock
+	if walletLock == nil {
+		walletLock = monero.NewWalletLock(walletClient)
+	}
+
 	// open Bob's XMR wallet
 	if cfg.WalletFile != "" {
-		if err = walletClient.OpenWallet(cfg.WalletFile, cfg.WalletPassword); err != nil {
+		walletLock.Lock()
+		err = walletLock.SetMainWallet(cfg.WalletFile, cfg.WalletPassword)
+		walletLock.Unlock()
+		if err != nil {
 			return nil, err
 		}
 	} else {
 		log.Warn("monero wallet-file not set; must be set via RPC call personal_setMoneroWalletFile before making an offer")
 	}
 
+	ethConfirmations := cfg.EthereumConfirmations
+	if ethConfirmations == 0 {
+		ethConfirmations = 1
+	}
+
+	if cfg.SweepAddress != "" {
+		if err = monero.ValidateSweepAddress(walletClient, cfg.SweepAddress, cfg.Environment); err != nil {
+			return nil, err
+		}
+	}
+
 	// this is only used in the monero development environment to generate new blocks
 	var daemonClient monero.DaemonClient
 	if cfg.Environment == common.Development {
@@ -107,23 +203,32 @@ func NewInstance(cfg *Config) (*Instance, error) {
 	}
 
 	return &Instance{
-		ctx:            cfg.Ctx,
-		basepath:       cfg.Basepath,
-		env:            cfg.Environment,
-		client:         walletClient,
-		daemonClient:   daemonClient,
-		walletFile:     cfg.WalletFile,
-		walletPassword: cfg.WalletPassword,
-		ethClient:      ec,
-		ethPrivKey:     pk,
+		ctx:               cfg.Ctx,
+		basepath:          cfg.Basepath,
+		secretsPassphrase: cfg.SecretsPassphrase,
+		env:               cfg.Environment,
+		client:            walletClient,
+		daemonClient:      daemonClient,
+		walletLock:        walletLock,
+		ethClient:         ec,
+		signer:            signer,
 		callOpts: &bind.CallOpts{
 			From:    addr,
 			Context: cfg.Ctx,
 		},
-		ethAddress:   addr,
-		chainID:      big.NewInt(cfg.ChainID),
-		offerManager: newOfferManager(),
-		swapManager:  cfg.SwapManager,
+		ethAddress:       addr,
+		chainID:          big.NewInt(cfg.ChainID),
+		gasPrice:         cfg.GasPrice,
+		gasLimit:         cfg.GasLimit,
+		txManager:        txManager,
+		ethConfirmations: ethConfirmations,
+		sweepAddress:     cfg.SweepAddress,
+		dleq:             d,
+		manualConfirm:    cfg.ManualConfirm,
+		confirmTimeout:   cfg.ConfirmTimeout,
+		offerManager:     pcommon.NewOfferManager(),
+		swapManager:      cfg.SwapManager,
+		swapStates:       make(map[uint64]*swapState),
 	}, nil
 }
 
@@ -134,8 +239,9 @@ func (b *Instance) SetMessageSender(n net.MessageSender) {
 
 // SetMoneroWalletFile sets the Instance's current monero wallet file.
 func (b *Instance) SetMoneroWalletFile(file, password string) error {
-	_ = b.client.CloseWallet()
-	return b.client.OpenWallet(file, password)
+	b.walletLock.Lock()
+	defer b.walletLock.Unlock()
+	return b.walletLock.SetMainWallet(file, password)
 }
 
 // SetGasPrice sets the ethereum gas price for the instance to use (in wei).
@@ -143,6 +249,32 @@ func (b *Instance) SetGasPrice(gasPrice uint64) {
 	b.gasPrice = big.NewInt(0).SetUint64(gasPrice)
 }
 
-func (b *Instance) openWallet() error { //nolint
-	return b.client.OpenWallet(b.walletFile, b.walletPassword)
+// ValidateSweepAddress checks that XMR can be swept to the given address.
+func (b *Instance) ValidateSweepAddress(address mcrypto.Address) error {
+	return monero.ValidateSweepAddress(b.client, address, b.env)
+}
+
+// startSweep sweeps the XMR reclaimed in the given swap wallet to the swap's sweep address in the
+// background, once it's unlocked. It does nothing if the swap has no sweep address.
+func (b *Instance) startSweep(info *swap.Info, walletName string) {
+	if info.SweepAddress() == "" {
+		return
+	}
+
+	go func() {
+		txHashes, err := monero.WaitAndSweep(&monero.SweepConfig{
+			Ctx:        b.ctx,
+			Client:     b.client,
+			WalletName: walletName,
+			To:         info.SweepAddress(),
+			WalletMu:   b.walletLock,
+			Restore:    b.walletLock.RestoreMainWallet,
+		})
+		if err != nil {
+			log.Errorf("failed to sweep XMR: id=%d wallet=%s err=%s", info.ID(), walletName, err)
+			return
+		}
+
+		info.SetSweepTxHashes(txHashes)
+	}()
 }
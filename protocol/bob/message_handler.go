@@ -32,11 +32,25 @@ func (s *swapState) HandleProtocolMessage(msg net.Message) (net.Message, bool, e
 
 	switch msg := msg.(type) {
 	case *net.SendKeysMessage:
+		// we took Alice's offer, and this is her response to our keys
+		if msg.ProvidedAmount.Cmp(s.info.ReceivedAmount()) != 0 {
+			return nil, true, net.NewRejectError(net.RejectAmountMismatch,
+				"expected to receive %s ETH, but counterparty is providing %s ETH",
+				s.info.ReceivedAmount(), msg.ProvidedAmount)
+		}
+
 		if err := s.handleSendKeysMessage(msg); err != nil {
 			return nil, true, err
 		}
 
-		return nil, false, nil
+		if s.bob.manualConfirm {
+			if err := pcommon.AwaitConfirmation(s.ctx, s.info, s.bob.confirmTimeout); err != nil {
+				return nil, true, err
+			}
+		}
+
+		// Alice can now deploy the contract
+		return &net.NotifyKeysAccepted{}, false, nil
 	case *net.NotifyContractDeployed:
 		out, err := s.handleNotifyContractDeployed(msg)
 		if err != nil {
@@ -45,31 +59,33 @@ func (s *swapState) HandleProtocolMessage(msg net.Message) (net.Message, bool, e
 
 		return out, false, nil
 	case *net.NotifyReady:
-		log.Debug("contract ready, attempting to claim funds...")
-		close(s.readyCh)
+		// we may have already claimed upon seeing the contract's Ready event
+		if s.info.Status() != pswap.Ongoing {
+			return nil, true, nil
+		}
 
-		// contract ready, let's claim our ether
-		txHash, err := s.claimFunds()
+		// Alice doesn't tell us the transaction which set the swap to ready
+		txHash, err := s.handleReady(ethcommon.Hash{})
 		if err != nil {
-			return nil, true, fmt.Errorf("failed to redeem ether: %w", err)
+			return nil, true, err
 		}
 
-		log.Debug("funds claimed!!")
 		out := &net.NotifyClaimed{
 			TxHash: txHash.String(),
 		}
 
-		s.info.SetStatus(pswap.Success)
 		return out, true, nil
 	case *net.NotifyRefund:
+		// we may have already reclaimed upon seeing the contract's Refunded event
+		if s.info.Status() != pswap.Ongoing {
+			return nil, true, nil
+		}
+
 		// generate monero wallet, regaining control over locked funds
-		addr, err := s.handleRefund(msg.TxHash)
-		if err != nil {
+		if err := s.handleRefund(msg.TxHash); err != nil {
 			return nil, false, err
 		}
 
-		s.info.SetStatus(pswap.Refunded)
-		log.Infof("regained control over monero account %s", addr)
 		return nil, true, nil
 	default:
 		return nil, true, errors.New("unexpected message type")
@@ -94,35 +110,62 @@ func (s *swapState) handleNotifyContractDeployed(msg *net.NotifyContractDeployed
 		return nil, errMissingAddress
 	}
 
-	log.Infof("got Swap contract address! address=%s", msg.Address)
+	if msg.SwapID == "" {
+		return nil, errMissingSwapID
+	}
+
+	log.Infof("got swap contract address and swap ID! address=%s id=%s", msg.Address, msg.SwapID)
 
-	if err := s.setContract(ethcommon.HexToAddress(msg.Address)); err != nil {
+	// the contract is given by Alice, so it must be checked to really be a SwapFactory before we
+	// trust the swap it reports, or reveal our secret to it by claiming
+	address := ethcommon.HexToAddress(msg.Address)
+	if err := swap.CheckSwapFactoryContract(s.ctx, s.bob.ethClient, address); err != nil {
+		return nil, err
+	}
+
+	if err := s.setContract(address, ethcommon.HexToHash(msg.SwapID)); err != nil {
 		return nil, fmt.Errorf("failed to instantiate contract instance: %w", err)
 	}
 
+	s.info.SetContractAddress(s.contractAddr)
+	s.info.SetContractSwapID(s.contractSwapID)
+
 	fp := fmt.Sprintf("%s/%d/contractaddress", s.bob.basepath, s.ID())
-	if err := common.WriteContractAddressToFile(fp, msg.Address); err != nil {
+	if err := common.WriteContractAddressToFile(fp, msg.Address, msg.SwapID); err != nil {
 		return nil, fmt.Errorf("failed to write contract address to file: %w", err)
 	}
 
-	if err := s.checkContract(); err != nil {
-		return nil, err
+	// events emitted from here on are for a swap we've checked, so we watch for them from here
+	fromBlock, err := s.bob.ethClient.BlockNumber(s.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block number: %w", err)
 	}
+	s.fromBlock = fromBlock
 
-	addrAB, err := s.lockFunds(common.MoneroToPiconero(s.info.ProvidedAmount()))
-	if err != nil {
-		return nil, fmt.Errorf("failed to lock funds: %w", err)
+	if err := s.waitForSwapConfirmations(); err != nil {
+		return nil, err
 	}
 
-	out := &net.NotifyXMRLock{
-		Address: string(addrAB),
+	if err := s.checkContract(); err != nil {
+		return nil, err
 	}
 
-	// set t0 and t1
+	s.info.SetStage(pswap.StageETHLocked, "")
+
+	// set t0 and t1, which are needed to claim, or reclaim, once our XMR is locked
 	if err := s.setTimeouts(); err != nil {
 		return nil, err
 	}
 
+	out, err := s.lockFunds(common.MoneroToPiconero(s.info.ProvidedAmount()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock funds: %w", err)
+	}
+
+	if err = s.watchContract(fromBlock); err != nil {
+		return nil, fmt.Errorf("failed to watch swap contract: %w", err)
+	}
+
 	go func() {
 		until := time.Until(s.t0)
 
@@ -144,7 +187,7 @@ func (s *swapState) handleNotifyContractDeployed(msg *net.NotifyContractDeployed
 			s.info.SetStatus(pswap.Success)
 
 			// send *net.NotifyClaimed
-			if err := s.bob.net.SendSwapMessage(&net.NotifyClaimed{
+			if err := s.bob.net.SendSwapMessage(s.ID(), &net.NotifyClaimed{
 				TxHash: txHash.String(),
 			}); err != nil {
 				log.Errorf("failed to send NotifyClaimed message: err=%s", err)
@@ -171,30 +214,73 @@ func (s *swapState) handleSendKeysMessage(msg *net.SendKeysMessage) error {
 	}
 
 	// verify counterparty's DLEq proof and ensure the resulting secp256k1 key is correct
-	secp256k1Pub, err := pcommon.VerifyKeysAndProof(msg.DLEqProof, msg.Secp256k1PublicKey)
+	secp256k1Pub, err := pcommon.VerifyKeysAndProof(s.bob.dleq, msg.DLEqProof, msg.Secp256k1PublicKey)
 	if err != nil {
 		return err
 	}
 
 	s.setAlicePublicKeys(kp, secp256k1Pub)
+	s.info.SetStage(pswap.StageKeysExchanged, "")
 	s.nextExpectedMessage = &net.NotifyContractDeployed{}
 	return nil
 }
 
-func (s *swapState) handleRefund(txHash string) (mcrypto.Address, error) {
+// handleReady claims our ether, or tokens, once Alice has set the swap to ready in the transaction
+// with the given hash, or in a transaction we look up if it's unknown.
+func (s *swapState) handleReady(readyTxHash ethcommon.Hash) (ethcommon.Hash, error) {
+	// claiming reveals our secret, so we must be sure that the swap is still ready, ie. that
+	// Alice can't refund, by the time our claim is included
+	if err := s.waitForReadyConfirmations(readyTxHash); err != nil {
+		return ethcommon.Hash{}, err
+	}
+
+	log.Debug("contract ready, attempting to claim funds...")
+	select {
+	case <-s.readyCh:
+	default:
+		close(s.readyCh)
+	}
+
+	s.info.SetStage(pswap.StageContractReady, "")
+
+	// contract ready, let's claim our ether
+	txHash, err := s.claimFunds()
+	if err != nil {
+		return ethcommon.Hash{}, fmt.Errorf("failed to redeem ether: %w", err)
+	}
+
+	log.Debug("funds claimed!!")
+	s.info.SetStatus(pswap.Success)
+	return txHash, nil
+}
+
+// handleRefund regains control over our locked monero using Alice's secret, which she revealed
+// by calling Refund() in the transaction with the given hash.
+func (s *swapState) handleRefund(txHash string) error {
 	receipt, err := s.bob.ethClient.TransactionReceipt(s.ctx, ethcommon.HexToHash(txHash))
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	if len(receipt.Logs) == 0 {
-		return "", errors.New("claim transaction has no logs")
+	// the transaction is given by Alice, so it may not be a refund of our swap
+	sa, err := swap.GetSecretFromLogs(s.contractAddr, s.contractSwapID, receipt.Logs, "Refunded")
+	if err != nil {
+		return fmt.Errorf("failed to get secret from refund transaction: %w", err)
 	}
 
-	sa, err := swap.GetSecretFromLog(receipt.Logs[0], "Refunded")
+	return s.handleRefundSecret(sa, txHash)
+}
+
+func (s *swapState) handleRefundSecret(sa *mcrypto.PrivateSpendKey, txHash string) error {
+	// generate monero wallet, regaining control over locked funds
+	addr, err := s.reclaimMonero(sa)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	return s.reclaimMonero(sa)
+	s.moneroReclaimAddress = addr
+	s.info.SetStage(pswap.StageRefunded, txHash)
+	s.info.SetStatus(pswap.Refunded)
+	log.Infof("regained control over monero account %s", addr)
+	return nil
 }
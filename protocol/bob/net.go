@@ -2,12 +2,15 @@ package bob
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/noot/atomic-swap/common"
 	"github.com/noot/atomic-swap/common/types"
 	"github.com/noot/atomic-swap/net"
+	pcommon "github.com/noot/atomic-swap/protocol"
 
 	"github.com/fatih/color" //nolint:misspell
+	"github.com/libp2p/go-libp2p-core/peer"
 )
 
 // Provides returns common.ProvidesXMR
@@ -15,37 +18,87 @@ func (b *Instance) Provides() common.ProvidesCoin {
 	return common.ProvidesXMR
 }
 
-func (b *Instance) initiate(offerID types.Hash, providesAmount common.MoneroAmount,
-	desiredAmount common.EtherAmount) error {
-	b.swapMu.Lock()
-	defer b.swapMu.Unlock()
+// InitiateProtocol is called when an RPC call is made from the user to initiate a swap by taking
+// an offer which provides ETH or an ERC-20 token. The input units are monero that we will provide.
+// The amount of ETH or tokens we expect to receive is determined by the offer's exchange rate, and
+// must be within the offer's limits.
+func (b *Instance) InitiateProtocol(providesAmount common.Amount, offer *types.Offer,
+	counterparty peer.ID) (net.SwapState, error) {
+	if !offer.Provides.IsEthereumAsset() {
+		return nil, errors.New("offer must provide ETH or an ERC-20 token")
+	}
+
+	if offer.IsExpired() {
+		return nil, errors.New("offer has expired")
+	}
+
+	if providesAmount.IsZero() {
+		return nil, errors.New("provided amount must be greater than zero")
+	}
+
+	if common.MoneroToPiconero(providesAmount).AsMonero().Cmp(providesAmount) != 0 {
+		return nil, errors.New("provided amount must be a whole number of piconero that fits in a uint64")
+	}
 
-	if b.swapState != nil {
-		return errors.New("protocol already in progress")
+	receivedAmount := offer.ExchangeRate.ToETH(providesAmount)
+	if receivedAmount.Cmp(offer.MinimumAmount) < 0 {
+		return nil, fmt.Errorf("amount to be received is less than offer minimum: got %s, minimum %s",
+			receivedAmount, offer.MinimumAmount)
 	}
 
+	if receivedAmount.Cmp(offer.MaximumAmount) > 0 {
+		return nil, fmt.Errorf("amount to be received is more than offer maximum: got %s, maximum %s",
+			receivedAmount, offer.MaximumAmount)
+	}
+
+	s, err := b.initiate(offer, common.MoneroToPiconero(providesAmount), receivedAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	s.info.SetCounterparty(counterparty)
+	return s, nil
+}
+
+func (b *Instance) initiate(offer *types.Offer, providesAmount common.MoneroAmount,
+	desiredAmount common.Amount) (*swapState, error) {
+	b.swapMu.Lock()
+	defer b.swapMu.Unlock()
+
+	b.walletLock.Lock()
 	balance, err := b.client.GetBalance(0)
+	b.walletLock.Unlock()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// check user's balance and that they actually have what they will provide
-	if balance.UnlockedBalance <= float64(providesAmount) {
-		return errors.New("balance lower than amount to be provided")
+	if common.MoneroAmount(balance.UnlockedBalance) <= providesAmount {
+		return nil, net.NewRejectError(net.RejectOfferUnavailable, "balance lower than amount to be provided")
 	}
 
-	b.swapState, err = newSwapState(b, offerID, providesAmount, desiredAmount)
+	s, err := newSwapState(b, offer.GetID(), providesAmount, desiredAmount)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	log.Info(color.New(color.Bold).Sprintf("**initiated swap with ID=%d**", b.swapState.ID()))
+	s.token = offer.Token
+
+	b.swapStates[s.ID()] = s
+
+	log.Info(color.New(color.Bold).Sprintf("**initiated swap with ID=%d**", s.ID()))
 	log.Info(color.New(color.Bold).Sprint("DO NOT EXIT THIS PROCESS OR FUNDS MAY BE LOST!"))
-	return nil
+	return s, nil
+}
+
+func (b *Instance) removeSwapState(id uint64) {
+	b.swapMu.Lock()
+	defer b.swapMu.Unlock()
+	delete(b.swapStates, id)
 }
 
 // HandleInitiateMessage is called when we receive a network message from a peer that they wish to initiate a swap.
-func (b *Instance) HandleInitiateMessage(msg *net.SendKeysMessage) (net.SwapState, net.Message, error) {
+func (b *Instance) HandleInitiateMessage(who peer.ID, msg *net.SendKeysMessage) (net.SwapState, net.Message, error) {
 	str := color.New(color.Bold).Sprintf("**incoming take of offer %s with provided amount %v**",
 		msg.OfferID,
 		msg.ProvidedAmount,
@@ -58,33 +111,57 @@ func (b *Instance) HandleInitiateMessage(msg *net.SendKeysMessage) (net.SwapStat
 		return nil, nil, err
 	}
 
-	offer := b.offerManager.getOffer(id)
+	offer := b.offerManager.GetOffer(id)
 	if offer == nil {
-		return nil, nil, errors.New("failed to find offer with given ID")
+		return nil, nil, net.NewRejectError(net.RejectOfferUnavailable, "failed to find offer with given ID")
+	}
+
+	if msg.ProvidedAmount.IsZero() {
+		return nil, nil, net.NewRejectError(net.RejectAmountOutOfRange, "amount provided by taker must not be zero")
 	}
 
 	providedAmount := offer.ExchangeRate.ToXMR(msg.ProvidedAmount)
 
-	if providedAmount < offer.MinimumAmount {
-		return nil, nil, errors.New("amount provided by taker is too low for offer")
+	if providedAmount.Cmp(offer.MinimumAmount) < 0 {
+		return nil, nil, net.NewRejectError(net.RejectAmountOutOfRange,
+			"amount provided by taker is too low for offer: %s XMR, minimum %s", providedAmount, offer.MinimumAmount)
+	}
+
+	if providedAmount.Cmp(offer.MaximumAmount) > 0 {
+		return nil, nil, net.NewRejectError(net.RejectAmountOutOfRange,
+			"amount provided by taker is too high for offer: %s XMR, maximum %s", providedAmount, offer.MaximumAmount)
 	}
 
-	if providedAmount > offer.MaximumAmount {
-		return nil, nil, errors.New("amount provided by taker is too low for offer")
+	s, err := b.initiate(offer, common.MoneroToPiconero(providedAmount), msg.ProvidedAmount)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if err = b.initiate(id, common.MoneroToPiconero(providedAmount), common.EtherToWei(msg.ProvidedAmount)); err != nil { //nolint:lll
+	// reserve the amount, so that concurrent swaps can't take more than the offer has available
+	if err = b.offerManager.Reserve(id, s.ID(), providedAmount); err != nil {
+		_ = s.ProtocolExited()
 		return nil, nil, err
 	}
 
-	if err = b.swapState.handleSendKeysMessage(msg); err != nil {
+	s.info.SetCounterparty(who)
+
+	if err = s.handleSendKeysMessage(msg); err != nil {
+		_ = s.ProtocolExited()
 		return nil, nil, err
 	}
 
-	resp, err := b.swapState.SendKeysMessage()
+	if b.manualConfirm {
+		if err = pcommon.AwaitConfirmation(s.ctx, s.info, b.confirmTimeout); err != nil {
+			_ = s.ProtocolExited()
+			return nil, nil, err
+		}
+	}
+
+	resp, err := s.SendKeysMessage()
 	if err != nil {
+		_ = s.ProtocolExited()
 		return nil, nil, err
 	}
 
-	return b.swapState, resp, nil
+	return s, resp, nil
 }
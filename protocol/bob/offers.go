@@ -5,53 +5,70 @@ import (
 
 	"github.com/noot/atomic-swap/common"
 	"github.com/noot/atomic-swap/common/types"
+	pcommon "github.com/noot/atomic-swap/protocol"
 )
 
-type offerManager struct {
-	offers map[types.Hash]*types.Offer
-}
-
-func newOfferManager() *offerManager {
-	return &offerManager{
-		offers: make(map[types.Hash]*types.Offer),
+// checkBalance returns an error if our unlocked balance doesn't cover the offer's maximum amount.
+func (b *Instance) checkBalance(o *types.Offer) error {
+	b.walletLock.Lock()
+	balance, err := b.client.GetBalance(0)
+	b.walletLock.Unlock()
+	if err != nil {
+		return err
 	}
-}
-
-func (om *offerManager) putOffer(o *types.Offer) {
-	om.offers[o.GetID()] = o
-}
 
-func (om *offerManager) getOffer(id types.Hash) *types.Offer {
-	return om.offers[id]
-}
+	if common.MoneroAmount(balance.UnlockedBalance) < common.MoneroToPiconero(o.MaximumAmount) {
+		return errors.New("unlocked balance is less than maximum offer amount")
+	}
 
-func (om *offerManager) deleteOffer(id types.Hash) {
-	delete(om.offers, id)
+	return nil
 }
 
 // MakeOffer makes a new swap offer.
 func (b *Instance) MakeOffer(o *types.Offer) error {
-	balance, err := b.client.GetBalance(0)
-	if err != nil {
+	if err := b.checkBalance(o); err != nil {
 		return err
 	}
 
-	if common.MoneroAmount(balance.UnlockedBalance) < common.MoneroToPiconero(o.MaximumAmount) {
-		return errors.New("unlocked balance is less than maximum offer amount")
+	if err := b.offerManager.PutOffer(o); err != nil {
+		return err
 	}
 
-	b.offerManager.putOffer(o)
 	log.Infof("created new offer: %v", o)
 	return nil
 }
 
+// UpdateOffer replaces the terms of the offer with the same ID as o. The offer keeps its ID,
+// so takers who already know of it can still take it, on the new terms.
+func (b *Instance) UpdateOffer(o *types.Offer) error {
+	if b.offerManager.GetOffer(o.ID) == nil {
+		return pcommon.ErrOfferNotFound
+	}
+
+	if err := b.checkBalance(o); err != nil {
+		return err
+	}
+
+	if err := b.offerManager.PutOffer(o); err != nil {
+		return err
+	}
+
+	log.Infof("updated offer: %v", o)
+	return nil
+}
+
+// CancelOffer removes the offer with the given ID, so that it can no longer be taken.
+// Swaps which have already been initiated from the offer aren't affected.
+func (b *Instance) CancelOffer(id types.Hash) error {
+	if !b.offerManager.DeleteOffer(id) {
+		return pcommon.ErrOfferNotFound
+	}
+
+	log.Infof("cancelled offer: id=%s", id)
+	return nil
+}
+
 // GetOffers returns all current offers.
 func (b *Instance) GetOffers() []*types.Offer {
-	offers := make([]*types.Offer, len(b.offerManager.offers))
-	i := 0
-	for _, o := range b.offerManager.offers {
-		offers[i] = o
-		i++
-	}
-	return offers
+	return b.offerManager.GetOffers()
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 
 	mcrypto "github.com/noot/atomic-swap/crypto/monero"
@@ -18,11 +17,8 @@ type recoveryState struct {
 // NewRecoveryState returns a new *bob.recoveryState,
 // which has methods to either claim ether or reclaim monero from an initiated swap.
 func NewRecoveryState(b *Instance, secret *mcrypto.PrivateSpendKey,
-	contractAddr ethcommon.Address) (*recoveryState, error) { //nolint:revive
-	txOpts, err := bind.NewKeyedTransactorWithChainID(b.ethPrivKey, b.chainID)
-	if err != nil {
-		return nil, err
-	}
+	contractAddr ethcommon.Address, contractSwapID ethcommon.Hash) (*recoveryState, error) { //nolint:revive
+	txOpts := b.signer.TransactOpts(b.chainID)
 
 	kp, err := secret.AsPrivateKeyPair()
 	if err != nil {
@@ -48,7 +44,7 @@ func NewRecoveryState(b *Instance, secret *mcrypto.PrivateSpendKey,
 		dleqProof: dleq.NewProofWithSecret(sc),
 	}
 
-	if err := s.setContract(contractAddr); err != nil {
+	if err := s.setContract(contractAddr, contractSwapID); err != nil {
 		return nil, err
 	}
 	return &recoveryState{
@@ -85,7 +81,12 @@ func (rs *recoveryState) ClaimOrRecover() (*RecoveryResult, error) {
 			return nil, err
 		}
 
-		rs.ss.setAlicePublicKeys(kpA.PublicKeyPair(), nil)
+		// Alice's keys are only known if we're resuming a swap, rather than recovering it from
+		// its secret
+		if rs.ss.alicePublicKeys == nil {
+			rs.ss.setAlicePublicKeys(kpA.PublicKeyPair(), nil)
+		}
+
 		addr, err := rs.ss.reclaimMonero(skA)
 		if err != nil {
 			return nil, err
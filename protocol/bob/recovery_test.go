@@ -21,8 +21,8 @@ func newTestRecoveryState(t *testing.T) *recoveryState {
 
 	duration, err := time.ParseDuration("1440m")
 	require.NoError(t, err)
-	addr, _ := deploySwap(t, inst, s, sr, big.NewInt(1), duration)
-	rs, err := NewRecoveryState(inst, s.privkeys.SpendKey(), addr)
+	addr, id, _ := deploySwap(t, inst, s, sr, big.NewInt(1), duration)
+	rs, err := NewRecoveryState(inst, s.privkeys.SpendKey(), addr, id)
 	require.NoError(t, err)
 
 	return rs
@@ -33,7 +33,7 @@ func TestClaimOrRecover_Claim(t *testing.T) {
 	rs := newTestRecoveryState(t)
 
 	// set contract to Ready
-	_, err := rs.ss.contract.SetReady(rs.ss.txOpts)
+	_, err := rs.ss.contract.SetReady(rs.ss.txOpts, rs.ss.contractSwapID)
 	require.NoError(t, err)
 
 	// assert we can claim ether
@@ -53,17 +53,17 @@ func TestClaimOrRecover_Recover(t *testing.T) {
 
 	// lock XMR
 	rs.ss.setAlicePublicKeys(rs.ss.pubkeys, nil)
-	addrAB, err := rs.ss.lockFunds(333)
+	lock, err := rs.ss.lockFunds(333)
 	require.NoError(t, err)
 
 	// call refund w/ Alice's spend key
 	sc := rs.ss.getSecret()
-	_, err = rs.ss.contract.Refund(rs.ss.txOpts, sc)
+	_, err = rs.ss.contract.Refund(rs.ss.txOpts, rs.ss.contractSwapID, sc)
 	require.NoError(t, err)
 
 	// assert Bob can reclaim his monero
 	res, err := rs.ClaimOrRecover()
 	require.NoError(t, err)
 	require.True(t, res.Recovered)
-	require.Equal(t, addrAB, res.MoneroAddress)
+	require.Equal(t, lock.Address, string(res.MoneroAddress))
 }
@@ -0,0 +1,237 @@
+package bob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color" //nolint:misspell
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/crypto"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+	"github.com/noot/atomic-swap/crypto/secp256k1"
+	"github.com/noot/atomic-swap/dleq"
+	"github.com/noot/atomic-swap/net"
+	pswap "github.com/noot/atomic-swap/protocol/swap"
+	"github.com/noot/atomic-swap/swap-contract"
+)
+
+// checkpoint writes the current protocol state to the swap database, so that the swap
+// can be resumed if swapd exits before the swap completes.
+func (s *swapState) checkpoint() error {
+	if s.info == nil || s.privkeys == nil {
+		return nil
+	}
+
+	cp := &pswap.Checkpoint{
+		XMRLockTxHash:       s.info.XMRLockTxHash(),
+		FromBlock:           s.fromBlock,
+		ContractAddress:     s.contractAddr,
+		ContractSwapID:      s.contractSwapID,
+		Timeout0:            s.t0,
+		Timeout1:            s.t1,
+		NextExpectedMessage: s.nextExpectedMessage.Type(),
+	}
+
+	if s.bob.secretsPassphrase == "" {
+		cp.PrivateSpendKey = s.privkeys.SpendKey().Hex()
+	} else {
+		// deriving the encryption key is slow, so the spend key is only encrypted once
+		if s.encryptedSpendKey == nil {
+			encrypted, err := crypto.EncryptSecret(s.privkeys.SpendKeyBytes(), s.bob.secretsPassphrase)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt private spend key: %w", err)
+			}
+
+			s.encryptedSpendKey = encrypted
+		}
+
+		cp.EncryptedPrivateSpendKey = s.encryptedSpendKey
+	}
+
+	if s.alicePublicKeys != nil {
+		cp.CounterpartyPublicSpendKey = s.alicePublicKeys.SpendKey().Hex()
+		cp.CounterpartyViewKey = s.alicePublicKeys.ViewKey().Hex()
+	}
+
+	if s.aliceSecp256K1PublicKey != nil {
+		cp.CounterpartySecp256k1Key = s.aliceSecp256K1PublicKey.String()
+	}
+
+	return s.bob.swapManager.WriteCheckpoint(s.ID(), cp)
+}
+
+// ResumeSwaps finds the swaps where we provided XMR that were interrupted by swapd exiting,
+// and drives each of them to completion in the background by either claiming the ETH or
+// reclaiming the XMR. The counterparty does not need to be online.
+func (b *Instance) ResumeSwaps() error {
+	for _, info := range b.swapManager.GetInterruptedSwaps() {
+		if info.Provides() != common.ProvidesXMR {
+			continue
+		}
+
+		cp, err := b.swapManager.GetCheckpoint(info.ID())
+		if err != nil {
+			return err
+		}
+
+		// we exited before any funds were locked
+		if cp == nil || cp.NextExpectedMessage != net.NotifyReadyType {
+			log.Infof("swap was interrupted before any funds were locked, aborting: id=%d", info.ID())
+			info.SetStatus(pswap.Aborted)
+			continue
+		}
+
+		s, err := newSwapStateFromCheckpoint(b, info, cp)
+		if err != nil {
+			log.Errorf("failed to resume swap: id=%d err=%s", info.ID(), err)
+			continue
+		}
+
+		log.Info(color.New(color.Bold).Sprintf("**resuming swap with ID=%d**", info.ID()))
+		b.swapManager.ResumeSwap(info.ID())
+		go s.resume()
+	}
+
+	return nil
+}
+
+func newSwapStateFromCheckpoint(b *Instance, info *pswap.Info, cp *pswap.Checkpoint) (*swapState, error) {
+	txOpts := b.signer.TransactOpts(b.chainID)
+	txOpts.GasPrice = b.gasPrice
+	txOpts.GasLimit = b.gasLimit
+
+	sb, err := cp.SpendKeyBytes(b.secretsPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get private spend key: %w", err)
+	}
+
+	sk, err := mcrypto.NewPrivateSpendKey(sb)
+	if err != nil {
+		return nil, err
+	}
+
+	kp, err := sk.AsPrivateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	var sc [32]byte
+	copy(sc[:], sk.Bytes())
+
+	ctx, cancel := context.WithCancel(b.ctx)
+	s := &swapState{
+		ctx:                 ctx,
+		cancel:              cancel,
+		bob:                 b,
+		info:                info,
+		offerID:             info.OfferID(),
+		txOpts:              txOpts,
+		privkeys:            kp,
+		pubkeys:             kp.PublicKeyPair(),
+		encryptedSpendKey:   cp.EncryptedPrivateSpendKey,
+		dleqProof:           dleq.NewProofWithSecret(sc),
+		fromBlock:           cp.FromBlock,
+		t0:                  cp.Timeout0,
+		t1:                  cp.Timeout1,
+		nextExpectedMessage: &net.NotifyReady{},
+		readyCh:             make(chan struct{}),
+	}
+
+	if cp.CounterpartyPublicSpendKey != "" && cp.CounterpartyViewKey != "" {
+		kpA, err := mcrypto.NewPublicKeyPairFromHex(cp.CounterpartyPublicSpendKey, cp.CounterpartyViewKey)
+		if err != nil {
+			return nil, err
+		}
+
+		var aliceSecp256k1Key *secp256k1.PublicKey
+		if cp.CounterpartySecp256k1Key != "" {
+			aliceSecp256k1Key, err = secp256k1.NewPublicKeyFromHex(cp.CounterpartySecp256k1Key)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		s.setAlicePublicKeys(kpA, aliceSecp256k1Key)
+	}
+
+	if cp.XMRLockTxHash != "" {
+		log.Debugf("resuming swap with XMR locked: id=%d txHash=%s", info.ID(), cp.XMRLockTxHash)
+	}
+
+	if err = s.setContract(cp.ContractAddress, cp.ContractSwapID); err != nil {
+		return nil, fmt.Errorf("failed to instantiate contract instance: %w", err)
+	}
+
+	return s, nil
+}
+
+// resume drives a swap that was reloaded from a checkpoint to completion.
+func (s *swapState) resume() {
+	defer s.cancel()
+	defer s.bob.swapManager.CompleteOngoingSwap(s.ID())
+
+	res, err := (&recoveryState{ss: s}).ClaimOrRecover()
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	if err != nil {
+		log.Errorf("failed to claim ether for resumed swap: id=%d err=%s", s.ID(), err)
+
+		// eg. if we're past t1, Alice can still refund, revealing her secret, so we keep
+		// watching for it
+		log.Infof("waiting for resumed swap to be refunded: id=%d", s.ID())
+		if err = s.waitForRefund(); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Errorf("failed to complete resumed swap: id=%d err=%s", s.ID(), err)
+			}
+			return
+		}
+
+		s.settleOffer()
+		log.Infof("resumed swap completed: id=%d status=%s", s.ID(), s.info.Status())
+		return
+	}
+
+	if res.Claimed {
+		s.info.SetStatus(pswap.Success)
+		s.settleOffer()
+		log.Infof("claimed ether for resumed swap: id=%d tx hash=%s", s.ID(), res.TxHash)
+		return
+	}
+
+	s.info.SetStage(pswap.StageRefunded, "")
+	s.info.SetStatus(pswap.Refunded)
+	s.settleOffer()
+	log.Infof("reclaimed monero for resumed swap: id=%d address=%s", s.ID(), res.MoneroAddress)
+}
+
+// waitForRefund watches the contract until Alice refunds the swap, and then reclaims our monero
+// using the secret she revealed. If our claim was included after all, the swap succeeded instead.
+func (s *swapState) waitForRefund() error {
+	w, err := swap.NewWatcher(&swap.WatcherConfig{
+		Ctx:       s.ctx,
+		Client:    s.bob.ethClient,
+		Address:   s.contractAddr,
+		SwapID:    s.contractSwapID,
+		FromBlock: s.fromBlock,
+	})
+	if err != nil {
+		return err
+	}
+
+	for ev := range w.Start() {
+		switch ev.Type {
+		case swap.EventClaimed:
+			s.info.SetStage(pswap.StageClaimed, ev.Log.TxHash.String())
+			s.info.SetStatus(pswap.Success)
+			return nil
+		case swap.EventRefunded:
+			return s.handleRefundSecret(ev.Secret, ev.Log.TxHash.String())
+		}
+	}
+
+	return s.ctx.Err()
+}
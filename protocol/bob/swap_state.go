@@ -3,19 +3,17 @@ package bob
 import (
 	"bytes"
 	"context"
-	"crypto/ecdsa"
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"strings"
+	"math/big"
 	"sync"
 	"time"
 
 	eth "github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
-	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/fatih/color" //nolint:misspell
 
 	"github.com/noot/atomic-swap/common"
@@ -33,14 +31,11 @@ import (
 var (
 	errMissingKeys       = errors.New("did not receive Alice's public spend or view key")
 	errMissingAddress    = errors.New("got empty contract address")
+	errMissingSwapID     = errors.New("got empty contract swap ID")
 	errNoRefundLogsFound = errors.New("no refund logs found")
 	errPastClaimTime     = errors.New("past t1, can no longer claim")
-)
-
-var (
-	// this is from the autogenerated swap.go
-	// TODO: generate this ourselves instead of hard-coding
-	refundedTopic = ethcommon.HexToHash("0xfe509803c09416b28ff3d8f690c8b0c61462a892c46d5430c8fb20abe472daf0")
+	errInvalidSecret     = errors.New("secret doesn't correspond to Alice's public spend key")
+	errSwapNotReady      = errors.New("swap is not set to ready in contract")
 )
 
 type swapState struct {
@@ -52,17 +47,27 @@ type swapState struct {
 	info    *pswap.Info
 	offerID types.Hash
 
+	// ERC-20 token we receive, or the zero address if we receive ether
+	token ethcommon.Address
+
 	// our keys for this session
 	dleqProof    *dleq.Proof
 	secp256k1Pub *secp256k1.PublicKey
 	privkeys     *mcrypto.PrivateKeyPair
 	pubkeys      *mcrypto.PublicKeyPair
 
-	// swap contract and timeouts in it; set once contract is deployed
-	contract     *swap.Swap
-	contractAddr ethcommon.Address
-	t0, t1       time.Time
-	txOpts       *bind.TransactOpts
+	// our private spend key encrypted with the secrets passphrase, once it's been checkpointed
+	encryptedSpendKey []byte
+
+	// swap contract, the ID of our swap in it, and its timeouts; set once the swap is created
+	contract       *swap.SwapFactory
+	contractAddr   ethcommon.Address
+	contractSwapID ethcommon.Hash
+	t0, t1         time.Time
+	txOpts         *bind.TransactOpts
+
+	// the block the swap's events are watched from, once we've checked the swap
+	fromBlock uint64
 
 	// Alice's keys for this session
 	alicePublicKeys         *mcrypto.PublicKeyPair
@@ -78,18 +83,23 @@ type swapState struct {
 	moneroReclaimAddress mcrypto.Address
 }
 
-func newSwapState(b *Instance, offerID types.Hash, providesAmount common.MoneroAmount, desiredAmount common.EtherAmount) (*swapState, error) { //nolint:lll
-	txOpts, err := bind.NewKeyedTransactorWithChainID(b.ethPrivKey, b.chainID)
-	if err != nil {
-		return nil, err
+func newSwapState(b *Instance, offerID types.Hash, providesAmount common.MoneroAmount, desiredAmount common.Amount) (*swapState, error) { //nolint:lll
+	// we can't complete a swap without signing transactions, so don't start one while our signer is locked
+	if b.signer.Locked() {
+		return nil, pcommon.ErrKeyLocked
 	}
 
+	txOpts := b.signer.TransactOpts(b.chainID)
 	txOpts.GasPrice = b.gasPrice
 	txOpts.GasLimit = b.gasLimit
 
-	exchangeRate := common.ExchangeRate(providesAmount.AsMonero() / desiredAmount.AsEther())
-	info := pswap.NewInfo(common.ProvidesXMR, providesAmount.AsMonero(), desiredAmount.AsEther(),
+	exchangeRate := common.NewExchangeRateFromRat(
+		new(big.Rat).Quo(providesAmount.AsMonero().Rat(), desiredAmount.Rat()),
+	)
+	info := pswap.NewInfo(common.ProvidesXMR, providesAmount.AsMonero(), desiredAmount,
 		exchangeRate, pswap.Ongoing)
+	info.SetOfferID(offerID)
+	info.SetSweepAddress(b.sweepAddress)
 	if err := b.swapManager.AddSwap(info); err != nil {
 		return nil, err
 	}
@@ -126,7 +136,7 @@ func (s *swapState) SendKeysMessage() (*net.SendKeysMessage, error) {
 }
 
 // ReceivedAmount returns the amount received, or expected to be received, at the end of the swap
-func (s *swapState) ReceivedAmount() float64 {
+func (s *swapState) ReceivedAmount() common.Amount {
 	return s.info.ReceivedAmount()
 }
 
@@ -135,6 +145,18 @@ func (s *swapState) ID() uint64 {
 	return s.info.ID()
 }
 
+// settleOffer updates the offer's available amount once the swap has finished. If the swap
+// succeeded, its amount is deducted from the offer; if it was aborted or refunded, its reservation
+// is released. If the swap is still ongoing, eg. because recovery failed, the amount stays reserved.
+func (s *swapState) settleOffer() {
+	switch s.info.Status() {
+	case pswap.Success:
+		s.bob.offerManager.Fill(s.offerID, s.ID())
+	case pswap.Aborted, pswap.Refunded:
+		s.bob.offerManager.Release(s.offerID, s.ID())
+	}
+}
+
 // ProtocolExited is called by the network when the protocol stream closes.
 // If it closes prematurely, we need to perform recovery.
 func (s *swapState) ProtocolExited() error {
@@ -144,16 +166,14 @@ func (s *swapState) ProtocolExited() error {
 	defer func() {
 		// stop all running goroutines
 		s.cancel()
-		s.bob.swapState = nil
-		s.bob.swapManager.CompleteOngoingSwap()
+		s.bob.removeSwapState(s.ID())
+		s.bob.swapManager.CompleteOngoingSwap(s.ID())
+		s.settleOffer()
 	}()
 
 	if s.info.Status() == pswap.Success {
 		str := color.New(color.Bold).Sprintf("**swap completed successfully! id=%d**", s.ID())
 		log.Info(str)
-
-		// remove offer, as it's been taken
-		s.bob.offerManager.deleteOffer(s.offerID)
 		return nil
 	}
 
@@ -180,6 +200,7 @@ func (s *swapState) ProtocolExited() error {
 		if err != nil {
 			log.Errorf("failed to claim funds: err=%s", err)
 		} else {
+			s.info.SetStatus(pswap.Success)
 			log.Infof("claimed ether! transaction hash=%s", txHash)
 			return nil
 		}
@@ -213,6 +234,16 @@ func (s *swapState) tryReclaimMonero() (mcrypto.Address, error) {
 }
 
 func (s *swapState) reclaimMonero(skA *mcrypto.PrivateSpendKey) (mcrypto.Address, error) {
+	if s.alicePublicKeys == nil {
+		return "", errMissingKeys
+	}
+
+	// the secret is from a log or message we didn't create, so make sure it's really Alice's;
+	// otherwise, the wallet wouldn't contain the XMR we locked
+	if skA.Public().Hex() != s.alicePublicKeys.SpendKey().Hex() {
+		return "", errInvalidSecret
+	}
+
 	vkA, err := skA.View()
 	if err != nil {
 		return "", err
@@ -224,18 +255,32 @@ func (s *swapState) reclaimMonero(skA *mcrypto.PrivateSpendKey) (mcrypto.Address
 
 	// write keys to file in case something goes wrong
 	fp := fmt.Sprintf("%s/%d/swap-secret", s.bob.basepath, s.ID())
-	if err = mcrypto.WriteKeysToFile(fp, kpAB, s.bob.env); err != nil {
+	if err = mcrypto.WriteKeysToFile(fp, kpAB, s.bob.env, s.bob.secretsPassphrase); err != nil {
 		return "", err
 	}
 
+	s.bob.walletLock.Lock()
+	defer s.bob.walletLock.Unlock()
+
 	// TODO: check balance
-	return monero.CreateMoneroWallet("bob-swap-wallet", s.bob.env, s.bob.client, kpAB)
+	addr, walletName, err := monero.CreateMoneroWallet("bob-swap-wallet", s.bob.env, s.bob.client, kpAB)
+	if err != nil {
+		return "", err
+	}
+
+	// switch back to our main wallet so that other swaps can continue to use it
+	if err = s.bob.walletLock.RestoreMainWallet(); err != nil {
+		return "", err
+	}
+
+	s.bob.startSweep(s.info, walletName)
+	return addr, nil
 }
 
 func (s *swapState) filterForRefund() (*mcrypto.PrivateSpendKey, error) {
 	logs, err := s.bob.ethClient.FilterLogs(s.ctx, eth.FilterQuery{
 		Addresses: []ethcommon.Address{s.contractAddr},
-		Topics:    [][]ethcommon.Hash{{refundedTopic}},
+		Topics:    [][]ethcommon.Hash{{swap.RefundedTopic}, {s.contractSwapID}},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to filter logs: %w", err)
@@ -254,20 +299,27 @@ func (s *swapState) filterForRefund() (*mcrypto.PrivateSpendKey, error) {
 }
 
 func (s *swapState) tryClaim() (ethcommon.Hash, error) {
-	untilT0 := time.Until(s.t0)
-	untilT1 := time.Until(s.t1)
-	isReady, err := s.contract.IsReady(s.bob.callOpts)
+	info, err := s.contract.Swaps(s.bob.callOpts, s.contractSwapID)
 	if err != nil {
 		return ethcommon.Hash{}, err
 	}
 
-	if untilT0 > 0 && !isReady {
+	isReady := info.Stage == swap.StageReady
+	if isReady && time.Until(s.t0) > 0 {
+		// claiming before t0 only succeeds if the swap stays ready
+		if err = s.waitForReadyConfirmations(ethcommon.Hash{}); err != nil {
+			log.Warnf("failed to confirm that swap is ready: err=%s", err)
+			isReady = false
+		}
+	}
+
+	if untilT0 := time.Until(s.t0); untilT0 > 0 && !isReady {
 		// we need to wait until t0 to claim
 		log.Infof("waiting until time %s to claim, time now=%s", s.t0, time.Now())
 		<-time.After(untilT0 + time.Second)
 	}
 
-	if untilT1 < 0 {
+	if time.Until(s.t1) < 0 {
 		// we've passed t1, our only option now is for Alice to refund
 		// and we can regain control of the locked XMR.
 		return ethcommon.Hash{}, errPastClaimTime
@@ -284,7 +336,7 @@ func (s *swapState) generateAndSetKeys() error {
 		return nil
 	}
 
-	keysAndProof, err := generateKeys()
+	keysAndProof, err := generateKeys(s.bob.dleq)
 	if err != nil {
 		return err
 	}
@@ -295,15 +347,15 @@ func (s *swapState) generateAndSetKeys() error {
 	s.pubkeys = keysAndProof.PublicKeyPair
 
 	fp := fmt.Sprintf("%s/%d/bob-secret", s.bob.basepath, s.ID())
-	if err := mcrypto.WriteKeysToFile(fp, s.privkeys, s.bob.env); err != nil {
+	if err := mcrypto.WriteKeysToFile(fp, s.privkeys, s.bob.env, s.bob.secretsPassphrase); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func generateKeys() (*pcommon.KeysAndProof, error) {
-	return pcommon.GenerateKeysAndProof()
+func generateKeys(d dleq.Interface) (*pcommon.KeysAndProof, error) {
+	return pcommon.GenerateKeysAndProof(d)
 }
 
 // getSecret secrets returns the current secret scalar used to unlock funds from the contract.
@@ -320,101 +372,170 @@ func (s *swapState) setAlicePublicKeys(sk *mcrypto.PublicKeyPair, secp256k1Pub *
 	s.aliceSecp256K1PublicKey = secp256k1Pub
 }
 
-// setContract sets the contract in which Alice has locked her ETH.
-func (s *swapState) setContract(address ethcommon.Address) error {
+// setContract sets the contract, and the ID of the swap in it, in which Alice has locked her ETH.
+func (s *swapState) setContract(address ethcommon.Address, id ethcommon.Hash) error {
 	var err error
 	s.contractAddr = address
-	s.contract, err = swap.NewSwap(address, s.bob.ethClient)
+	s.contractSwapID = id
+	s.contract, err = swap.NewSwapFactory(address, s.bob.ethClient)
 	return err
 }
 
 func (s *swapState) setTimeouts() error {
-	st0, err := s.contract.Timeout0(s.bob.callOpts)
+	info, err := s.contract.Swaps(s.bob.callOpts, s.contractSwapID)
 	if err != nil {
-		return fmt.Errorf("failed to get timeout0 from contract: err=%w", err)
+		return fmt.Errorf("failed to get timeouts from contract: err=%w", err)
 	}
 
-	s.t0 = time.Unix(st0.Int64(), 0)
+	s.t0 = time.Unix(info.Timeout0.Int64(), 0)
+	s.t1 = time.Unix(info.Timeout1.Int64(), 0)
+	s.info.SetTimeouts(s.t0, s.t1)
+	return nil
+}
 
-	st1, err := s.contract.Timeout1(s.bob.callOpts)
+// waitForSwapConfirmations waits for the transaction in which Alice created our swap to have the
+// number of confirmations we require, so that we only lock our XMR once the swap is unlikely to be
+// re-organized out of the chain.
+func (s *swapState) waitForSwapConfirmations() error {
+	logs, err := s.bob.ethClient.FilterLogs(s.ctx, eth.FilterQuery{
+		Addresses: []ethcommon.Address{s.contractAddr},
+		Topics:    [][]ethcommon.Hash{{swap.NewTopic}, {s.contractSwapID}},
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get timeout1 from contract: err=%w", err)
+		return fmt.Errorf("failed to filter logs: %w", err)
+	}
+
+	if len(logs) == 0 {
+		return errors.New("cannot find swap creation in contract")
+	}
+
+	txHash := logs[0].TxHash
+	log.Debugf("waiting for swap creation to be confirmed: txHash=%s", txHash)
+	if _, err = common.WaitForConfirmations(s.ctx, s.bob.ethClient, txHash, s.bob.ethConfirmations); err != nil {
+		return fmt.Errorf("failed to wait for swap creation to be confirmed: %w", err)
 	}
 
-	s.t1 = time.Unix(st1.Int64(), 0)
 	return nil
 }
 
-// checkContract checks the contract's balance and Claim/Refund keys.
-// if the balance doesn't match what we're expecting to receive, or the public keys in the contract
-// aren't what we expect, we error and abort the swap.
-func (s *swapState) checkContract() error {
-	balance, err := s.bob.ethClient.BalanceAt(s.ctx, s.contractAddr, nil)
-	if err != nil {
-		return err
+// waitForReadyConfirmations waits for the transaction which set the swap to ready to be confirmed,
+// looking it up from the contract's Ready event if the given hash is empty, and then checks that
+// the swap is still ready in the contract.
+func (s *swapState) waitForReadyConfirmations(txHash ethcommon.Hash) error {
+	if txHash == (ethcommon.Hash{}) {
+		logs, err := s.bob.ethClient.FilterLogs(s.ctx, eth.FilterQuery{
+			Addresses: []ethcommon.Address{s.contractAddr},
+			Topics:    [][]ethcommon.Hash{{swap.ReadyTopic}, {s.contractSwapID}},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to filter logs: %w", err)
+		}
+
+		if len(logs) == 0 {
+			return errSwapNotReady
+		}
+
+		txHash = logs[0].TxHash
 	}
 
-	expected := common.EtherToWei(s.info.ReceivedAmount()).BigInt()
-	if balance.Cmp(expected) < 0 {
-		return fmt.Errorf("contract does not have expected balance: got %s, expected %s", balance, expected)
+	log.Debugf("waiting for swap to be set to ready to be confirmed: txHash=%s", txHash)
+	if _, err := common.WaitForConfirmations(s.ctx, s.bob.ethClient, txHash, s.bob.ethConfirmations); err != nil {
+		return fmt.Errorf("failed to wait for swap to be set to ready to be confirmed: %w", err)
 	}
 
-	constructedTopic := ethcommon.HexToHash("0x8d36aa70807342c3036697a846281194626fd4afa892356ad5979e03831ab080")
-	logs, err := s.bob.ethClient.FilterLogs(s.ctx, eth.FilterQuery{
-		Addresses: []ethcommon.Address{s.contractAddr},
-		Topics:    [][]ethcommon.Hash{{constructedTopic}},
-	})
+	// the transaction may have been reorged out before it was confirmed
+	info, err := s.contract.Swaps(s.bob.callOpts, s.contractSwapID)
 	if err != nil {
-		return fmt.Errorf("failed to filter logs: %w", err)
+		return fmt.Errorf("failed to get swap from contract: %w", err)
 	}
 
-	if len(logs) == 0 {
-		return errors.New("cannot find Constructed log")
+	if info.Stage != swap.StageReady {
+		return errSwapNotReady
 	}
 
-	abi, err := abi.JSON(strings.NewReader(swap.SwapABI))
-	if err != nil {
-		return err
-	}
+	return nil
+}
 
-	data := logs[0].Data
-	res, err := abi.Unpack("Constructed", data)
+// checkContract checks the swap Alice created in the contract: that we're its claimer, that its
+// Claim/Refund keys are the ones we expect, and that it locks the amount we expect to receive.
+// If any of these aren't what we expect, we error and abort the swap.
+func (s *swapState) checkContract() error {
+	info, err := s.contract.Swaps(s.bob.callOpts, s.contractSwapID)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to get swap from contract: %w", err)
 	}
 
-	if len(res) < 2 {
-		return errors.New("constructed event was missing parameters")
+	if info.Stage != swap.StagePending {
+		return fmt.Errorf("swap is not pending in contract: stage=%d", info.Stage)
 	}
 
-	pkClaim := res[0].([32]byte)
-	pkRefund := res[0].([32]byte)
+	if info.Claimer != s.bob.ethAddress {
+		return fmt.Errorf("swap claimer is not expected: got %s, expected %s", info.Claimer, s.bob.ethAddress)
+	}
 
-	// check that contract was constructed with correct secp256k1 keys
+	// check that the swap was created with the correct secp256k1 keys
 	skOurs := s.secp256k1Pub.Keccak256()
-	if !bytes.Equal(pkClaim[:], skOurs[:]) {
-		return fmt.Errorf("contract claim key is not expected: got 0x%x, expected 0x%x", pkClaim, skOurs)
+	if !bytes.Equal(info.PubKeyClaim[:], skOurs[:]) {
+		return fmt.Errorf("contract claim key is not expected: got 0x%x, expected 0x%x", info.PubKeyClaim, skOurs)
 	}
 
 	skTheirs := s.aliceSecp256K1PublicKey.Keccak256()
-	if !bytes.Equal(pkRefund[:], skOurs[:]) {
-		return fmt.Errorf("contract claim key is not expected: got 0x%x, expected 0x%x", pkRefund, skTheirs)
+	if !bytes.Equal(info.PubKeyRefund[:], skTheirs[:]) {
+		return fmt.Errorf("contract refund key is not expected: got 0x%x, expected 0x%x", info.PubKeyRefund, skTheirs)
+	}
+
+	// the swap must lock the asset we expect, and not some other token
+	if info.Asset != s.token {
+		return fmt.Errorf("swap does not lock expected asset: got %s, expected %s", info.Asset, s.token)
+	}
+
+	expected, err := s.expectedValue()
+	if err != nil {
+		return err
+	}
+
+	if info.Value.Cmp(expected) < 0 {
+		return fmt.Errorf("swap does not lock expected amount: got %s, expected %s", info.Value, expected)
 	}
 
 	return nil
 }
 
+// expectedValue returns the amount we expect to receive in the smallest units of the asset being
+// swapped for, which is either wei, or the base units of the ERC-20 token of the offer.
+func (s *swapState) expectedValue() (*big.Int, error) {
+	if s.token == (ethcommon.Address{}) {
+		return common.EtherToWei(s.info.ReceivedAmount()).BigInt(), nil
+	}
+
+	erc20, err := swap.NewIERC20Caller(s.token, s.bob.ethClient)
+	if err != nil {
+		return nil, err
+	}
+
+	decimals, err := erc20.Decimals(s.bob.callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decimals of token %s: %w", s.token, err)
+	}
+
+	return common.TokenToUnits(s.info.ReceivedAmount(), decimals), nil
+}
+
 // lockFunds locks Bob's funds in the monero account specified by public key
 // (S_a + S_b), viewable with (V_a + V_b)
-// It accepts the amount to lock as the input
+// It accepts the amount to lock as the input, and returns the message notifying Alice of the lock,
+// which includes the lock transaction's secret key so she can verify it.
 // TODO: units
-func (s *swapState) lockFunds(amount common.MoneroAmount) (mcrypto.Address, error) {
+func (s *swapState) lockFunds(amount common.MoneroAmount) (*net.NotifyXMRLock, error) {
 	kp := mcrypto.SumSpendAndViewKeys(s.alicePublicKeys, s.pubkeys)
 	log.Infof("going to lock XMR funds, amount(piconero)=%d", amount)
 
+	s.bob.walletLock.Lock()
+	defer s.bob.walletLock.Unlock()
+
 	balance, err := s.bob.client.GetBalance(0)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	log.Debug("total XMR balance: ", balance.Balance)
@@ -423,14 +544,23 @@ func (s *swapState) lockFunds(amount common.MoneroAmount) (mcrypto.Address, erro
 	address := kp.Address(s.bob.env)
 	txResp, err := s.bob.client.Transfer(address, 0, uint(amount))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	log.Infof("locked XMR, txHash=%s fee=%d", txResp.TxHash, txResp.Fee)
+	s.info.SetXMRLock(address, txResp.TxHash)
+	s.info.SetStage(pswap.StageXMRLocked, txResp.TxHash)
+
+	// our XMR is now locked, so the swap must be resumable from here on, even if we exit before
+	// the lock is confirmed
+	s.nextExpectedMessage = &net.NotifyReady{}
+	if err = s.checkpoint(); err != nil {
+		log.Errorf("failed to checkpoint swap: err=%s", err)
+	}
 
 	bobAddr, err := s.bob.client.GetAddress(0)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// if we're on a development --regtest node, generate some blocks
@@ -439,23 +569,25 @@ func (s *swapState) lockFunds(amount common.MoneroAmount) (mcrypto.Address, erro
 	} else {
 		// otherwise, wait for new blocks
 		if err := monero.WaitForBlocks(s.bob.client); err != nil {
-			return "", err
+			return nil, err
 		}
 	}
 
 	if err := s.bob.client.Refresh(); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	log.Infof("successfully locked XMR funds: address=%s", address)
-	return address, nil
+	return &net.NotifyXMRLock{
+		Address: string(address),
+		TxHash:  txResp.TxHash,
+		TxKey:   txResp.TxKey,
+	}, nil
 }
 
 // claimFunds redeems Bob's ETH funds by calling Claim() on the contract
 func (s *swapState) claimFunds() (ethcommon.Hash, error) {
-	pub := s.bob.ethPrivKey.Public().(*ecdsa.PublicKey)
-	addr := ethcrypto.PubkeyToAddress(*pub)
-
+	addr := s.bob.ethAddress
 	balance, err := s.bob.ethClient.BalanceAt(s.ctx, addr, nil)
 	if err != nil {
 		return ethcommon.Hash{}, err
@@ -463,18 +595,35 @@ func (s *swapState) claimFunds() (ethcommon.Hash, error) {
 
 	log.Info("Bob's balance before claim: ", balance)
 
-	// call swap.Swap.Claim() w/ b.privkeys.sk, revealing Bob's secret spend key
+	// call swap.SwapFactory.Claim() w/ b.privkeys.sk, revealing Bob's secret spend key
 	sc := s.getSecret()
-	tx, err := s.contract.Claim(s.txOpts, sc)
+
+	// a swap resumed when swapd starts may need to claim before our key is unlocked, which must
+	// happen before t1 for the claim to succeed.
+	ctx, cancel := context.WithDeadline(s.ctx, s.t1)
+	defer cancel()
+	if err = pcommon.WaitForUnlock(ctx, s.bob.signer); err != nil {
+		return ethcommon.Hash{}, fmt.Errorf("ethereum key wasn't unlocked before t1: %w", err)
+	}
+
+	// we must claim before t1, after which the claim would fail and Alice can refund; if our
+	// transaction is stuck, it's replaced with higher fees until then.
+	urgency := pcommon.DeadlineUrgency(pcommon.UrgencyNormal, s.t1)
+	receipt, err := s.bob.txManager.Send(s.ctx, s.txOpts, urgency, s.t1,
+		func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+			tx, err := s.contract.Claim(opts, s.contractSwapID, sc) //nolint:govet
+			if err != nil {
+				return nil, err
+			}
+
+			log.Infof("sent Claim tx, tx hash=%s", tx.Hash())
+			return tx, nil
+		})
 	if err != nil {
-		return ethcommon.Hash{}, err
+		return ethcommon.Hash{}, fmt.Errorf("failed to call Claim in SwapFactory.sol: %w", err)
 	}
 
-	log.Infof("sent Claim tx, tx hash=%s", tx.Hash())
-
-	if _, ok := common.WaitForReceipt(s.ctx, s.bob.ethClient, tx.Hash()); !ok {
-		return ethcommon.Hash{}, errors.New("failed to check Claim transaction receipt")
-	}
+	s.info.SetStage(pswap.StageClaimed, receipt.TxHash.String())
 
 	balance, err = s.bob.ethClient.BalanceAt(s.ctx, addr, nil)
 	if err != nil {
@@ -482,5 +631,5 @@ func (s *swapState) claimFunds() (ethcommon.Hash, error) {
 	}
 
 	log.Info("Bob's balance after claim: ", balance)
-	return tx.Hash(), nil
+	return receipt.TxHash, nil
 }
@@ -9,6 +9,8 @@ import (
 
 	"github.com/noot/atomic-swap/common"
 	"github.com/noot/atomic-swap/common/types"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+	"github.com/noot/atomic-swap/dleq"
 	"github.com/noot/atomic-swap/net"
 	pcommon "github.com/noot/atomic-swap/protocol"
 	pswap "github.com/noot/atomic-swap/protocol/swap"
@@ -16,6 +18,7 @@ import (
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	logging "github.com/ipfs/go-log"
 	"github.com/stretchr/testify/require"
 )
@@ -23,20 +26,26 @@ import (
 var (
 	_            = logging.SetLogLevel("bob", "debug")
 	testWallet   = "test-wallet"
-	desiredAmout = common.EtherToWei(0.33)
+	desiredAmout = common.EtherToWei(common.MustNewAmount("0.33"))
 )
 
 type mockNet struct {
 	msg net.Message
 }
 
-func (n *mockNet) SendSwapMessage(msg net.Message) error {
+func (n *mockNet) SendSwapMessage(_ uint64, msg net.Message) error {
 	n.msg = msg
 	return nil
 }
 
 var defaultTimeoutDuration = big.NewInt(60 * 60 * 24) // 1 day = 60s * 60min * 24hr
 
+func newTestSwapManager(t *testing.T) *pswap.Manager {
+	sm, err := pswap.NewManager(memorydb.New())
+	require.NoError(t, err)
+	return sm
+}
+
 func newTestInstance(t *testing.T) (*Instance, *swapState) {
 	cfg := &Config{
 		Ctx:                  context.Background(),
@@ -49,7 +58,8 @@ func newTestInstance(t *testing.T) (*Instance, *swapState) {
 		EthereumPrivateKey:   common.DefaultPrivKeyBob,
 		Environment:          common.Development,
 		ChainID:              common.MainnetConfig.EthereumChainID,
-		SwapManager:          pswap.NewManager(),
+		SwapManager:          newTestSwapManager(t),
+		DLEq:                 &dleq.FakeDLEq{},
 	}
 
 	bob, err := NewInstance(cfg)
@@ -60,13 +70,13 @@ func newTestInstance(t *testing.T) (*Instance, *swapState) {
 
 	_ = bob.daemonClient.GenerateBlocks(bobAddr.Address, 121)
 
-	swapState, err := newSwapState(bob, types.Hash{}, common.MoneroAmount(33), desiredAmout)
+	swapState, err := newSwapState(bob, types.Hash{}, common.MoneroAmount(33), desiredAmout.AsEther())
 	require.NoError(t, err)
 	return bob, swapState
 }
 
 func newTestAliceSendKeySMessage(t *testing.T) (*net.SendKeysMessage, *pcommon.KeysAndProof) {
-	keysAndProof, err := pcommon.GenerateKeysAndProof()
+	keysAndProof, err := pcommon.GenerateKeysAndProof(&dleq.FakeDLEq{})
 	require.NoError(t, err)
 
 	msg := &net.SendKeysMessage{
@@ -94,15 +104,10 @@ func TestSwapState_ClaimFunds(t *testing.T) {
 	err := swapState.generateAndSetKeys()
 	require.NoError(t, err)
 
-	conn, err := ethclient.Dial(common.DefaultEthEndpoint)
-	require.NoError(t, err)
-
-	claimKey := swapState.secp256k1Pub.Keccak256()
-	swapState.contractAddr, _, swapState.contract, err = swap.DeploySwap(swapState.txOpts, conn,
-		claimKey, [32]byte{}, bob.ethAddress, defaultTimeoutDuration)
-	require.NoError(t, err)
+	swapState.contractAddr, swapState.contractSwapID, swapState.contract = deploySwap(t, bob, swapState,
+		[32]byte{}, big.NewInt(1), time.Duration(defaultTimeoutDuration.Int64())*time.Second)
 
-	_, err = swapState.contract.SetReady(swapState.txOpts)
+	_, err = swapState.contract.SetReady(swapState.txOpts, swapState.contractSwapID)
 	require.NoError(t, err)
 
 	txHash, err := swapState.claimFunds()
@@ -128,10 +133,13 @@ func TestSwapState_handleSendKeysMessage(t *testing.T) {
 }
 
 func deploySwap(t *testing.T, bob *Instance, swapState *swapState, refundKey [32]byte, amount *big.Int,
-	timeout time.Duration) (ethcommon.Address, *swap.Swap) {
+	timeout time.Duration) (ethcommon.Address, ethcommon.Hash, *swap.SwapFactory) {
 	conn, err := ethclient.Dial(common.DefaultEthEndpoint)
 	require.NoError(t, err)
 
+	addr, _, contract, err := swap.DeploySwapFactory(swapState.txOpts, conn)
+	require.NoError(t, err)
+
 	tm := big.NewInt(int64(timeout.Seconds()))
 
 	claimKey := swapState.secp256k1Pub.Keccak256()
@@ -141,9 +149,16 @@ func deploySwap(t *testing.T, bob *Instance, swapState *swapState, refundKey [32
 		swapState.txOpts.Value = nil
 	}()
 
-	addr, _, contract, err := swap.DeploySwap(swapState.txOpts, conn, claimKey, refundKey, bob.ethAddress, tm)
+	tx, err := contract.NewSwap(swapState.txOpts, claimKey, refundKey, bob.ethAddress, tm,
+		ethcommon.Address{}, amount)
+	require.NoError(t, err)
+
+	receipt, err := conn.TransactionReceipt(context.Background(), tx.Hash())
 	require.NoError(t, err)
-	return addr, contract
+
+	id, err := swap.GetIDFromLogs(addr, receipt.Logs)
+	require.NoError(t, err)
+	return addr, id, contract
 }
 
 func TestSwapState_HandleProtocolMessage_NotifyContractDeployed_ok(t *testing.T) {
@@ -153,7 +168,7 @@ func TestSwapState_HandleProtocolMessage_NotifyContractDeployed_ok(t *testing.T)
 	err := s.generateAndSetKeys()
 	require.NoError(t, err)
 
-	aliceKeysAndProof, err := generateKeys()
+	aliceKeysAndProof, err := generateKeys(&dleq.FakeDLEq{})
 	require.NoError(t, err)
 	s.setAlicePublicKeys(aliceKeysAndProof.PublicKeyPair, aliceKeysAndProof.Secp256k1PublicKey)
 
@@ -165,11 +180,17 @@ func TestSwapState_HandleProtocolMessage_NotifyContractDeployed_ok(t *testing.T)
 
 	duration, err := time.ParseDuration("2s")
 	require.NoError(t, err)
-	addr, _ := deploySwap(t, bob, s, [32]byte{}, desiredAmout.BigInt(), duration)
+	addr, id, _ := deploySwap(t, bob, s, [32]byte{}, desiredAmout.BigInt(), duration)
 
 	msg = &net.NotifyContractDeployed{
 		Address: addr.String(),
 	}
+	resp, done, err = s.HandleProtocolMessage(msg)
+	require.Equal(t, errMissingSwapID, err)
+	require.Nil(t, resp)
+	require.True(t, done)
+
+	msg.SwapID = id.String()
 
 	resp, done, err = s.HandleProtocolMessage(msg)
 	require.NoError(t, err)
@@ -178,6 +199,7 @@ func TestSwapState_HandleProtocolMessage_NotifyContractDeployed_ok(t *testing.T)
 	require.False(t, done)
 	require.NotNil(t, s.contract)
 	require.Equal(t, addr, s.contractAddr)
+	require.Equal(t, id, s.contractSwapID)
 	require.Equal(t, duration, s.t1.Sub(s.t0))
 	require.Equal(t, &net.NotifyReady{}, s.nextExpectedMessage)
 }
@@ -190,7 +212,7 @@ func TestSwapState_HandleProtocolMessage_NotifyContractDeployed_timeout(t *testi
 	err := s.generateAndSetKeys()
 	require.NoError(t, err)
 
-	aliceKeysAndProof, err := generateKeys()
+	aliceKeysAndProof, err := generateKeys(&dleq.FakeDLEq{})
 	require.NoError(t, err)
 	s.setAlicePublicKeys(aliceKeysAndProof.PublicKeyPair, aliceKeysAndProof.Secp256k1PublicKey)
 
@@ -202,11 +224,17 @@ func TestSwapState_HandleProtocolMessage_NotifyContractDeployed_timeout(t *testi
 
 	duration, err := time.ParseDuration("15s")
 	require.NoError(t, err)
-	addr, _ := deploySwap(t, bob, s, [32]byte{}, desiredAmout.BigInt(), duration)
+	addr, id, _ := deploySwap(t, bob, s, [32]byte{}, desiredAmout.BigInt(), duration)
 
 	msg = &net.NotifyContractDeployed{
 		Address: addr.String(),
 	}
+	resp, done, err = s.HandleProtocolMessage(msg)
+	require.Equal(t, errMissingSwapID, err)
+	require.Nil(t, resp)
+	require.True(t, done)
+
+	msg.SwapID = id.String()
 
 	resp, done, err = s.HandleProtocolMessage(msg)
 	require.NoError(t, err)
@@ -215,6 +243,7 @@ func TestSwapState_HandleProtocolMessage_NotifyContractDeployed_timeout(t *testi
 	require.False(t, done)
 	require.NotNil(t, s.contract)
 	require.Equal(t, addr, s.contractAddr)
+	require.Equal(t, id, s.contractSwapID)
 	require.Equal(t, duration, s.t1.Sub(s.t0))
 	require.Equal(t, &net.NotifyReady{}, s.nextExpectedMessage)
 
@@ -232,9 +261,10 @@ func TestSwapState_HandleProtocolMessage_NotifyReady(t *testing.T) {
 
 	duration, err := time.ParseDuration("10m")
 	require.NoError(t, err)
-	_, s.contract = deploySwap(t, bob, s, [32]byte{}, desiredAmout.BigInt(), duration)
+	s.contractAddr, s.contractSwapID, s.contract = deploySwap(t, bob, s, [32]byte{}, desiredAmout.BigInt(),
+		duration)
 
-	_, err = s.contract.SetReady(s.txOpts)
+	_, err = s.contract.SetReady(s.txOpts, s.contractSwapID)
 	require.NoError(t, err)
 
 	msg := &net.NotifyReady{}
@@ -252,7 +282,7 @@ func TestSwapState_handleRefund(t *testing.T) {
 	err := s.generateAndSetKeys()
 	require.NoError(t, err)
 
-	aliceKeysAndProof, err := generateKeys()
+	aliceKeysAndProof, err := generateKeys(&dleq.FakeDLEq{})
 	require.NoError(t, err)
 	s.setAlicePublicKeys(aliceKeysAndProof.PublicKeyPair, aliceKeysAndProof.Secp256k1PublicKey)
 
@@ -260,10 +290,10 @@ func TestSwapState_handleRefund(t *testing.T) {
 	require.NoError(t, err)
 
 	refundKey := aliceKeysAndProof.Secp256k1PublicKey.Keccak256()
-	_, s.contract = deploySwap(t, bob, s, refundKey, desiredAmout.BigInt(), duration)
+	_, s.contractSwapID, s.contract = deploySwap(t, bob, s, refundKey, desiredAmout.BigInt(), duration)
 
 	// lock XMR
-	addrAB, err := s.lockFunds(common.MoneroToPiconero(s.info.ProvidedAmount()))
+	lock, err := s.lockFunds(common.MoneroToPiconero(s.info.ProvidedAmount()))
 	require.NoError(t, err)
 
 	// call refund w/ Alice's spend key
@@ -271,12 +301,13 @@ func TestSwapState_handleRefund(t *testing.T) {
 	var sc [32]byte
 	copy(sc[:], common.Reverse(secret))
 
-	tx, err := s.contract.Refund(s.txOpts, sc)
+	tx, err := s.contract.Refund(s.txOpts, s.contractSwapID, sc)
 	require.NoError(t, err)
 
-	addr, err := s.handleRefund(tx.Hash().String())
+	err = s.handleRefund(tx.Hash().String())
 	require.NoError(t, err)
-	require.Equal(t, addrAB, addr)
+	require.Equal(t, lock.Address, string(s.moneroReclaimAddress))
+	require.Equal(t, pswap.Refunded, s.info.Status())
 }
 
 func TestSwapState_HandleProtocolMessage_NotifyRefund(t *testing.T) {
@@ -285,7 +316,7 @@ func TestSwapState_HandleProtocolMessage_NotifyRefund(t *testing.T) {
 	err := s.generateAndSetKeys()
 	require.NoError(t, err)
 
-	aliceKeysAndProof, err := generateKeys()
+	aliceKeysAndProof, err := generateKeys(&dleq.FakeDLEq{})
 	require.NoError(t, err)
 	s.setAlicePublicKeys(aliceKeysAndProof.PublicKeyPair, aliceKeysAndProof.Secp256k1PublicKey)
 
@@ -293,7 +324,7 @@ func TestSwapState_HandleProtocolMessage_NotifyRefund(t *testing.T) {
 	require.NoError(t, err)
 
 	refundKey := aliceKeysAndProof.Secp256k1PublicKey.Keccak256()
-	_, s.contract = deploySwap(t, bob, s, refundKey, desiredAmout.BigInt(), duration)
+	_, s.contractSwapID, s.contract = deploySwap(t, bob, s, refundKey, desiredAmout.BigInt(), duration)
 
 	// lock XMR
 	_, err = s.lockFunds(common.MoneroToPiconero(s.info.ProvidedAmount()))
@@ -304,7 +335,7 @@ func TestSwapState_HandleProtocolMessage_NotifyRefund(t *testing.T) {
 	var sc [32]byte
 	copy(sc[:], common.Reverse(secret[:]))
 
-	tx, err := s.contract.Refund(s.txOpts, sc)
+	tx, err := s.contract.Refund(s.txOpts, s.contractSwapID, sc)
 	require.NoError(t, err)
 
 	msg := &net.NotifyRefund{
@@ -324,7 +355,7 @@ func TestSwapState_ProtocolExited_Reclaim(t *testing.T) {
 	err := s.generateAndSetKeys()
 	require.NoError(t, err)
 
-	aliceKeysAndProof, err := generateKeys()
+	aliceKeysAndProof, err := generateKeys(&dleq.FakeDLEq{})
 	require.NoError(t, err)
 	s.setAlicePublicKeys(aliceKeysAndProof.PublicKeyPair, aliceKeysAndProof.Secp256k1PublicKey)
 
@@ -332,7 +363,7 @@ func TestSwapState_ProtocolExited_Reclaim(t *testing.T) {
 	require.NoError(t, err)
 
 	refundKey := aliceKeysAndProof.Secp256k1PublicKey.Keccak256()
-	s.contractAddr, s.contract = deploySwap(t, bob, s, refundKey, desiredAmout.BigInt(), duration)
+	s.contractAddr, s.contractSwapID, s.contract = deploySwap(t, bob, s, refundKey, desiredAmout.BigInt(), duration)
 
 	// lock XMR
 	_, err = s.lockFunds(common.MoneroToPiconero(s.info.ProvidedAmount()))
@@ -343,14 +374,15 @@ func TestSwapState_ProtocolExited_Reclaim(t *testing.T) {
 	var sc [32]byte
 	copy(sc[:], common.Reverse(secret[:]))
 
-	tx, err := s.contract.Refund(s.txOpts, sc)
+	tx, err := s.contract.Refund(s.txOpts, s.contractSwapID, sc)
 	require.NoError(t, err)
 
 	receipt, err := bob.ethClient.TransactionReceipt(s.ctx, tx.Hash())
 	require.NoError(t, err)
 	require.Equal(t, 1, len(receipt.Logs))
-	require.Equal(t, 1, len(receipt.Logs[0].Topics))
-	require.Equal(t, refundedTopic, receipt.Logs[0].Topics[0])
+	require.Equal(t, 2, len(receipt.Logs[0].Topics))
+	require.Equal(t, swap.RefundedTopic, receipt.Logs[0].Topics[0])
+	require.Equal(t, s.contractSwapID, receipt.Logs[0].Topics[1])
 
 	s.nextExpectedMessage = &net.NotifyReady{}
 	err = s.ProtocolExited()
@@ -358,5 +390,20 @@ func TestSwapState_ProtocolExited_Reclaim(t *testing.T) {
 
 	balance, err := bob.client.GetBalance(0)
 	require.NoError(t, err)
-	require.Equal(t, common.MoneroToPiconero(s.info.ProvidedAmount()).Uint64(), uint64(balance.Balance))
+	require.Equal(t, common.MoneroToPiconero(s.info.ProvidedAmount()).Uint64(), balance.Balance)
+}
+
+func TestSwapState_ReclaimMonero_InvalidSecret(t *testing.T) {
+	aliceKeys, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	otherKeys, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+	ourKeys, err := mcrypto.GenerateKeys()
+	require.NoError(t, err)
+
+	s := &swapState{bob: &Instance{}, privkeys: ourKeys}
+	s.setAlicePublicKeys(aliceKeys.PublicKeyPair(), nil)
+
+	_, err = s.reclaimMonero(otherKeys.SpendKey())
+	require.ErrorIs(t, err, errInvalidSecret)
 }
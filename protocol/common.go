@@ -1,14 +1,22 @@
 package protocol
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
+	"time"
 
 	mcrypto "github.com/noot/atomic-swap/crypto/monero"
 	"github.com/noot/atomic-swap/crypto/secp256k1"
 	"github.com/noot/atomic-swap/dleq"
+	"github.com/noot/atomic-swap/net"
+	"github.com/noot/atomic-swap/protocol/swap"
 )
 
+// DefaultConfirmTimeout is how long a swap waits for the user to confirm it, when manual
+// confirmation is required.
+const DefaultConfirmTimeout = time.Minute * 5
+
 // KeysAndProof contains a DLEq proof, a secp256k1 public key,
 // and ed25519 public and private keypairs.
 type KeysAndProof struct {
@@ -19,9 +27,8 @@ type KeysAndProof struct {
 }
 
 // GenerateKeysAndProof generates keys on the secp256k1 and ed25519 curves as well as
-// a DLEq proof between the two.
-func GenerateKeysAndProof() (*KeysAndProof, error) {
-	d := &dleq.FarcasterDLEq{}
+// a DLEq proof between the two, using the given DLEq implementation.
+func GenerateKeysAndProof(d dleq.Interface) (*KeysAndProof, error) {
 	proof, err := d.Prove()
 	if err != nil {
 		return nil, err
@@ -53,13 +60,12 @@ func GenerateKeysAndProof() (*KeysAndProof, error) {
 
 // VerifyKeysAndProof verifies the given DLEq proof and asserts that the resulting secp256k1 key corresponds
 // to the given key.
-func VerifyKeysAndProof(proofStr, secp256k1PubString string) (*secp256k1.PublicKey, error) {
+func VerifyKeysAndProof(d dleq.Interface, proofStr, secp256k1PubString string) (*secp256k1.PublicKey, error) {
 	pb, err := hex.DecodeString(proofStr)
 	if err != nil {
 		return nil, err
 	}
 
-	d := &dleq.FarcasterDLEq{}
 	proof := dleq.NewProofWithoutSecret(pb)
 	res, err := d.Verify(proof)
 	if err != nil {
@@ -77,3 +83,19 @@ func VerifyKeysAndProof(proofStr, secp256k1PubString string) (*secp256k1.PublicK
 
 	return secp256k1Pub, nil
 }
+
+// AwaitConfirmation waits for the user to confirm the swap, if confirmation is required. If the user
+// declines the swap or doesn't confirm it before the timeout, it returns a *net.RejectError, so that
+// the counterparty is told the swap was declined.
+func AwaitConfirmation(ctx context.Context, info *swap.Info, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = DefaultConfirmTimeout
+	}
+
+	err := info.AwaitConfirmation(ctx, timeout)
+	if errors.Is(err, swap.ErrSwapDeclined) || errors.Is(err, swap.ErrConfirmationTimeout) {
+		return net.NewRejectError(net.RejectDeclined, "%s", err)
+	}
+
+	return err
+}
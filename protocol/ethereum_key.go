@@ -0,0 +1,135 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrKeyLocked is returned when signing with an ethereum key which is locked.
+var ErrKeyLocked = errors.New("ethereum key is locked, it must be unlocked with personal_unlock")
+
+// EthereumKey is the private key of the ethereum account our transactions are sent from. A key
+// loaded from an encrypted keystore file is locked until it's unlocked with its passphrase, and
+// transactions can't be signed until then.
+type EthereumKey struct {
+	address ethcommon.Address
+
+	// encrypted keystore JSON of the key; nil if the key wasn't loaded from a keystore
+	keyJSON []byte
+
+	mu sync.RWMutex
+	pk *ecdsa.PrivateKey // nil while the key is locked
+}
+
+// NewEthereumKey returns an unlocked *EthereumKey for the given private key.
+func NewEthereumKey(pk *ecdsa.PrivateKey) *EthereumKey {
+	return &EthereumKey{
+		address: crypto.PubkeyToAddress(pk.PublicKey),
+		pk:      pk,
+	}
+}
+
+// NewKeystoreKey returns a locked *EthereumKey for the given encrypted JSON keystore file contents,
+// as written by go-ethereum's keystore.
+func NewKeystoreKey(keyJSON []byte) (*EthereumKey, error) {
+	address, err := KeystoreAddress(keyJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EthereumKey{
+		address: address,
+		keyJSON: keyJSON,
+	}, nil
+}
+
+// KeystoreAddress returns the address of the account of the given encrypted JSON keystore file
+// contents, without decrypting it.
+func KeystoreAddress(keyJSON []byte) (ethcommon.Address, error) {
+	var key struct {
+		Address string `json:"address"`
+	}
+
+	if err := json.Unmarshal(keyJSON, &key); err != nil {
+		return ethcommon.Address{}, fmt.Errorf("invalid keystore file: %w", err)
+	}
+
+	if !ethcommon.IsHexAddress(key.Address) {
+		return ethcommon.Address{}, errors.New("invalid keystore file: missing address")
+	}
+
+	return ethcommon.HexToAddress(key.Address), nil
+}
+
+// Address returns the address of the key's account.
+func (k *EthereumKey) Address() ethcommon.Address {
+	return k.address
+}
+
+// Locked returns whether the key is locked.
+func (k *EthereumKey) Locked() bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.pk == nil
+}
+
+// Unlock decrypts the key with the given passphrase. Keys which weren't loaded from a keystore are
+// always unlocked.
+func (k *EthereumKey) Unlock(passphrase string) error {
+	if k.keyJSON == nil {
+		return nil
+	}
+
+	key, err := keystore.DecryptKey(k.keyJSON, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt ethereum key: %w", err)
+	}
+
+	if key.Address != k.address {
+		return fmt.Errorf("keystore file is for address %s, but its key is for %s", k.address, key.Address)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.pk = key.PrivateKey
+	return nil
+}
+
+// TransactOpts returns options for sending transactions signed by the key. The key is only needed
+// once a transaction is signed, which fails with ErrKeyLocked if it's locked then.
+func (k *EthereumKey) TransactOpts(chainID *big.Int) *bind.TransactOpts {
+	signer := ethtypes.LatestSignerForChainID(chainID)
+	return &bind.TransactOpts{
+		From: k.address,
+		Signer: func(address ethcommon.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			if address != k.address {
+				return nil, bind.ErrNotAuthorized
+			}
+
+			k.mu.RLock()
+			defer k.mu.RUnlock()
+			if k.pk == nil {
+				return nil, ErrKeyLocked
+			}
+
+			sig, err := crypto.Sign(signer.Hash(tx).Bytes(), k.pk)
+			if err != nil {
+				return nil, err
+			}
+
+			return tx.WithSignature(signer, sig)
+		},
+		Context: context.Background(),
+	}
+}
@@ -0,0 +1,94 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthereumKey_Keystore(t *testing.T) {
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(pk, "passphrase")
+	require.NoError(t, err)
+	keyJSON, err := os.ReadFile(account.URL.Path)
+	require.NoError(t, err)
+
+	key, err := NewKeystoreKey(keyJSON)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(pk.PublicKey), key.Address())
+	require.True(t, key.Locked())
+
+	chainID := big.NewInt(1337)
+	opts := key.TransactOpts(chainID)
+	tx := ethtypes.NewTx(&ethtypes.DynamicFeeTx{ChainID: chainID, Nonce: 1})
+	_, err = opts.Signer(opts.From, tx)
+	require.True(t, errors.Is(err, ErrKeyLocked))
+
+	err = key.Unlock("wrong")
+	require.Error(t, err)
+	require.True(t, key.Locked())
+
+	err = key.Unlock("passphrase")
+	require.NoError(t, err)
+	require.False(t, key.Locked())
+
+	// options created while the key was locked can sign once it's unlocked
+	signed, err := opts.Signer(opts.From, tx)
+	require.NoError(t, err)
+	sender, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, key.Address(), sender)
+}
+
+func TestEthereumKey_PrivateKey(t *testing.T) {
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	key := NewEthereumKey(pk)
+	require.False(t, key.Locked())
+	require.NoError(t, key.Unlock(""))
+
+	_, err = NewKeystoreKey([]byte(`{"version":3}`))
+	require.Error(t, err)
+}
+
+func TestWaitForUnlock(t *testing.T) {
+	unlockPollInterval = time.Millisecond * 10
+
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(pk, "passphrase")
+	require.NoError(t, err)
+	keyJSON, err := os.ReadFile(account.URL.Path)
+	require.NoError(t, err)
+	key, err := NewKeystoreKey(keyJSON)
+	require.NoError(t, err)
+
+	// the context is done before the key is unlocked
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	err = WaitForUnlock(ctx, key)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	go func() {
+		time.Sleep(time.Millisecond * 50)
+		_ = key.Unlock("passphrase")
+	}()
+
+	err = WaitForUnlock(context.Background(), key)
+	require.NoError(t, err)
+	require.False(t, key.Locked())
+	require.NoError(t, WaitForUnlock(context.Background(), NewEthereumKey(pk)))
+}
@@ -0,0 +1,180 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// urgentDeadline is how close to its deadline a transaction must be for it to be urgent.
+const urgentDeadline = time.Minute * 30
+
+// Urgency is how urgently a transaction needs to be included, which determines the fees paid for it.
+type Urgency byte
+
+const (
+	// UrgencyLow is for transactions which nothing is waiting on, such as deploying the contract
+	UrgencyLow Urgency = iota
+	// UrgencyNormal is for transactions the counterparty is waiting on
+	UrgencyNormal
+	// UrgencyHigh is for transactions which must be included before a deadline, such as refunds
+	UrgencyHigh
+)
+
+// String ...
+func (u Urgency) String() string {
+	switch u {
+	case UrgencyLow:
+		return "low"
+	case UrgencyNormal:
+		return "normal"
+	case UrgencyHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+// DeadlineUrgency returns the urgency of a transaction which must be included before the given
+// deadline: it's high if the deadline is near, and the given urgency otherwise.
+func DeadlineUrgency(urgency Urgency, deadline time.Time) Urgency {
+	if !deadline.IsZero() && time.Until(deadline) < urgentDeadline {
+		return UrgencyHigh
+	}
+
+	return urgency
+}
+
+// feeMultipliers are how much more than the suggested fees is paid at each urgency: the
+// priority fee is multiplied by tipPercent / 100, and the base fee by baseFee.
+var feeMultipliers = map[Urgency]struct {
+	tipPercent int64
+	baseFee    int64
+}{
+	UrgencyLow:    {tipPercent: 100, baseFee: 2},
+	UrgencyNormal: {tipPercent: 125, baseFee: 2},
+	UrgencyHigh:   {tipPercent: 200, baseFee: 3},
+}
+
+// Fees are the fees to pay for a transaction. Either GasFeeCap and GasTipCap are set, for an
+// EIP-1559 transaction, or GasPrice is, if the chain doesn't support EIP-1559.
+type Fees struct {
+	GasFeeCap *big.Int // max fee per gas
+	GasTipCap *big.Int // max priority fee per gas
+	GasPrice  *big.Int
+}
+
+// FeeOracle suggests the fees to pay for transactions.
+type FeeOracle interface {
+	SuggestFees(ctx context.Context, urgency Urgency) (*Fees, error)
+}
+
+// FeeClient is the subset of an ethereum client used by the default FeeOracle.
+type FeeClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// FeeOracleConfig contains the configuration values for the default FeeOracle.
+type FeeOracleConfig struct {
+	Client FeeClient
+
+	// caps on the fees paid for a transaction, whatever its urgency; there's no cap if nil.
+	// MaxFeePerGas also caps the gas price on chains which don't support EIP-1559.
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+type feeOracle struct {
+	client               FeeClient
+	maxFeePerGas         *big.Int
+	maxPriorityFeePerGas *big.Int
+}
+
+// NewFeeOracle returns the default FeeOracle, which suggests fees based on the latest block's
+// base fee and the client's suggested priority fee, paying more for more urgent transactions.
+func NewFeeOracle(cfg *FeeOracleConfig) FeeOracle {
+	return &feeOracle{
+		client:               cfg.Client,
+		maxFeePerGas:         cfg.MaxFeePerGas,
+		maxPriorityFeePerGas: cfg.MaxPriorityFeePerGas,
+	}
+}
+
+// SuggestFees ...
+func (o *feeOracle) SuggestFees(ctx context.Context, urgency Urgency) (*Fees, error) {
+	mul, has := feeMultipliers[urgency]
+	if !has {
+		return nil, fmt.Errorf("invalid urgency %d", urgency)
+	}
+
+	head, err := o.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+
+	if head.BaseFee == nil {
+		gasPrice, err := o.client.SuggestGasPrice(ctx) //nolint:govet
+		if err != nil {
+			return nil, fmt.Errorf("failed to get suggested gas price: %w", err)
+		}
+
+		gasPrice = percent(gasPrice, mul.tipPercent)
+		return &Fees{
+			GasPrice: capFee(gasPrice, o.maxFeePerGas),
+		}, nil
+	}
+
+	tip, err := o.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suggested priority fee: %w", err)
+	}
+
+	tip = capFee(percent(tip, mul.tipPercent), o.maxPriorityFeePerGas)
+	feeCap := new(big.Int).Mul(head.BaseFee, big.NewInt(mul.baseFee))
+	feeCap = capFee(feeCap.Add(feeCap, tip), o.maxFeePerGas)
+	if tip.Cmp(feeCap) > 0 {
+		tip = feeCap
+	}
+
+	return &Fees{
+		GasFeeCap: feeCap,
+		GasTipCap: tip,
+	}, nil
+}
+
+func percent(x *big.Int, p int64) *big.Int {
+	res := new(big.Int).Mul(x, big.NewInt(p))
+	return res.Div(res, big.NewInt(100))
+}
+
+func capFee(fee, max *big.Int) *big.Int {
+	if max != nil && fee.Cmp(max) > 0 {
+		return new(big.Int).Set(max)
+	}
+
+	return fee
+}
+
+// SetFees sets the fees of the given transaction options to those suggested by the oracle for a
+// transaction with the given urgency. Options with a fixed gas price are left as they are.
+func SetFees(ctx context.Context, oracle FeeOracle, opts *bind.TransactOpts, urgency Urgency) error {
+	if opts.GasPrice != nil {
+		return nil
+	}
+
+	fees, err := oracle.SuggestFees(ctx, urgency)
+	if err != nil {
+		return err
+	}
+
+	opts.GasPrice = fees.GasPrice
+	opts.GasFeeCap = fees.GasFeeCap
+	opts.GasTipCap = fees.GasTipCap
+	return nil
+}
@@ -0,0 +1,118 @@
+package protocol
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFeeClient struct {
+	baseFee  *big.Int
+	tip      *big.Int
+	gasPrice *big.Int
+}
+
+func (c *mockFeeClient) HeaderByNumber(_ context.Context, _ *big.Int) (*ethtypes.Header, error) {
+	return &ethtypes.Header{BaseFee: c.baseFee}, nil
+}
+
+func (c *mockFeeClient) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	return c.tip, nil
+}
+
+func (c *mockFeeClient) SuggestGasPrice(_ context.Context) (*big.Int, error) {
+	return c.gasPrice, nil
+}
+
+func TestFeeOracle_SuggestFees(t *testing.T) {
+	o := NewFeeOracle(&FeeOracleConfig{
+		Client: &mockFeeClient{
+			baseFee: big.NewInt(100),
+			tip:     big.NewInt(10),
+		},
+	})
+
+	fees, err := o.SuggestFees(context.Background(), UrgencyLow)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(10), fees.GasTipCap)
+	require.Equal(t, big.NewInt(210), fees.GasFeeCap)
+	require.Nil(t, fees.GasPrice)
+
+	fees, err = o.SuggestFees(context.Background(), UrgencyNormal)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(12), fees.GasTipCap)
+	require.Equal(t, big.NewInt(212), fees.GasFeeCap)
+
+	fees, err = o.SuggestFees(context.Background(), UrgencyHigh)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(20), fees.GasTipCap)
+	require.Equal(t, big.NewInt(320), fees.GasFeeCap)
+}
+
+func TestFeeOracle_SuggestFees_Caps(t *testing.T) {
+	o := NewFeeOracle(&FeeOracleConfig{
+		Client: &mockFeeClient{
+			baseFee: big.NewInt(100),
+			tip:     big.NewInt(10),
+		},
+		MaxFeePerGas:         big.NewInt(250),
+		MaxPriorityFeePerGas: big.NewInt(15),
+	})
+
+	fees, err := o.SuggestFees(context.Background(), UrgencyHigh)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(15), fees.GasTipCap)
+	require.Equal(t, big.NewInt(250), fees.GasFeeCap)
+}
+
+func TestFeeOracle_SuggestFees_Legacy(t *testing.T) {
+	o := NewFeeOracle(&FeeOracleConfig{
+		Client: &mockFeeClient{
+			gasPrice: big.NewInt(100),
+		},
+		MaxFeePerGas: big.NewInt(150),
+	})
+
+	fees, err := o.SuggestFees(context.Background(), UrgencyNormal)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(125), fees.GasPrice)
+	require.Nil(t, fees.GasFeeCap)
+	require.Nil(t, fees.GasTipCap)
+
+	fees, err = o.SuggestFees(context.Background(), UrgencyHigh)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(150), fees.GasPrice)
+}
+
+func TestSetFees(t *testing.T) {
+	o := NewFeeOracle(&FeeOracleConfig{
+		Client: &mockFeeClient{
+			baseFee: big.NewInt(100),
+			tip:     big.NewInt(10),
+		},
+	})
+
+	opts := &bind.TransactOpts{}
+	err := SetFees(context.Background(), o, opts, UrgencyLow)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(210), opts.GasFeeCap)
+	require.Equal(t, big.NewInt(10), opts.GasTipCap)
+
+	// a fixed gas price is used as is
+	opts = &bind.TransactOpts{GasPrice: big.NewInt(1)}
+	err = SetFees(context.Background(), o, opts, UrgencyHigh)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), opts.GasPrice)
+	require.Nil(t, opts.GasFeeCap)
+}
+
+func TestDeadlineUrgency(t *testing.T) {
+	require.Equal(t, UrgencyNormal, DeadlineUrgency(UrgencyNormal, time.Time{}))
+	require.Equal(t, UrgencyNormal, DeadlineUrgency(UrgencyNormal, time.Now().Add(time.Hour)))
+	require.Equal(t, UrgencyHigh, DeadlineUrgency(UrgencyNormal, time.Now().Add(time.Minute)))
+}
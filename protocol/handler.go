@@ -0,0 +1,52 @@
+package protocol
+
+import (
+	"github.com/noot/atomic-swap/common/types"
+	"github.com/noot/atomic-swap/net"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+var _ net.Handler = &Handler{}
+
+// Handler is a net.Handler which combines the offers of several handlers, and passes each
+// incoming swap to the handler which made the offer being taken. This allows both the ETH
+// and XMR sides of a node to make offers.
+type Handler struct {
+	handlers []net.Handler
+}
+
+// NewHandler returns a new *Handler which combines the given handlers.
+func NewHandler(handlers ...net.Handler) *Handler {
+	return &Handler{
+		handlers: handlers,
+	}
+}
+
+// GetOffers returns the offers of all the handlers.
+func (h *Handler) GetOffers() []*types.Offer {
+	var offers []*types.Offer
+	for _, handler := range h.handlers {
+		offers = append(offers, handler.GetOffers()...)
+	}
+
+	return offers
+}
+
+// HandleInitiateMessage passes the message to the handler which made the offer being taken.
+func (h *Handler) HandleInitiateMessage(who peer.ID, msg *net.SendKeysMessage) (net.SwapState, net.Message, error) {
+	id, err := types.HexToHash(msg.OfferID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, handler := range h.handlers {
+		for _, o := range handler.GetOffers() {
+			if o.GetID() == id {
+				return handler.HandleInitiateMessage(who, msg)
+			}
+		}
+	}
+
+	return nil, nil, net.NewRejectError(net.RejectOfferUnavailable, "%s", ErrOfferNotFound)
+}
@@ -0,0 +1,58 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
+	"github.com/noot/atomic-swap/net"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+type mockHandler struct {
+	offers    []*types.Offer
+	initiated bool
+}
+
+func (h *mockHandler) GetOffers() []*types.Offer {
+	return h.offers
+}
+
+func (h *mockHandler) HandleInitiateMessage(_ peer.ID, _ *net.SendKeysMessage) (net.SwapState, net.Message, error) {
+	h.initiated = true
+	return nil, nil, nil
+}
+
+func TestHandler(t *testing.T) {
+	xmrOffer := &types.Offer{
+		Provides:      common.ProvidesXMR,
+		MinimumAmount: common.MustNewAmount("1"),
+		MaximumAmount: common.MustNewAmount("2"),
+		ExchangeRate:  common.MustNewExchangeRate("0.1"),
+	}
+	ethOffer := &types.Offer{
+		Provides:      common.ProvidesETH,
+		MinimumAmount: common.MustNewAmount("0.1"),
+		MaximumAmount: common.MustNewAmount("0.2"),
+		ExchangeRate:  common.MustNewExchangeRate("0.1"),
+	}
+
+	alice := &mockHandler{offers: []*types.Offer{ethOffer}}
+	bob := &mockHandler{offers: []*types.Offer{xmrOffer}}
+	h := NewHandler(alice, bob)
+	require.Equal(t, []*types.Offer{ethOffer, xmrOffer}, h.GetOffers())
+
+	_, _, err := h.HandleInitiateMessage("", &net.SendKeysMessage{OfferID: xmrOffer.GetID().String()})
+	require.NoError(t, err)
+	require.False(t, alice.initiated)
+	require.True(t, bob.initiated)
+
+	_, _, err = h.HandleInitiateMessage("", &net.SendKeysMessage{OfferID: ethOffer.GetID().String()})
+	require.NoError(t, err)
+	require.True(t, alice.initiated)
+
+	_, _, err = h.HandleInitiateMessage("", &net.SendKeysMessage{OfferID: types.Hash{}.String()})
+	require.ErrorIs(t, err, &net.RejectError{Reason: net.RejectOfferUnavailable})
+}
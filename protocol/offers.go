@@ -0,0 +1,204 @@
+package protocol
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
+	"github.com/noot/atomic-swap/net"
+
+	logging "github.com/ipfs/go-log"
+)
+
+var log = logging.Logger("protocol")
+
+// ErrOfferNotFound is returned when we don't have an offer with the given ID.
+var ErrOfferNotFound = errors.New("failed to find offer with given ID")
+
+// ErrOfferAlreadyFilled is returned when an offer's update would leave less than its minimum amount
+// remaining, once the amount already filled is deducted from its new maximum amount.
+var ErrOfferAlreadyFilled = errors.New("offer's remaining amount would be less than its minimum amount")
+
+// OfferManager keeps track of the offers we've made, and how much of each is still available.
+type OfferManager struct {
+	sync.RWMutex
+	offers map[types.Hash]*offerEntry
+}
+
+// offerEntry tracks how much of an offer is still available. An offer starts out with its
+// maximum amount remaining; each swap taking from it reserves its amount while it's in progress,
+// and the remaining amount is decremented when the swap completes.
+type offerEntry struct {
+	offer        *types.Offer
+	remaining    common.Amount
+	reservations map[uint64]common.Amount // swap ID -> reserved amount
+}
+
+// available returns the amount of the offer which can still be taken.
+func (e *offerEntry) available() common.Amount {
+	var reserved common.Amount
+	for _, amt := range e.reservations {
+		reserved = reserved.Add(amt)
+	}
+
+	return e.remaining.Sub(reserved)
+}
+
+// current returns a copy of the offer with its maximum amount lowered to the amount still available.
+func (e *offerEntry) current() *types.Offer {
+	o := *e.offer
+	if available := e.available(); available.Cmp(o.MaximumAmount) < 0 {
+		o.MaximumAmount = available
+	}
+
+	return &o
+}
+
+// NewOfferManager returns a new, empty *OfferManager.
+func NewOfferManager() *OfferManager {
+	return &OfferManager{
+		offers: make(map[types.Hash]*offerEntry),
+	}
+}
+
+// PutOffer adds an offer, or replaces the terms of an existing one. The amount already filled by
+// completed swaps is deducted from the new maximum amount, and the reservations of in-progress
+// swaps are kept. An update is rejected if what remains would be less than the minimum amount,
+// since Fill would have retired the offer.
+func (om *OfferManager) PutOffer(o *types.Offer) error {
+	om.Lock()
+	defer om.Unlock()
+
+	entry := &offerEntry{
+		offer:        o,
+		remaining:    o.MaximumAmount,
+		reservations: make(map[uint64]common.Amount),
+	}
+
+	if e, has := om.offers[o.GetID()]; has {
+		filled := e.offer.MaximumAmount.Sub(e.remaining)
+		entry.remaining = o.MaximumAmount.Sub(filled)
+		entry.reservations = e.reservations
+
+		if entry.remaining.Cmp(o.MinimumAmount) < 0 || entry.remaining.IsZero() {
+			return ErrOfferAlreadyFilled
+		}
+	}
+
+	om.offers[o.GetID()] = entry
+	return nil
+}
+
+// GetOffer returns the offer with the given ID, or nil if it doesn't exist or has expired.
+// The offer's maximum amount is the amount still available.
+func (om *OfferManager) GetOffer(id types.Hash) *types.Offer {
+	om.Lock()
+	defer om.Unlock()
+	om.deleteExpired()
+
+	e, has := om.offers[id]
+	if !has {
+		return nil
+	}
+
+	return e.current()
+}
+
+// DeleteOffer deletes the offer with the given ID, returning false if it didn't exist.
+func (om *OfferManager) DeleteOffer(id types.Hash) bool {
+	om.Lock()
+	defer om.Unlock()
+	om.deleteExpired()
+
+	if _, has := om.offers[id]; !has {
+		return false
+	}
+
+	delete(om.offers, id)
+	return true
+}
+
+// GetOffers returns all current offers, with their maximum amounts lowered to the amounts still available.
+func (om *OfferManager) GetOffers() []*types.Offer {
+	om.Lock()
+	defer om.Unlock()
+	om.deleteExpired()
+
+	offers := make([]*types.Offer, len(om.offers))
+	i := 0
+	for _, e := range om.offers {
+		offers[i] = e.current()
+		i++
+	}
+	return offers
+}
+
+// Reserve reserves the given amount of the offer for the swap with the given ID.
+func (om *OfferManager) Reserve(id types.Hash, swapID uint64, amount common.Amount) error {
+	om.Lock()
+	defer om.Unlock()
+	om.deleteExpired()
+
+	e, has := om.offers[id]
+	if !has {
+		return net.NewRejectError(net.RejectOfferUnavailable, "failed to find offer with given ID")
+	}
+
+	if available := e.available(); amount.Cmp(available) > 0 {
+		return net.NewRejectError(net.RejectAmountOutOfRange,
+			"amount provided by taker is too high for offer: %s %s, available %s", amount, e.offer.Provides, available)
+	}
+
+	e.reservations[swapID] = amount
+	return nil
+}
+
+// Release releases the amount reserved for the swap with the given ID, if any,
+// making it available to be taken again.
+func (om *OfferManager) Release(id types.Hash, swapID uint64) {
+	om.Lock()
+	defer om.Unlock()
+
+	if e, has := om.offers[id]; has {
+		delete(e.reservations, swapID)
+	}
+}
+
+// Fill deducts the amount reserved for the swap with the given ID, if any, from the offer's
+// remaining amount. If what remains is less than the offer's minimum amount, the offer is deleted.
+func (om *OfferManager) Fill(id types.Hash, swapID uint64) {
+	om.Lock()
+	defer om.Unlock()
+
+	e, has := om.offers[id]
+	if !has {
+		return
+	}
+
+	amount, has := e.reservations[swapID]
+	if !has {
+		return
+	}
+
+	delete(e.reservations, swapID)
+	e.remaining = e.remaining.Sub(amount)
+
+	if e.remaining.Cmp(e.offer.MinimumAmount) < 0 || e.remaining.IsZero() {
+		log.Infof("offer filled: id=%s", id)
+		delete(om.offers, id)
+		return
+	}
+
+	log.Infof("offer partially filled: id=%s remaining=%s", id, e.remaining)
+}
+
+// deleteExpired deletes expired offers. It must be called with the lock held.
+func (om *OfferManager) deleteExpired() {
+	for id, e := range om.offers {
+		if e.offer.IsExpired() {
+			log.Infof("offer expired: id=%s", id)
+			delete(om.offers, id)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
+	"github.com/noot/atomic-swap/net"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfferManager(t *testing.T) {
+	om := NewOfferManager()
+
+	offer := &types.Offer{
+		Provides:      common.ProvidesXMR,
+		MinimumAmount: common.MustNewAmount("1"),
+		MaximumAmount: common.MustNewAmount("2"),
+		ExchangeRate:  common.MustNewExchangeRate("0.1"),
+	}
+	require.NoError(t, om.PutOffer(offer))
+
+	expired := &types.Offer{
+		Provides:      common.ProvidesXMR,
+		MinimumAmount: common.MustNewAmount("1"),
+		MaximumAmount: common.MustNewAmount("2"),
+		ExchangeRate:  common.MustNewExchangeRate("0.2"),
+		Expiry:        time.Now().Add(-time.Second).Unix(),
+	}
+	require.NoError(t, om.PutOffer(expired))
+
+	require.Equal(t, []*types.Offer{offer}, om.GetOffers())
+	require.Nil(t, om.GetOffer(expired.GetID()))
+	require.Equal(t, offer, om.GetOffer(offer.GetID()))
+
+	require.True(t, om.DeleteOffer(offer.GetID()))
+	require.False(t, om.DeleteOffer(offer.GetID()))
+	require.Empty(t, om.GetOffers())
+}
+
+func TestOfferManager_PartialFills(t *testing.T) {
+	om := NewOfferManager()
+
+	offer := &types.Offer{
+		Provides:      common.ProvidesXMR,
+		MinimumAmount: common.MustNewAmount("1"),
+		MaximumAmount: common.MustNewAmount("5"),
+		ExchangeRate:  common.MustNewExchangeRate("0.1"),
+	}
+	require.NoError(t, om.PutOffer(offer))
+	id := offer.GetID()
+
+	// concurrent swaps can't reserve more than is available
+	require.NoError(t, om.Reserve(id, 0, common.MustNewAmount("2")))
+	require.NoError(t, om.Reserve(id, 1, common.MustNewAmount("2")))
+	require.ErrorIs(t, om.Reserve(id, 2, common.MustNewAmount("1.5")), &net.RejectError{Reason: net.RejectAmountOutOfRange})
+	require.Equal(t, "1", om.GetOffer(id).MaximumAmount.String())
+
+	// a failed swap releases its reservation
+	om.Release(id, 1)
+	require.Equal(t, "3", om.GetOffer(id).MaximumAmount.String())
+
+	// a completed swap is deducted from the remaining amount
+	om.Fill(id, 0)
+	require.Equal(t, "3", om.GetOffer(id).MaximumAmount.String())
+	om.Fill(id, 0)
+	require.Equal(t, "3", om.GetOffer(id).MaximumAmount.String())
+
+	// the offer's own terms aren't modified
+	require.Equal(t, "5", offer.MaximumAmount.String())
+
+	// updating the offer keeps the amount already filled, and the reservations
+	increased := *offer
+	increased.MaximumAmount = common.MustNewAmount("10")
+	require.NoError(t, om.PutOffer(&increased))
+	require.Equal(t, "8", om.GetOffer(id).MaximumAmount.String())
+	decreased := *offer
+	decreased.MaximumAmount = common.MustNewAmount("5")
+	require.NoError(t, om.PutOffer(&decreased))
+	require.Equal(t, "3", om.GetOffer(id).MaximumAmount.String())
+
+	// an update leaving less than the minimum amount after what's already filled is rejected
+	tooLow := *offer
+	tooLow.MaximumAmount = common.MustNewAmount("2.5")
+	require.ErrorIs(t, om.PutOffer(&tooLow), ErrOfferAlreadyFilled)
+	tooLow.MaximumAmount = common.MustNewAmount("1")
+	require.ErrorIs(t, om.PutOffer(&tooLow), ErrOfferAlreadyFilled)
+	require.Equal(t, "3", om.GetOffer(id).MaximumAmount.String())
+
+	// the offer is retired once the remainder is below the minimum amount
+	require.NoError(t, om.Reserve(id, 3, common.MustNewAmount("2.5")))
+	om.Fill(id, 3)
+	require.Nil(t, om.GetOffer(id))
+	require.ErrorIs(t, om.Reserve(id, 4, common.MustNewAmount("0.5")), &net.RejectError{Reason: net.RejectOfferUnavailable})
+}
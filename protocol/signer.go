@@ -0,0 +1,213 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// Signer signs the transactions of the ethereum account they're sent from.
+type Signer interface {
+	// Address returns the address of the account.
+	Address() ethcommon.Address
+	// Locked returns whether the signer can't currently sign transactions, eg. because its key
+	// needs to be unlocked.
+	Locked() bool
+	// TransactOpts returns options for sending transactions signed by the signer.
+	TransactOpts(chainID *big.Int) *bind.TransactOpts
+}
+
+// ContextSigner is a Signer which makes calls to sign transactions, eg. to an external process, so
+// that they can be made with the context the transactions are sent with.
+type ContextSigner interface {
+	Signer
+	// SignerFn returns a function signing transactions for the given chain with the given context.
+	SignerFn(ctx context.Context, chainID *big.Int) bind.SignerFn
+}
+
+var (
+	_ Signer        = &EthereumKey{}
+	_ ContextSigner = &ExternalSigner{}
+)
+
+// unlockPollInterval is how often WaitForUnlock checks whether the signer has been unlocked.
+var unlockPollInterval = time.Second
+
+// WaitForUnlock waits for the signer to be unlocked, eg. with personal_unlock, so that it can sign
+// transactions. It returns the context's error if the context is done first.
+func WaitForUnlock(ctx context.Context, signer Signer) error {
+	if !signer.Locked() {
+		return nil
+	}
+
+	log.Warnf("waiting for ethereum account %s to be unlocked with personal_unlock to send transaction",
+		signer.Address())
+
+	ticker := time.NewTicker(unlockPollInterval)
+	defer ticker.Stop()
+	for signer.Locked() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	return nil
+}
+
+// ExternalSigner is a Signer which has transactions signed by an external process, such as
+// Clef, through its JSON-RPC signing API, so that the account's key isn't held by the daemon.
+type ExternalSigner struct {
+	client  *ethrpc.Client
+	address ethcommon.Address
+}
+
+// NewExternalSigner returns a new *ExternalSigner for the signer at the given endpoint. The
+// account must be one of the signer's accounts; if it's the zero address, the signer must
+// only have one account, which is used.
+func NewExternalSigner(ctx context.Context, endpoint string, account ethcommon.Address) (*ExternalSigner, error) {
+	client, err := ethrpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to external signer: %w", err)
+	}
+
+	var accounts []ethcommon.Address
+	if err = client.CallContext(ctx, &accounts, "account_list"); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to list external signer's accounts: %w", err)
+	}
+
+	addresses := make([]string, len(accounts))
+	for i, a := range accounts {
+		if a == account || (account == ethcommon.Address{}) && len(accounts) == 1 {
+			return &ExternalSigner{
+				client:  client,
+				address: a,
+			}, nil
+		}
+
+		addresses[i] = a.Hex()
+	}
+
+	client.Close()
+	switch {
+	case len(accounts) == 0:
+		return nil, errors.New("external signer has no accounts")
+	case (account == ethcommon.Address{}):
+		return nil, fmt.Errorf("external signer has several accounts, one must be chosen: %s",
+			strings.Join(addresses, ", "))
+	default:
+		return nil, fmt.Errorf("account %s not found in external signer, which has: %s", account,
+			strings.Join(addresses, ", "))
+	}
+}
+
+// Address ...
+func (s *ExternalSigner) Address() ethcommon.Address {
+	return s.address
+}
+
+// Locked always returns false; the external signer decides whether to sign each transaction.
+func (s *ExternalSigner) Locked() bool {
+	return false
+}
+
+// sendTxArgs are the arguments of account_signTransaction.
+type sendTxArgs struct {
+	From                 ethcommon.Address  `json:"from"`
+	To                   *ethcommon.Address `json:"to"`
+	Gas                  hexutil.Uint64     `json:"gas"`
+	GasPrice             *hexutil.Big       `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big       `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big       `json:"maxPriorityFeePerGas,omitempty"`
+	Value                hexutil.Big        `json:"value"`
+	Nonce                hexutil.Uint64     `json:"nonce"`
+	Data                 hexutil.Bytes      `json:"data"`
+	ChainID              *hexutil.Big       `json:"chainId,omitempty"`
+}
+
+// signTxResult is the result of account_signTransaction.
+type signTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// TransactOpts returns options for sending transactions signed by the external signer. Since
+// their Signer can't be given the context the transactions are sent with, it waits for the
+// external signer indefinitely; use SignerFn to bind it to a context.
+func (s *ExternalSigner) TransactOpts(chainID *big.Int) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:    s.address,
+		Signer:  s.SignerFn(context.Background(), chainID),
+		Context: context.Background(),
+	}
+}
+
+// SignerFn returns a function which has transactions for the given chain signed by the external
+// signer, giving up if the context is done first.
+func (s *ExternalSigner) SignerFn(ctx context.Context, chainID *big.Int) bind.SignerFn {
+	signer := ethtypes.LatestSignerForChainID(chainID)
+	return func(address ethcommon.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+		if address != s.address {
+			return nil, bind.ErrNotAuthorized
+		}
+
+		return s.signTx(ctx, signer, chainID, tx)
+	}
+}
+
+func (s *ExternalSigner) signTx(ctx context.Context, signer ethtypes.Signer, chainID *big.Int,
+	tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+	args := &sendTxArgs{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+
+	if tx.Type() == ethtypes.LegacyTxType {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	} else {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	}
+
+	var res signTxResult
+	if err := s.client.CallContext(ctx, &res, "account_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("external signer failed to sign transaction: %w", err)
+	}
+
+	signed := new(ethtypes.Transaction)
+	if err := signed.UnmarshalBinary(res.Raw); err != nil {
+		return nil, fmt.Errorf("external signer returned an invalid transaction: %w", err)
+	}
+
+	// the signer could change the transaction, eg. if it's configured to, but then it isn't the
+	// transaction we meant to send
+	if signer.Hash(signed) != signer.Hash(tx) {
+		return nil, errors.New("external signer signed a different transaction than requested")
+	}
+
+	sender, err := ethtypes.Sender(signer, signed)
+	if err != nil {
+		return nil, fmt.Errorf("external signer returned an invalid signature: %w", err)
+	}
+
+	if sender != s.address {
+		return nil, fmt.Errorf("external signer signed the transaction with %s instead of %s", sender, s.address)
+	}
+
+	return signed, nil
+}
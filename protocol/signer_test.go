@@ -0,0 +1,145 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// mockClef is a stand-in for Clef's account API, which signs transactions with its keys.
+type mockClef struct {
+	keys []*ecdsa.PrivateKey
+
+	// if set, the nonce of transactions is changed before they're signed
+	tamper bool
+}
+
+func (c *mockClef) List() []ethcommon.Address {
+	addresses := make([]ethcommon.Address, len(c.keys))
+	for i, k := range c.keys {
+		addresses[i] = crypto.PubkeyToAddress(k.PublicKey)
+	}
+
+	return addresses
+}
+
+func (c *mockClef) SignTransaction(args sendTxArgs) (*signTxResult, error) {
+	nonce := uint64(args.Nonce)
+	if c.tamper {
+		nonce++
+	}
+
+	tx := ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+		ChainID:   args.ChainID.ToInt(),
+		Nonce:     nonce,
+		GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+		GasFeeCap: args.MaxFeePerGas.ToInt(),
+		Gas:       uint64(args.Gas),
+		To:        args.To,
+		Value:     args.Value.ToInt(),
+		Data:      args.Data,
+	})
+
+	for _, k := range c.keys {
+		if crypto.PubkeyToAddress(k.PublicKey) != args.From {
+			continue
+		}
+
+		signed, err := ethtypes.SignTx(tx, ethtypes.LatestSignerForChainID(args.ChainID.ToInt()), k)
+		if err != nil {
+			return nil, err
+		}
+
+		raw, err := signed.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &signTxResult{Raw: hexutil.Bytes(raw)}, nil
+	}
+
+	return nil, ethrpc.ErrNoResult
+}
+
+func newMockClef(t *testing.T, clef *mockClef) string {
+	srv := ethrpc.NewServer()
+	require.NoError(t, srv.RegisterName("account", clef))
+	s := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		s.Close()
+		srv.Stop()
+	})
+	return s.URL
+}
+
+func TestExternalSigner(t *testing.T) {
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	clef := &mockClef{keys: []*ecdsa.PrivateKey{pk}}
+	endpoint := newMockClef(t, clef)
+
+	s, err := NewExternalSigner(context.Background(), endpoint, ethcommon.Address{})
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(pk.PublicKey), s.Address())
+	require.False(t, s.Locked())
+
+	chainID := big.NewInt(1337)
+	to := ethcommon.Address{1}
+	tx := ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     3,
+		GasTipCap: big.NewInt(10),
+		GasFeeCap: big.NewInt(210),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+		Data:      []byte{1, 2, 3},
+	})
+
+	opts := s.TransactOpts(chainID)
+	signed, err := opts.Signer(opts.From, tx)
+	require.NoError(t, err)
+	sender, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, s.Address(), sender)
+	require.Equal(t, tx.Nonce(), signed.Nonce())
+
+	// signing is abandoned once the context it's made with is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.SignerFn(ctx, chainID)(s.Address(), tx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// transactions changed by the signer are rejected
+	clef.tamper = true
+	_, err = opts.Signer(opts.From, tx)
+	require.Error(t, err)
+}
+
+func TestNewExternalSigner_Accounts(t *testing.T) {
+	pk1, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	pk2, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	endpoint := newMockClef(t, &mockClef{keys: []*ecdsa.PrivateKey{pk1, pk2}})
+
+	// an account must be chosen if the signer has several
+	_, err = NewExternalSigner(context.Background(), endpoint, ethcommon.Address{})
+	require.Error(t, err)
+
+	s, err := NewExternalSigner(context.Background(), endpoint, crypto.PubkeyToAddress(pk2.PublicKey))
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(pk2.PublicKey), s.Address())
+
+	_, err = NewExternalSigner(context.Background(), endpoint, ethcommon.Address{9})
+	require.Error(t, err)
+}
@@ -0,0 +1,120 @@
+package swap
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/noot/atomic-swap/crypto"
+	"github.com/noot/atomic-swap/net"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+var checkpointPrefix = []byte("checkpoint-")
+
+// Checkpoint contains the protocol state needed to resume a swap after swapd restarts.
+// Keys are hex-encoded.
+type Checkpoint struct {
+	// our private spend key for this swap; if the swap secrets are encrypted, it's
+	// stored in EncryptedPrivateSpendKey instead
+	PrivateSpendKey          string
+	EncryptedPrivateSpendKey []byte
+
+	// the counterparty's keys. the view key is Bob's private view key when
+	// we're Alice, and Alice's public view key when we're Bob.
+	CounterpartyPublicSpendKey string
+	CounterpartyViewKey        string
+	CounterpartySecp256k1Key   string
+
+	// the transaction which locked Bob's XMR, once it's been sent
+	XMRLockTxHash string
+
+	// the transaction creating Alice's swap, whose receipt has the swap's ID; only set if we
+	// exited before the swap's creation was confirmed, when the ID isn't known yet
+	NewSwapTxHash ethcommon.Hash
+
+	// the block the swap's events are watched from when it's resumed: the block it was created in
+	// for Alice, or the latest block when Bob learned of it. Zero if it isn't known yet.
+	FromBlock uint64
+
+	// the swap contract, and the ID of the swap within it
+	ContractAddress     ethcommon.Address
+	ContractSwapID      ethcommon.Hash
+	Timeout0, Timeout1  time.Time
+	NextExpectedMessage net.MessageType
+}
+
+// SpendKeyBytes returns our private spend key for the swap, decrypting it with the given
+// passphrase if it's encrypted.
+func (cp *Checkpoint) SpendKeyBytes(passphrase string) ([]byte, error) {
+	if cp.EncryptedPrivateSpendKey == nil {
+		return hex.DecodeString(cp.PrivateSpendKey)
+	}
+
+	if passphrase == "" {
+		return nil, errors.New("checkpoint's private spend key is encrypted, but no passphrase is set")
+	}
+
+	return crypto.DecryptSecret(cp.EncryptedPrivateSpendKey, passphrase)
+}
+
+func checkpointKey(id uint64) []byte {
+	key := make([]byte, len(checkpointPrefix)+8)
+	copy(key, checkpointPrefix)
+	binary.BigEndian.PutUint64(key[len(checkpointPrefix):], id)
+	return key
+}
+
+// WriteCheckpoint stores the protocol state of the swap with the given ID.
+func (m *Manager) WriteCheckpoint(id uint64, cp *Checkpoint) error {
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	return m.db.Put(checkpointKey(id), b)
+}
+
+// GetCheckpoint returns the latest protocol state of the swap with the given ID,
+// or nil if none has been written.
+func (m *Manager) GetCheckpoint(id uint64) (*Checkpoint, error) {
+	has, err := m.db.Has(checkpointKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	if !has {
+		return nil, nil
+	}
+
+	b, err := m.db.Get(checkpointKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	var cp *Checkpoint
+	if err = json.Unmarshal(b, &cp); err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// GetInterruptedSwaps returns the swaps loaded from the database that were still
+// ongoing when swapd last exited.
+func (m *Manager) GetInterruptedSwaps() []*Info {
+	m.RLock()
+	defer m.RUnlock()
+
+	var infos []*Info
+	for _, info := range m.past {
+		if info.Status() == Ongoing {
+			infos = append(infos, info)
+		}
+	}
+
+	return infos
+}
@@ -0,0 +1,95 @@
+package swap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/crypto"
+	"github.com/noot/atomic-swap/net"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Checkpoint(t *testing.T) {
+	db, err := OpenDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	m, err := NewManager(db)
+	require.NoError(t, err)
+
+	info := NewInfo(common.ProvidesETH, common.MustNewAmount("1"), common.MustNewAmount("10"),
+		common.MustNewExchangeRate("0.1"), Ongoing)
+	err = m.AddSwap(info)
+	require.NoError(t, err)
+
+	cp, err := m.GetCheckpoint(info.ID())
+	require.NoError(t, err)
+	require.Nil(t, cp)
+
+	t0 := time.Unix(time.Now().Unix(), 0)
+	cp = &Checkpoint{
+		PrivateSpendKey:     "abcd",
+		XMRLockTxHash:       "1234",
+		FromBlock:           100,
+		ContractAddress:     ethcommon.HexToAddress("0xabcd"),
+		ContractSwapID:      ethcommon.HexToHash("0x1234"),
+		Timeout0:            t0,
+		Timeout1:            t0.Add(time.Hour),
+		NextExpectedMessage: net.NotifyXMRLockType,
+	}
+	err = m.WriteCheckpoint(info.ID(), cp)
+	require.NoError(t, err)
+
+	// reopen the manager, as if the daemon restarted
+	m, err = NewManager(db)
+	require.NoError(t, err)
+
+	interrupted := m.GetInterruptedSwaps()
+	require.Len(t, interrupted, 1)
+	require.Equal(t, info.ID(), interrupted[0].ID())
+
+	res, err := m.GetCheckpoint(info.ID())
+	require.NoError(t, err)
+	require.Equal(t, cp.PrivateSpendKey, res.PrivateSpendKey)
+	require.Equal(t, cp.XMRLockTxHash, res.XMRLockTxHash)
+	require.Equal(t, cp.FromBlock, res.FromBlock)
+	require.Equal(t, cp.ContractAddress, res.ContractAddress)
+	require.Equal(t, cp.ContractSwapID, res.ContractSwapID)
+	require.True(t, cp.Timeout0.Equal(res.Timeout0))
+	require.True(t, cp.Timeout1.Equal(res.Timeout1))
+	require.Equal(t, net.NotifyXMRLockType, res.NextExpectedMessage)
+
+	// a resumed swap is ongoing until it completes
+	m.ResumeSwap(info.ID())
+	require.Equal(t, interrupted[0], m.GetOngoingSwap(info.ID()))
+	require.Nil(t, m.GetPastSwap(info.ID()))
+	require.Empty(t, m.GetInterruptedSwaps())
+
+	interrupted[0].SetStatus(Refunded)
+	m.CompleteOngoingSwap(info.ID())
+	require.Nil(t, m.GetOngoingSwap(info.ID()))
+	require.Equal(t, Refunded, m.GetPastSwap(info.ID()).Status())
+	require.NoError(t, db.Close())
+}
+
+func TestCheckpoint_SpendKeyBytes(t *testing.T) {
+	cp := &Checkpoint{PrivateSpendKey: "abcd"}
+	sk, err := cp.SpendKeyBytes("")
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xab, 0xcd}, sk)
+
+	encrypted, err := crypto.EncryptSecret([]byte{0xab, 0xcd}, "passphrase")
+	require.NoError(t, err)
+	cp = &Checkpoint{EncryptedPrivateSpendKey: encrypted}
+
+	_, err = cp.SpendKeyBytes("")
+	require.Error(t, err)
+	_, err = cp.SpendKeyBytes("wrong")
+	require.Error(t, err)
+
+	sk, err = cp.SpendKeyBytes("passphrase")
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xab, 0xcd}, sk)
+}
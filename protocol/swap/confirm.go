@@ -0,0 +1,79 @@
+package swap
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrSwapDeclined is returned by AwaitConfirmation when the user declines the swap.
+	ErrSwapDeclined = errors.New("swap was declined")
+	// ErrConfirmationTimeout is returned by AwaitConfirmation when the swap isn't confirmed in time.
+	ErrConfirmationTimeout = errors.New("timed out waiting for swap to be confirmed")
+
+	errNotAwaitingConfirmation = errors.New("swap is not awaiting confirmation")
+)
+
+// AwaitingConfirmation returns true if the swap is waiting for the user to confirm or decline it.
+func (i *Info) AwaitingConfirmation() bool {
+	i.confirmMu.Lock()
+	defer i.confirmMu.Unlock()
+	return i.confirmCh != nil
+}
+
+// AwaitConfirmation blocks until the user confirms or declines the swap with Confirm. It returns
+// ErrSwapDeclined if the swap is declined, and ErrConfirmationTimeout if neither happens before
+// the timeout.
+func (i *Info) AwaitConfirmation(ctx context.Context, timeout time.Duration) error {
+	ch := make(chan bool, 1)
+
+	i.confirmMu.Lock()
+	i.confirmCh = ch
+	i.confirmMu.Unlock()
+	i.notify()
+
+	defer func() {
+		i.confirmMu.Lock()
+		i.confirmCh = nil
+		i.confirmMu.Unlock()
+		i.notify()
+	}()
+
+	log.Infof("swap is awaiting confirmation: id=%d provided=%s %s received=%s",
+		i.ID(), i.ProvidedAmount(), i.Provides(), i.ReceivedAmount())
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(timeout):
+		return ErrConfirmationTimeout
+	case accept := <-ch:
+		if !accept {
+			return ErrSwapDeclined
+		}
+
+		return nil
+	}
+}
+
+// Confirm accepts or declines a swap which is waiting in AwaitConfirmation.
+func (i *Info) Confirm(accept bool) error {
+	i.confirmMu.Lock()
+	defer i.confirmMu.Unlock()
+
+	if i.confirmCh == nil {
+		return errNotAwaitingConfirmation
+	}
+
+	i.confirmCh <- accept
+	i.confirmCh = nil
+	return nil
+}
+
+// notify takes the lock and publishes an Event with the swap's current stage and status.
+func (i *Info) notify() {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	i.publish("", time.Now())
+}
@@ -0,0 +1,50 @@
+package swap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/noot/atomic-swap/common"
+
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfo_AwaitConfirmation(t *testing.T) {
+	m, err := NewManager(memorydb.New())
+	require.NoError(t, err)
+
+	events, unsubscribe := m.SubscribeEvents()
+	defer unsubscribe()
+
+	info := NewInfo(common.ProvidesETH, common.MustNewAmount("1"), common.MustNewAmount("10"),
+		common.MustNewExchangeRate("0.1"), Ongoing)
+	require.NoError(t, m.AddSwap(info))
+	require.ErrorIs(t, info.Confirm(true), errNotAwaitingConfirmation)
+
+	for _, accept := range []bool{true, false} {
+		errCh := make(chan error)
+		go func() {
+			errCh <- info.AwaitConfirmation(context.Background(), time.Minute)
+		}()
+
+		ev := <-events
+		require.True(t, ev.AwaitingConfirmation)
+		require.True(t, info.AwaitingConfirmation())
+		require.NoError(t, info.Confirm(accept))
+
+		if accept {
+			require.NoError(t, <-errCh)
+		} else {
+			require.ErrorIs(t, <-errCh, ErrSwapDeclined)
+		}
+
+		ev = <-events
+		require.False(t, ev.AwaitingConfirmation)
+		require.False(t, info.AwaitingConfirmation())
+	}
+
+	err = info.AwaitConfirmation(context.Background(), time.Millisecond)
+	require.ErrorIs(t, err, ErrConfirmationTimeout)
+}
@@ -0,0 +1,224 @@
+package swap
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"time"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	databaseDir     = "db"
+	databaseCache   = 16 // MB
+	databaseHandles = 16
+)
+
+var (
+	swapPrefix        = []byte("swap-")
+	nextIDKey         = []byte("nextID")
+	swapFactoryPrefix = []byte("swapFactory-")
+)
+
+// OpenDatabase opens (or creates) the swap database within the given basepath.
+func OpenDatabase(basepath string) (ethdb.KeyValueStore, error) {
+	return leveldb.New(filepath.Join(basepath, databaseDir), databaseCache, databaseHandles, "", false)
+}
+
+// infoEncoding is the format an *Info is stored in within the database.
+type infoEncoding struct {
+	ID              uint64
+	OfferID         types.Hash
+	Counterparty    string
+	Provides        common.ProvidesCoin
+	ProvidedAmount  common.Amount
+	ReceivedAmount  common.Amount
+	ExchangeRate    common.ExchangeRate
+	ContractAddress ethcommon.Address
+	ContractSwapID  ethcommon.Hash
+	XMRLockAddress  mcrypto.Address
+	XMRLockTxHash   string
+	SweepAddress    mcrypto.Address
+	SweepTxHashes   []string
+	Timeout0        time.Time
+	Timeout1        time.Time
+	Status          Status
+	StatusHistory   []StatusChange
+	Stage           Stage
+}
+
+// MarshalJSON ...
+func (i *Info) MarshalJSON() ([]byte, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.encode()
+}
+
+// encode returns the Info's JSON encoding. It must be called with the lock held.
+func (i *Info) encode() ([]byte, error) {
+	var counterparty string
+	if i.counterparty != "" {
+		counterparty = i.counterparty.Pretty()
+	}
+
+	return json.Marshal(&infoEncoding{
+		ID:              i.id,
+		OfferID:         i.offerID,
+		Counterparty:    counterparty,
+		Provides:        i.provides,
+		ProvidedAmount:  i.providedAmount,
+		ReceivedAmount:  i.receivedAmount,
+		ExchangeRate:    i.exchangeRate,
+		ContractAddress: i.contractAddress,
+		ContractSwapID:  i.contractSwapID,
+		XMRLockAddress:  i.xmrLockAddress,
+		XMRLockTxHash:   i.xmrLockTxHash,
+		SweepAddress:    i.sweepAddress,
+		SweepTxHashes:   i.sweepTxHashes,
+		Timeout0:        i.timeout0,
+		Timeout1:        i.timeout1,
+		Status:          i.status,
+		StatusHistory:   i.statusHistory,
+		Stage:           i.stage,
+	})
+}
+
+// UnmarshalJSON ...
+func (i *Info) UnmarshalJSON(b []byte) error {
+	var enc infoEncoding
+	if err := json.Unmarshal(b, &enc); err != nil {
+		return err
+	}
+
+	if enc.Counterparty != "" {
+		who, err := peer.Decode(enc.Counterparty)
+		if err != nil {
+			return err
+		}
+
+		i.counterparty = who
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.id = enc.ID
+	i.offerID = enc.OfferID
+	i.provides = enc.Provides
+	i.providedAmount = enc.ProvidedAmount
+	i.receivedAmount = enc.ReceivedAmount
+	i.exchangeRate = enc.ExchangeRate
+	i.contractAddress = enc.ContractAddress
+	i.contractSwapID = enc.ContractSwapID
+	i.xmrLockAddress = enc.XMRLockAddress
+	i.xmrLockTxHash = enc.XMRLockTxHash
+	i.sweepAddress = enc.SweepAddress
+	i.sweepTxHashes = enc.SweepTxHashes
+	i.timeout0 = enc.Timeout0
+	i.timeout1 = enc.Timeout1
+	i.status = enc.Status
+	i.statusHistory = enc.StatusHistory
+	i.stage = enc.Stage
+	return nil
+}
+
+func swapKey(id uint64) []byte {
+	key := make([]byte, len(swapPrefix)+8)
+	copy(key, swapPrefix)
+	binary.BigEndian.PutUint64(key[len(swapPrefix):], id)
+	return key
+}
+
+func (m *Manager) writeSwap(info *Info) error {
+	b, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	return m.db.Put(swapKey(info.id), b)
+}
+
+func (m *Manager) readAllSwaps() ([]*Info, error) {
+	iter := m.db.NewIterator(swapPrefix, nil)
+	defer iter.Release()
+
+	var infos []*Info
+	for iter.Next() {
+		info := new(Info)
+		if err := json.Unmarshal(iter.Value(), info); err != nil {
+			return nil, fmt.Errorf("failed to decode swap with key 0x%x: %w", iter.Key(), err)
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos, iter.Error()
+}
+
+func (m *Manager) writeNextID(id uint64) error {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, id)
+	return m.db.Put(nextIDKey, b)
+}
+
+func (m *Manager) readNextID() (uint64, error) {
+	has, err := m.db.Has(nextIDKey)
+	if err != nil {
+		return 0, err
+	}
+
+	if !has {
+		return 0, nil
+	}
+
+	b, err := m.db.Get(nextIDKey)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(b) != 8 {
+		return 0, fmt.Errorf("invalid next swap ID in database: 0x%x", b)
+	}
+
+	return binary.BigEndian.Uint64(b), nil
+}
+
+func swapFactoryKey(chainID *big.Int) []byte {
+	return append(append([]byte{}, swapFactoryPrefix...), chainID.String()...)
+}
+
+// WriteSwapFactoryAddress stores the address of the SwapFactory contract we deployed on the chain
+// with the given ID, so that it's reused when swapd restarts.
+func (m *Manager) WriteSwapFactoryAddress(chainID *big.Int, address ethcommon.Address) error {
+	return m.db.Put(swapFactoryKey(chainID), address.Bytes())
+}
+
+// GetSwapFactoryAddress returns the address of the SwapFactory contract stored for the chain with
+// the given ID, or the zero address if none has been stored.
+func (m *Manager) GetSwapFactoryAddress(chainID *big.Int) (ethcommon.Address, error) {
+	has, err := m.db.Has(swapFactoryKey(chainID))
+	if err != nil || !has {
+		return ethcommon.Address{}, err
+	}
+
+	b, err := m.db.Get(swapFactoryKey(chainID))
+	if err != nil {
+		return ethcommon.Address{}, err
+	}
+
+	if len(b) != ethcommon.AddressLength {
+		return ethcommon.Address{}, fmt.Errorf("invalid SwapFactory address in database: 0x%x", b)
+	}
+
+	return ethcommon.BytesToAddress(b), nil
+}
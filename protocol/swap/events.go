@@ -0,0 +1,117 @@
+package swap
+
+import (
+	"sync"
+	"time"
+)
+
+// eventBufferSize is the number of events that can be queued for a subscriber
+// before further events are dropped.
+const eventBufferSize = 64
+
+// Stage represents how far a swap has progressed through the protocol.
+type Stage byte
+
+const (
+	// StageInitiated is the stage of a swap that has started, but where keys haven't been exchanged.
+	StageInitiated Stage = iota
+	// StageKeysExchanged is the stage where both parties have each other's keys and DLEq proofs.
+	StageKeysExchanged
+	// StageETHLocked is the stage where the swap contract has been deployed with the ETH locked in it.
+	StageETHLocked
+	// StageXMRLocked is the stage where the XMR has been locked in the shared account.
+	StageXMRLocked
+	// StageContractReady is the stage where the swap contract has been set to ready.
+	StageContractReady
+	// StageClaimed is the stage where the ETH has been claimed from the contract.
+	StageClaimed
+	// StageRefunded is the stage where the ETH has been refunded from the contract.
+	StageRefunded
+)
+
+// String ...
+func (s Stage) String() string {
+	switch s {
+	case StageInitiated:
+		return "initiated"
+	case StageKeysExchanged:
+		return "keys exchanged"
+	case StageETHLocked:
+		return "ETH locked"
+	case StageXMRLocked:
+		return "XMR locked"
+	case StageContractReady:
+		return "contract ready"
+	case StageClaimed:
+		return "claimed"
+	case StageRefunded:
+		return "refunded"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is emitted whenever a swap moves to a new stage or status.
+type Event struct {
+	ID                   uint64
+	Stage                Stage
+	Status               Status
+	AwaitingConfirmation bool
+	TxHash               string // hash of the transaction that caused the event, if there was one
+	Time                 time.Time
+}
+
+// eventFeed fans out events to subscribers.
+type eventFeed struct {
+	sync.Mutex
+	nextID uint64
+	subs   map[uint64]chan *Event
+}
+
+func newEventFeed() *eventFeed {
+	return &eventFeed{
+		subs: make(map[uint64]chan *Event),
+	}
+}
+
+func (f *eventFeed) subscribe() (<-chan *Event, func()) {
+	f.Lock()
+	defer f.Unlock()
+
+	id := f.nextID
+	f.nextID++
+	ch := make(chan *Event, eventBufferSize)
+	f.subs[id] = ch
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			f.Lock()
+			defer f.Unlock()
+			delete(f.subs, id)
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+func (f *eventFeed) publish(ev *Event) {
+	f.Lock()
+	defer f.Unlock()
+
+	for _, ch := range f.subs {
+		select {
+		case ch <- ev:
+		default:
+			log.Warnf("event subscriber is not keeping up, dropping event: id=%d stage=%s status=%s",
+				ev.ID, ev.Stage, ev.Status)
+		}
+	}
+}
+
+// SubscribeEvents returns a channel on which the Events of every swap are sent, and a function
+// which must be called to unsubscribe once the caller is done.
+func (m *Manager) SubscribeEvents() (<-chan *Event, func()) {
+	return m.events.subscribe()
+}
@@ -1,13 +1,21 @@
 package swap
 
 import (
-	"errors"
 	"sync"
+	"time"
 
 	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	logging "github.com/ipfs/go-log"
 )
 
-var nextID uint64
+var log = logging.Logger("swap")
 
 // Status represents the status of a swap.
 type Status byte
@@ -39,14 +47,42 @@ func (s Status) String() string {
 	}
 }
 
-// Info contains the details of the swap as well as its status.
+// StatusChange records the time at which a swap moved into a status.
+type StatusChange struct {
+	Status Status
+	Time   time.Time
+}
+
+// Info contains the details of the swap as well as its status. It's safe for concurrent use.
 type Info struct {
-	id             uint64 // ID number of the swap (not the swap offer ID!)
-	provides       common.ProvidesCoin
-	providedAmount float64
-	receivedAmount float64
-	exchangeRate   common.ExchangeRate
-	status         Status
+	// guards every field except the confirmation ones, which confirmMu guards
+	mu sync.RWMutex
+
+	id              uint64 // ID number of the swap (not the swap offer ID!)
+	offerID         types.Hash
+	counterparty    peer.ID
+	provides        common.ProvidesCoin
+	providedAmount  common.Amount
+	receivedAmount  common.Amount
+	exchangeRate    common.ExchangeRate
+	contractAddress ethcommon.Address
+	contractSwapID  ethcommon.Hash
+	xmrLockAddress  mcrypto.Address
+	xmrLockTxHash   string
+	sweepAddress    mcrypto.Address
+	sweepTxHashes   []string
+	timeout0        time.Time
+	timeout1        time.Time
+	status          Status
+	statusHistory   []StatusChange
+	stage           Stage
+
+	// set while the swap is waiting for the user to confirm it; see AwaitConfirmation
+	confirmMu sync.Mutex
+	confirmCh chan bool
+
+	// set once the swap is added to a Manager; used to persist updates
+	manager *Manager
 }
 
 // ID returns the swap ID.
@@ -55,42 +91,239 @@ func (i *Info) ID() uint64 {
 		return 0
 	}
 
+	i.mu.RLock()
+	defer i.mu.RUnlock()
 	return i.id
 }
 
+// OfferID returns the ID of the offer this swap was initiated from.
+func (i *Info) OfferID() types.Hash {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.offerID
+}
+
+// Counterparty returns the peer ID of the node we're swapping with.
+func (i *Info) Counterparty() peer.ID {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.counterparty
+}
+
 // Provides returns the coin that was provided for this swap.
 func (i *Info) Provides() common.ProvidesCoin {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
 	return i.provides
 }
 
 // ProvidedAmount returns the amount of coin provided for this swap, in standard units.
-func (i *Info) ProvidedAmount() float64 {
+func (i *Info) ProvidedAmount() common.Amount {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
 	return i.providedAmount
 }
 
 // ReceivedAmount returns the amount of coin received for this swap, in standard units.
-func (i *Info) ReceivedAmount() float64 {
+func (i *Info) ReceivedAmount() common.Amount {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
 	return i.receivedAmount
 }
 
 // ExchangeRate returns the exchange rate for this swap, represented by a ratio of XMR/ETH.
 func (i *Info) ExchangeRate() common.ExchangeRate {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
 	return i.exchangeRate
 }
 
+// ContractAddress returns the address of the swap contract, if it has been deployed.
+func (i *Info) ContractAddress() ethcommon.Address {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.contractAddress
+}
+
+// ContractSwapID returns the ID of the swap within the swap contract, if it has been created.
+func (i *Info) ContractSwapID() ethcommon.Hash {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.contractSwapID
+}
+
+// XMRLockAddress returns the address of the account the XMR is locked in, if it has been locked.
+func (i *Info) XMRLockAddress() mcrypto.Address {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.xmrLockAddress
+}
+
+// XMRLockTxHash returns the hash of the transaction which locked the XMR. It's only known
+// by the party that provided the XMR.
+func (i *Info) XMRLockTxHash() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.xmrLockTxHash
+}
+
+// SweepAddress returns the address the XMR we receive is swept to once it's unlocked, or the
+// empty address if it's left in the swap wallet.
+func (i *Info) SweepAddress() mcrypto.Address {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.sweepAddress
+}
+
+// SweepTxHashes returns the hashes of the transactions which swept the XMR we received to the
+// sweep address, if it has been swept.
+func (i *Info) SweepTxHashes() []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return append([]string(nil), i.sweepTxHashes...)
+}
+
+// Timeout0 returns t0, before which the ETH provider may refund, or the zero time if it isn't known yet.
+func (i *Info) Timeout0() time.Time {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.timeout0
+}
+
+// Timeout1 returns t1, after which the ETH provider may refund, or the zero time if it isn't known yet.
+func (i *Info) Timeout1() time.Time {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.timeout1
+}
+
 // Status returns the swap's status.
 func (i *Info) Status() Status {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
 	return i.status
 }
 
+// Stage returns how far the swap has progressed through the protocol.
+func (i *Info) Stage() Stage {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.stage
+}
+
+// StatusHistory returns every status the swap has been in, along with the time it entered it.
+func (i *Info) StatusHistory() []StatusChange {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return append([]StatusChange(nil), i.statusHistory...)
+}
+
+// StartTime returns the time the swap was created.
+func (i *Info) StartTime() time.Time {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if len(i.statusHistory) == 0 {
+		return time.Time{}
+	}
+
+	return i.statusHistory[0].Time
+}
+
+// EndTime returns the time the swap left the Ongoing status, or the zero time if it's still ongoing.
+func (i *Info) EndTime() time.Time {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if i.status == Ongoing || len(i.statusHistory) == 0 {
+		return time.Time{}
+	}
+
+	return i.statusHistory[len(i.statusHistory)-1].Time
+}
+
+// SetOfferID ...
+func (i *Info) SetOfferID(id types.Hash) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.offerID = id
+	i.persist()
+}
+
+// SetCounterparty ...
+func (i *Info) SetCounterparty(who peer.ID) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.counterparty = who
+	i.persist()
+}
+
 // SetReceivedAmount ...
-func (i *Info) SetReceivedAmount(a float64) {
+func (i *Info) SetReceivedAmount(a common.Amount) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
 	i.receivedAmount = a
+	i.persist()
 }
 
 // SetExchangeRate ...
 func (i *Info) SetExchangeRate(r common.ExchangeRate) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
 	i.exchangeRate = r
+	i.persist()
+}
+
+// SetContractAddress ...
+func (i *Info) SetContractAddress(addr ethcommon.Address) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.contractAddress = addr
+	i.persist()
+}
+
+// SetContractSwapID ...
+func (i *Info) SetContractSwapID(id ethcommon.Hash) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.contractSwapID = id
+	i.persist()
+}
+
+// SetXMRLock sets the address of the account the XMR is locked in, and the hash of the
+// transaction which locked it, if known.
+func (i *Info) SetXMRLock(addr mcrypto.Address, txHash string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.xmrLockAddress = addr
+	i.xmrLockTxHash = txHash
+	i.persist()
+}
+
+// SetSweepAddress sets the address the XMR we receive is swept to; if empty, it's left in the
+// swap wallet.
+func (i *Info) SetSweepAddress(addr mcrypto.Address) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.sweepAddress = addr
+	i.persist()
+}
+
+// SetSweepTxHashes sets the hashes of the transactions which swept the XMR we received.
+func (i *Info) SetSweepTxHashes(txHashes []string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.sweepTxHashes = txHashes
+	i.persist()
+}
+
+// SetTimeouts sets the swap contract's timeouts t0 and t1.
+func (i *Info) SetTimeouts(t0, t1 time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.timeout0 = t0
+	i.timeout1 = t1
+	i.persist()
 }
 
 // SetStatus ...
@@ -99,50 +332,159 @@ func (i *Info) SetStatus(s Status) {
 		return
 	}
 
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := time.Now()
 	i.status = s
+	i.statusHistory = append(i.statusHistory, StatusChange{
+		Status: s,
+		Time:   now,
+	})
+	i.persist()
+	i.publish("", now)
+}
+
+// SetStage sets the swap's stage. txHash is the hash of the transaction which moved the swap
+// into the stage, if there was one.
+func (i *Info) SetStage(s Stage, txHash string) {
+	if i == nil {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.stage = s
+	i.persist()
+	i.publish(txHash, time.Now())
+}
+
+// publish sends an Event with the swap's current stage and status to the Manager's subscribers,
+// if it's been added to one. It must be called with the lock held.
+func (i *Info) publish(txHash string, t time.Time) {
+	if i.manager == nil {
+		return
+	}
+
+	i.manager.events.publish(&Event{
+		ID:                   i.id,
+		Stage:                i.stage,
+		Status:               i.status,
+		AwaitingConfirmation: i.AwaitingConfirmation(),
+		TxHash:               txHash,
+		Time:                 t,
+	})
+}
+
+// persist writes the Info to the Manager's database, if it's been added to one. It must be called
+// with the lock held.
+func (i *Info) persist() {
+	if i.manager == nil {
+		return
+	}
+
+	b, err := i.encode()
+	if err == nil {
+		err = i.manager.db.Put(swapKey(i.id), b)
+	}
+
+	if err != nil {
+		log.Errorf("failed to write swap to database: id=%d err=%s", i.id, err)
+	}
 }
 
 // NewInfo ...
-func NewInfo(provides common.ProvidesCoin, providedAmount, receivedAmount float64,
+func NewInfo(provides common.ProvidesCoin, providedAmount, receivedAmount common.Amount,
 	exchangeRate common.ExchangeRate, status Status) *Info {
-	info := &Info{
-		id:             nextID,
+	return &Info{
 		provides:       provides,
 		providedAmount: providedAmount,
 		receivedAmount: receivedAmount,
 		exchangeRate:   exchangeRate,
 		status:         status,
+		statusHistory: []StatusChange{{
+			Status: status,
+			Time:   time.Now(),
+		}},
 	}
-	nextID++
-	return info
 }
 
 // Manager tracks current and past swaps.
 type Manager struct {
 	sync.RWMutex
-	ongoing *Info
+	db      ethdb.KeyValueStore
+	nextID  uint64
+	ongoing map[uint64]*Info
 	past    map[uint64]*Info
+	events  *eventFeed
 }
 
-// NewManager ...
-func NewManager() *Manager {
-	return &Manager{
-		past: make(map[uint64]*Info),
+// NewManager returns a new *Manager backed by the given database.
+// Any swaps already in the database are loaded as past swaps.
+func NewManager(db ethdb.KeyValueStore) (*Manager, error) {
+	m := &Manager{
+		db:      db,
+		ongoing: make(map[uint64]*Info),
+		past:    make(map[uint64]*Info),
+		events:  newEventFeed(),
+	}
+
+	nextID, err := m.readNextID()
+	if err != nil {
+		return nil, err
 	}
+
+	infos, err := m.readAllSwaps()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range infos {
+		if info.status == Ongoing {
+			log.Warnf("found swap that was interrupted before completing: id=%d", info.id)
+		}
+
+		info.manager = m
+		m.past[info.id] = info
+		if info.id >= nextID {
+			nextID = info.id + 1
+		}
+	}
+
+	m.nextID = nextID
+	return m, nil
 }
 
-// AddSwap adds the given swap *Info to the Manager.
+// AddSwap assigns the given swap *Info an ID and adds it to the Manager.
 func (m *Manager) AddSwap(info *Info) error {
 	m.Lock()
 	defer m.Unlock()
 
+	info.mu.Lock()
+	defer info.mu.Unlock()
+
+	info.id = m.nextID
+	if err := m.writeNextID(m.nextID + 1); err != nil {
+		return err
+	}
+
+	m.nextID++
+
+	b, err := info.encode()
+	if err != nil {
+		return err
+	}
+
+	if err = m.db.Put(swapKey(info.id), b); err != nil {
+		return err
+	}
+
+	info.manager = m
+
 	switch info.status {
 	case Ongoing:
-		if m.ongoing != nil {
-			return errors.New("already have ongoing swap")
-		}
-
-		m.ongoing = info
+		m.ongoing[info.id] = info
 	default:
 		m.past[info.id] = info
 	}
@@ -154,13 +496,7 @@ func (m *Manager) AddSwap(info *Info) error {
 func (m *Manager) GetPastIDs() []uint64 {
 	m.RLock()
 	defer m.RUnlock()
-	ids := make([]uint64, len(m.past))
-	i := 0
-	for id := range m.past {
-		ids[i] = id
-		i++
-	}
-	return ids
+	return getIDs(m.past)
 }
 
 // GetPastSwap returns a swap's *Info given its ID.
@@ -170,19 +506,57 @@ func (m *Manager) GetPastSwap(id uint64) *Info {
 	return m.past[id]
 }
 
-// GetOngoingSwap returns the ongoing swap's *Info, if there is one.
-func (m *Manager) GetOngoingSwap() *Info {
-	return m.ongoing
+// GetOngoingIDs returns the IDs of all ongoing swaps.
+func (m *Manager) GetOngoingIDs() []uint64 {
+	m.RLock()
+	defer m.RUnlock()
+	return getIDs(m.ongoing)
+}
+
+// GetOngoingSwap returns an ongoing swap's *Info given its ID.
+func (m *Manager) GetOngoingSwap(id uint64) *Info {
+	m.RLock()
+	defer m.RUnlock()
+	return m.ongoing[id]
 }
 
-// CompleteOngoingSwap marks the current ongoing swap as completed.
-func (m *Manager) CompleteOngoingSwap() {
+// CompleteOngoingSwap marks the ongoing swap with the given ID as completed.
+func (m *Manager) CompleteOngoingSwap(id uint64) {
 	m.Lock()
 	defer m.Unlock()
-	if m.ongoing == nil {
+	info, has := m.ongoing[id]
+	if !has {
 		return
 	}
 
-	m.past[m.ongoing.id] = m.ongoing
-	m.ongoing = nil
+	if err := m.writeSwap(info); err != nil {
+		log.Errorf("failed to write swap to database: id=%d err=%s", id, err)
+	}
+
+	m.past[id] = info
+	delete(m.ongoing, id)
+}
+
+// ResumeSwap moves the interrupted swap with the given ID, which was loaded as a past swap, back
+// to the ongoing swaps while it's resumed. It's moved back by CompleteOngoingSwap.
+func (m *Manager) ResumeSwap(id uint64) {
+	m.Lock()
+	defer m.Unlock()
+	info, has := m.past[id]
+	if !has {
+		return
+	}
+
+	m.ongoing[id] = info
+	delete(m.past, id)
+}
+
+func getIDs(swaps map[uint64]*Info) []uint64 {
+	ids := make([]uint64, len(swaps))
+	i := 0
+	for id := range swaps {
+		ids[i] = id
+		i++
+	}
+	return ids
 }
@@ -0,0 +1,222 @@
+package swap
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+const testPeerID = "12D3KooWHLUrLnJtUbaGzTSi6azZavKhNgUZTtSiUZ9Uy12v1eZ7"
+
+func TestManager_AddSwap_AssignsIDs(t *testing.T) {
+	m, err := NewManager(memorydb.New())
+	require.NoError(t, err)
+
+	info := NewInfo(common.ProvidesETH, common.MustNewAmount("1"), common.MustNewAmount("10"),
+		common.MustNewExchangeRate("0.1"), Ongoing)
+	err = m.AddSwap(info)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), info.ID())
+
+	info = NewInfo(common.ProvidesXMR, common.MustNewAmount("10"), common.MustNewAmount("1"),
+		common.MustNewExchangeRate("0.1"), Ongoing)
+	err = m.AddSwap(info)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), info.ID())
+}
+
+func TestManager_MultipleOngoingSwaps(t *testing.T) {
+	m, err := NewManager(memorydb.New())
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		err = m.AddSwap(NewInfo(common.ProvidesETH, common.MustNewAmount("1"), common.MustNewAmount("10"),
+			common.MustNewExchangeRate("0.1"), Ongoing))
+		require.NoError(t, err)
+	}
+
+	require.ElementsMatch(t, []uint64{0, 1, 2}, m.GetOngoingIDs())
+	require.Equal(t, uint64(1), m.GetOngoingSwap(1).ID())
+
+	m.GetOngoingSwap(1).SetStatus(Success)
+	m.CompleteOngoingSwap(1)
+	require.Nil(t, m.GetOngoingSwap(1))
+	require.ElementsMatch(t, []uint64{0, 2}, m.GetOngoingIDs())
+	require.ElementsMatch(t, []uint64{1}, m.GetPastIDs())
+	require.Equal(t, Success, m.GetPastSwap(1).Status())
+}
+
+func TestManager_PersistsAcrossRestart(t *testing.T) {
+	db, err := OpenDatabase(t.TempDir())
+	require.NoError(t, err)
+
+	m, err := NewManager(db)
+	require.NoError(t, err)
+
+	who, err := peer.Decode(testPeerID)
+	require.NoError(t, err)
+
+	info := NewInfo(common.ProvidesETH, common.MustNewAmount("1"), common.Amount{}, common.ExchangeRate{}, Ongoing)
+	info.SetOfferID(types.Hash{1, 2, 3})
+	err = m.AddSwap(info)
+	require.NoError(t, err)
+
+	info.SetCounterparty(who)
+	info.SetReceivedAmount(common.MustNewAmount("10"))
+	info.SetExchangeRate(common.MustNewExchangeRate("0.1"))
+	info.SetContractAddress(ethcommon.HexToAddress("0xabcd"))
+	info.SetContractSwapID(ethcommon.HexToHash("0x1234"))
+	t0 := time.Unix(1642175017, 0)
+	t1 := time.Unix(1642261417, 0)
+	info.SetTimeouts(t0, t1)
+	info.SetXMRLock("49oFJna6jrkJYvmupQktXKXmhnktf1aCvUmwp8HJGvY7fdXpLMTVeqmZLWQLkyHXuU9Z8mZ78LordCmp3Nqx5T9GFdEGueB", "0x1234")
+	info.SetStage(StageClaimed, "")
+	info.SetStatus(Success)
+	m.CompleteOngoingSwap(info.ID())
+
+	// the received XMR is swept after the swap completes
+	info.SetSweepAddress("sweep-address")
+	info.SetSweepTxHashes([]string{"0x5678"})
+
+	// the second swap is interrupted before it completes
+	info = NewInfo(common.ProvidesETH, common.MustNewAmount("2"), common.Amount{}, common.ExchangeRate{}, Ongoing)
+	err = m.AddSwap(info)
+	require.NoError(t, err)
+
+	// reopen the manager, as if the daemon restarted
+	m, err = NewManager(db)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uint64{0, 1}, m.GetPastIDs())
+
+	res := m.GetPastSwap(0)
+	require.NotNil(t, res)
+	require.Equal(t, types.Hash{1, 2, 3}, res.OfferID())
+	require.Equal(t, who, res.Counterparty())
+	require.Equal(t, common.ProvidesETH, res.Provides())
+	require.Equal(t, "1", res.ProvidedAmount().String())
+	require.Equal(t, "10", res.ReceivedAmount().String())
+	require.Equal(t, "0.1", res.ExchangeRate().String())
+	require.Equal(t, ethcommon.HexToAddress("0xabcd"), res.ContractAddress())
+	require.Equal(t, ethcommon.HexToHash("0x1234"), res.ContractSwapID())
+	require.True(t, t0.Equal(res.Timeout0()))
+	require.True(t, t1.Equal(res.Timeout1()))
+	require.Equal(t, mcrypto.Address("49oFJna6jrkJYvmupQktXKXmhnktf1aCvUmwp8HJGvY7fdXpLMTVeqmZLWQLkyHXuU9Z8mZ78LordCmp3Nqx5T9GFdEGueB"),
+		res.XMRLockAddress())
+	require.Equal(t, "0x1234", res.XMRLockTxHash())
+	require.Equal(t, mcrypto.Address("sweep-address"), res.SweepAddress())
+	require.Equal(t, []string{"0x5678"}, res.SweepTxHashes())
+	require.Equal(t, StageClaimed, res.Stage())
+	require.Equal(t, Success, res.Status())
+	require.Len(t, res.StatusHistory(), 2)
+	require.False(t, res.EndTime().Before(res.StartTime()))
+	require.Equal(t, Ongoing, m.GetPastSwap(1).Status())
+
+	// IDs must not be reused after a restart
+	info = NewInfo(common.ProvidesETH, common.MustNewAmount("1"), common.Amount{}, common.ExchangeRate{}, Ongoing)
+	err = m.AddSwap(info)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), info.ID())
+
+	require.NoError(t, db.Close())
+}
+
+func TestManager_SwapFactoryAddress(t *testing.T) {
+	m, err := NewManager(memorydb.New())
+	require.NoError(t, err)
+
+	addr, err := m.GetSwapFactoryAddress(big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, ethcommon.Address{}, addr)
+
+	err = m.WriteSwapFactoryAddress(big.NewInt(1), ethcommon.HexToAddress("0xabcd"))
+	require.NoError(t, err)
+
+	addr, err = m.GetSwapFactoryAddress(big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, ethcommon.HexToAddress("0xabcd"), addr)
+
+	// the address is only reused on the same chain
+	addr, err = m.GetSwapFactoryAddress(big.NewInt(1337))
+	require.NoError(t, err)
+	require.Equal(t, ethcommon.Address{}, addr)
+}
+
+func TestManager_SubscribeEvents(t *testing.T) {
+	m, err := NewManager(memorydb.New())
+	require.NoError(t, err)
+
+	events, unsubscribe := m.SubscribeEvents()
+	defer unsubscribe()
+
+	info := NewInfo(common.ProvidesETH, common.MustNewAmount("1"), common.MustNewAmount("10"),
+		common.MustNewExchangeRate("0.1"), Ongoing)
+	err = m.AddSwap(info)
+	require.NoError(t, err)
+
+	info.SetStage(StageETHLocked, "0xabcd")
+	ev := <-events
+	require.Equal(t, info.ID(), ev.ID)
+	require.Equal(t, StageETHLocked, ev.Stage)
+	require.Equal(t, Ongoing, ev.Status)
+	require.Equal(t, "0xabcd", ev.TxHash)
+
+	info.SetStatus(Success)
+	ev = <-events
+	require.Equal(t, StageETHLocked, ev.Stage)
+	require.Equal(t, Success, ev.Status)
+
+	// the stage is persisted along with the rest of the swap
+	m, err = NewManager(m.db)
+	require.NoError(t, err)
+	require.Equal(t, StageETHLocked, m.GetPastSwap(info.ID()).Stage())
+}
+
+// TestInfo_Concurrent updates a swap while it's read and persisted; run with -race.
+func TestInfo_Concurrent(t *testing.T) {
+	db := memorydb.New()
+	m, err := NewManager(db)
+	require.NoError(t, err)
+
+	info := NewInfo(common.ProvidesETH, common.MustNewAmount("1"), common.MustNewAmount("10"),
+		common.MustNewExchangeRate("0.1"), Ongoing)
+	require.NoError(t, m.AddSwap(info))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			info.SetStage(StageETHLocked, "")
+			info.SetSweepTxHashes([]string{"0xabcd"})
+			info.SetStatus(Ongoing)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = info.Stage()
+			_ = info.StatusHistory()
+			_, err := json.Marshal(info) //nolint:govet
+			require.NoError(t, err)
+		}
+	}()
+
+	wg.Wait()
+	m.CompleteOngoingSwap(info.ID())
+
+	m, err = NewManager(db)
+	require.NoError(t, err)
+	require.Len(t, m.GetPastSwap(info.ID()).StatusHistory(), len(info.StatusHistory()))
+}
@@ -0,0 +1,352 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	defaultStuckTimeout   = time.Minute * 3
+	defaultTxPollInterval = time.Second * 10
+
+	// nodes only accept a replacement transaction if its fees are at least 10% higher than
+	// the original's; we bump them by 12.5%.
+	feeBumpDivisor    = 8
+	minFeeBumpPercent = 110
+)
+
+// ErrTxDeadline is returned when a transaction isn't included in the chain before its deadline.
+var ErrTxDeadline = errors.New("transaction wasn't included before its deadline")
+
+// SendTxFunc signs and sends a transaction with the given options, eg. by calling a method of
+// a contract binding.
+type SendTxFunc func(opts *bind.TransactOpts) (*ethtypes.Transaction, error)
+
+// TxManagerClient is the subset of an ethereum client used by a TxManager.
+type TxManagerClient interface {
+	FeeClient
+	PendingNonceAt(ctx context.Context, account ethcommon.Address) (uint64, error)
+	NonceAt(ctx context.Context, account ethcommon.Address, blockNumber *big.Int) (uint64, error)
+	TransactionReceipt(ctx context.Context, txHash ethcommon.Hash) (*ethtypes.Receipt, error)
+}
+
+// TxManagerConfig contains the configuration values for a new TxManager.
+type TxManagerConfig struct {
+	Client    TxManagerClient
+	FeeOracle FeeOracle         // defaults to the default FeeOracle for Client if nil
+	From      ethcommon.Address // the account transactions are sent from
+
+	// Signer is the account's signer; if it's a ContextSigner, transactions for ChainID are signed
+	// with the context they're sent with, so that signing them is abandoned once it's done. Otherwise,
+	// or if it's nil, they're signed by the Signer of the options they're sent with.
+	Signer  Signer
+	ChainID *big.Int
+
+	// caps on the fees replacement transactions are sent with; there's no cap if nil.
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+
+	// StuckTimeout is how long a transaction can be pending before it's replaced with higher
+	// fees; it defaults to 3 minutes if zero.
+	StuckTimeout time.Duration
+
+	// PollInterval is how often to check whether a transaction was included; it defaults to
+	// 10 seconds if zero.
+	PollInterval time.Duration
+}
+
+// TxManager sends the transactions of an account, and waits for them to be included in the
+// chain. It assigns nonces to the transactions itself, so that concurrent swaps don't send
+// transactions with the same nonce, and replaces transactions which are stuck with ones
+// paying higher fees.
+type TxManager struct {
+	client               TxManagerClient
+	feeOracle            FeeOracle
+	from                 ethcommon.Address
+	signer               Signer
+	chainID              *big.Int
+	maxFeePerGas         *big.Int
+	maxPriorityFeePerGas *big.Int
+	stuckTimeout         time.Duration
+	pollInterval         time.Duration
+
+	// nonce of the next transaction to send; it's fetched from the node if unknown
+	nonceMu    sync.Mutex
+	nonce      uint64
+	nonceKnown bool
+}
+
+// NewTxManager returns a new *TxManager
+func NewTxManager(cfg *TxManagerConfig) *TxManager {
+	feeOracle := cfg.FeeOracle
+	if feeOracle == nil {
+		feeOracle = NewFeeOracle(&FeeOracleConfig{
+			Client:               cfg.Client,
+			MaxFeePerGas:         cfg.MaxFeePerGas,
+			MaxPriorityFeePerGas: cfg.MaxPriorityFeePerGas,
+		})
+	}
+
+	stuckTimeout := cfg.StuckTimeout
+	if stuckTimeout == 0 {
+		stuckTimeout = defaultStuckTimeout
+	}
+
+	pollInterval := cfg.PollInterval
+	if pollInterval == 0 {
+		pollInterval = defaultTxPollInterval
+	}
+
+	return &TxManager{
+		client:               cfg.Client,
+		feeOracle:            feeOracle,
+		from:                 cfg.From,
+		signer:               cfg.Signer,
+		chainID:              cfg.ChainID,
+		maxFeePerGas:         cfg.MaxFeePerGas,
+		maxPriorityFeePerGas: cfg.MaxPriorityFeePerGas,
+		stuckTimeout:         stuckTimeout,
+		pollInterval:         pollInterval,
+	}
+}
+
+// Send sends the transaction built by send, with the fees suggested for the given urgency, and
+// returns its receipt once it's included in the chain. If it's still pending after the stuck
+// timeout, it's replaced by the same transaction with higher fees, until one of them is
+// included. If the deadline is set and passes first, it returns ErrTxDeadline.
+// The returned receipt is for whichever transaction was included, which might not be the first
+// one sent. It returns an error if the transaction failed.
+func (m *TxManager) Send(ctx context.Context, opts *bind.TransactOpts, urgency Urgency, deadline time.Time,
+	send SendTxFunc) (*ethtypes.Receipt, error) {
+	txOpts := *opts
+	txOpts.Context = ctx
+	if s, ok := m.signer.(ContextSigner); ok && m.chainID != nil && opts.From == s.Address() {
+		// there's no point signing a transaction after its deadline
+		signCtx := ctx
+		if !deadline.IsZero() {
+			var cancel context.CancelFunc
+			signCtx, cancel = context.WithDeadline(ctx, deadline)
+			defer cancel()
+		}
+
+		txOpts.Signer = s.SignerFn(signCtx, m.chainID)
+	}
+
+	if err := SetFees(ctx, m.feeOracle, &txOpts, urgency); err != nil {
+		return nil, fmt.Errorf("failed to get transaction fees: %w", err)
+	}
+
+	tx, err := m.sendNew(ctx, &txOpts, send)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("sent transaction: txHash=%s nonce=%d urgency=%s", tx.Hash(), tx.Nonce(), urgency)
+
+	// every transaction we've sent with this nonce; any of them might be included
+	sent := []*ethtypes.Transaction{tx}
+	sentAt := time.Now()
+	for {
+		receipt, err := m.findReceipt(ctx, sent)
+		if err != nil {
+			return nil, err
+		}
+
+		if receipt != nil {
+			if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+				return receipt, fmt.Errorf("transaction %s failed", receipt.TxHash)
+			}
+
+			log.Debugf("transaction included in chain: txHash=%s block=%d", receipt.TxHash, receipt.BlockNumber)
+			return receipt, nil
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: txHash=%s", ErrTxDeadline, sent[len(sent)-1].Hash())
+		}
+
+		if time.Since(sentAt) >= m.stuckTimeout {
+			sentAt = time.Now()
+			replacement, err := m.replace(ctx, &txOpts, sent[len(sent)-1], send)
+			if err != nil {
+				return nil, err
+			}
+
+			if replacement != nil {
+				sent = append(sent, replacement)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(m.pollInterval):
+		}
+	}
+}
+
+// sendNew sends a transaction with the next nonce.
+func (m *TxManager) sendNew(ctx context.Context, opts *bind.TransactOpts, send SendTxFunc) (*ethtypes.Transaction,
+	error) {
+	m.nonceMu.Lock()
+	defer m.nonceMu.Unlock()
+
+	if !m.nonceKnown {
+		nonce, err := m.client.PendingNonceAt(ctx, m.from)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account nonce: %w", err)
+		}
+
+		m.nonce, m.nonceKnown = nonce, true
+	}
+
+	opts.Nonce = new(big.Int).SetUint64(m.nonce)
+	tx, err := send(opts)
+	if err != nil {
+		// we don't know whether the nonce was used, eg. by a transaction sent from the same
+		// account by someone else, so it's fetched again for the next transaction
+		m.nonceKnown = false
+		return nil, err
+	}
+
+	m.nonce++
+	return tx, nil
+}
+
+// findReceipt returns the receipt of whichever of the given transactions, which all have the same
+// nonce, was included in the chain, or nil if none of them have been yet.
+func (m *TxManager) findReceipt(ctx context.Context, sent []*ethtypes.Transaction) (*ethtypes.Receipt, error) {
+	// check whether the nonce was used before looking for our transactions, so that if it was,
+	// but by none of them, we know they'll never be included.
+	mined, err := m.client.NonceAt(ctx, m.from, nil)
+	if err != nil {
+		log.Warnf("failed to get account nonce: err=%s", err)
+		return nil, nil
+	}
+
+	for _, tx := range sent {
+		receipt, err := m.client.TransactionReceipt(ctx, tx.Hash())
+		if errors.Is(err, eth.NotFound) {
+			continue
+		}
+
+		if err != nil {
+			log.Warnf("failed to get transaction receipt: txHash=%s err=%s", tx.Hash(), err)
+			return nil, nil
+		}
+
+		return receipt, nil
+	}
+
+	if nonce := sent[0].Nonce(); mined > nonce {
+		return nil, fmt.Errorf("nonce %d was used by another transaction than %s", nonce, sent[0].Hash())
+	}
+
+	log.Infof("waiting for transaction to be included in chain: txHash=%s", sent[len(sent)-1].Hash())
+	return nil, nil
+}
+
+// replace sends a replacement for the given stuck transaction, with the same nonce and higher fees.
+// It returns nil if no replacement could be sent, in which case we keep waiting for the transactions
+// already sent.
+func (m *TxManager) replace(ctx context.Context, opts *bind.TransactOpts, stuck *ethtypes.Transaction,
+	send SendTxFunc) (*ethtypes.Transaction, error) {
+	txOpts := *opts
+	txOpts.Nonce = new(big.Int).SetUint64(stuck.Nonce())
+	txOpts.GasLimit = stuck.Gas()
+
+	ok, err := m.bumpFees(ctx, &txOpts, stuck)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		log.Warnf("transaction is stuck, but its fees can't be raised past the configured maximum: txHash=%s",
+			stuck.Hash())
+		return nil, nil
+	}
+
+	tx, err := send(&txOpts)
+	if err != nil {
+		// the stuck transaction might have been included since we last checked
+		if strings.Contains(err.Error(), "nonce too low") {
+			log.Debugf("stuck transaction's nonce was used, not replacing it: txHash=%s", stuck.Hash())
+			return nil, nil
+		}
+
+		log.Warnf("failed to replace stuck transaction: txHash=%s err=%s", stuck.Hash(), err)
+		return nil, nil
+	}
+
+	log.Infof("replaced stuck transaction with higher fees: txHash=%s replacement=%s", stuck.Hash(), tx.Hash())
+	return tx, nil
+}
+
+// bumpFees sets the fees of the given options to the higher of the currently suggested fees for
+// urgent transactions, and the given stuck transaction's fees bumped by 12.5%, capped by the
+// configured maximum fees. It returns false if the capped fees aren't high enough for the
+// replacement to be accepted.
+func (m *TxManager) bumpFees(ctx context.Context, opts *bind.TransactOpts, stuck *ethtypes.Transaction) (bool,
+	error) {
+	fees, err := m.feeOracle.SuggestFees(ctx, UrgencyHigh)
+	if err != nil {
+		return false, fmt.Errorf("failed to get transaction fees: %w", err)
+	}
+
+	if stuck.Type() == ethtypes.LegacyTxType {
+		suggested := fees.GasPrice
+		if suggested == nil {
+			suggested = fees.GasFeeCap
+		}
+
+		opts.GasFeeCap, opts.GasTipCap = nil, nil
+		opts.GasPrice = capFee(maxFee(bumpFee(stuck.GasPrice()), suggested), m.maxFeePerGas)
+		return isBumped(opts.GasPrice, stuck.GasPrice()), nil
+	}
+
+	suggestedFeeCap, suggestedTip := fees.GasFeeCap, fees.GasTipCap
+	if suggestedFeeCap == nil {
+		suggestedFeeCap, suggestedTip = fees.GasPrice, fees.GasPrice
+	}
+
+	opts.GasPrice = nil
+	opts.GasTipCap = capFee(maxFee(bumpFee(stuck.GasTipCap()), suggestedTip), m.maxPriorityFeePerGas)
+	opts.GasFeeCap = capFee(maxFee(bumpFee(stuck.GasFeeCap()), suggestedFeeCap), m.maxFeePerGas)
+	if opts.GasTipCap.Cmp(opts.GasFeeCap) > 0 {
+		opts.GasTipCap = opts.GasFeeCap
+	}
+
+	return isBumped(opts.GasTipCap, stuck.GasTipCap()) && isBumped(opts.GasFeeCap, stuck.GasFeeCap()), nil
+}
+
+// bumpFee returns the given fee plus 12.5%, rounded up.
+func bumpFee(fee *big.Int) *big.Int {
+	bump := new(big.Int).Add(fee, big.NewInt(feeBumpDivisor-1))
+	bump.Div(bump, big.NewInt(feeBumpDivisor))
+	return bump.Add(bump, fee)
+}
+
+// isBumped returns whether the given fee is enough higher than the old one for a replacement.
+func isBumped(fee, old *big.Int) bool {
+	scaled := new(big.Int).Mul(fee, big.NewInt(100))
+	min := new(big.Int).Mul(old, big.NewInt(minFeeBumpPercent))
+	return scaled.Cmp(min) >= 0 && fee.Cmp(old) > 0
+}
+
+func maxFee(a, b *big.Int) *big.Int {
+	if b != nil && b.Cmp(a) > 0 {
+		return b
+	}
+
+	return a
+}
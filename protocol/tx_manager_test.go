@@ -0,0 +1,206 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTxClient is a TxManagerClient which includes the transactions passed to mine.
+type mockTxClient struct {
+	mockFeeClient
+	sync.Mutex
+	nonce    uint64
+	receipts map[ethcommon.Hash]*ethtypes.Receipt
+}
+
+func newMockTxClient(nonce uint64) *mockTxClient {
+	return &mockTxClient{
+		mockFeeClient: mockFeeClient{
+			baseFee: big.NewInt(100),
+			tip:     big.NewInt(10),
+		},
+		nonce:    nonce,
+		receipts: make(map[ethcommon.Hash]*ethtypes.Receipt),
+	}
+}
+
+func (c *mockTxClient) PendingNonceAt(_ context.Context, _ ethcommon.Address) (uint64, error) {
+	c.Lock()
+	defer c.Unlock()
+	return c.nonce, nil
+}
+
+func (c *mockTxClient) NonceAt(_ context.Context, _ ethcommon.Address, _ *big.Int) (uint64, error) {
+	c.Lock()
+	defer c.Unlock()
+	return c.nonce, nil
+}
+
+func (c *mockTxClient) TransactionReceipt(_ context.Context, txHash ethcommon.Hash) (*ethtypes.Receipt, error) {
+	c.Lock()
+	defer c.Unlock()
+	receipt, has := c.receipts[txHash]
+	if !has {
+		return nil, eth.NotFound
+	}
+
+	return receipt, nil
+}
+
+func (c *mockTxClient) mine(tx *ethtypes.Transaction) {
+	c.Lock()
+	defer c.Unlock()
+	c.nonce = tx.Nonce() + 1
+	c.receipts[tx.Hash()] = &ethtypes.Receipt{
+		Status:      ethtypes.ReceiptStatusSuccessful,
+		TxHash:      tx.Hash(),
+		BlockNumber: big.NewInt(1),
+	}
+}
+
+// mockSender returns a SendTxFunc which sends a transaction with the given options, and passes
+// it to onSend.
+func mockSender(onSend func(tx *ethtypes.Transaction)) SendTxFunc {
+	return func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+		gas := opts.GasLimit
+		if gas == 0 {
+			gas = 21000
+		}
+
+		tx := ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			Nonce:     opts.Nonce.Uint64(),
+			GasFeeCap: opts.GasFeeCap,
+			GasTipCap: opts.GasTipCap,
+			Gas:       gas,
+		})
+		onSend(tx)
+		return tx, nil
+	}
+}
+
+func TestTxManager_Send(t *testing.T) {
+	client := newMockTxClient(5)
+	m := NewTxManager(&TxManagerConfig{
+		Client:       client,
+		PollInterval: time.Millisecond * 10,
+	})
+
+	var sent []*ethtypes.Transaction
+	send := mockSender(func(tx *ethtypes.Transaction) {
+		sent = append(sent, tx)
+		client.mine(tx)
+	})
+
+	receipt, err := m.Send(context.Background(), &bind.TransactOpts{}, UrgencyNormal, time.Time{}, send)
+	require.NoError(t, err)
+	require.Equal(t, sent[0].Hash(), receipt.TxHash)
+	require.Equal(t, uint64(5), sent[0].Nonce())
+	require.Equal(t, big.NewInt(12), sent[0].GasTipCap())
+
+	_, err = m.Send(context.Background(), &bind.TransactOpts{}, UrgencyNormal, time.Time{}, send)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), sent[1].Nonce())
+}
+
+func TestTxManager_Send_Replace(t *testing.T) {
+	client := newMockTxClient(0)
+	m := NewTxManager(&TxManagerConfig{
+		Client:       client,
+		StuckTimeout: time.Millisecond * 50,
+		PollInterval: time.Millisecond * 10,
+	})
+
+	// only the replacement is included
+	var sent []*ethtypes.Transaction
+	send := mockSender(func(tx *ethtypes.Transaction) {
+		sent = append(sent, tx)
+		if len(sent) == 2 {
+			client.mine(tx)
+		}
+	})
+
+	receipt, err := m.Send(context.Background(), &bind.TransactOpts{}, UrgencyLow, time.Time{}, send)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(sent))
+	require.Equal(t, sent[1].Hash(), receipt.TxHash)
+	require.Equal(t, sent[0].Nonce(), sent[1].Nonce())
+	require.Equal(t, sent[0].Gas(), sent[1].Gas())
+
+	// fees are bumped to those suggested for urgent transactions
+	require.Equal(t, big.NewInt(10), sent[0].GasTipCap())
+	require.Equal(t, big.NewInt(210), sent[0].GasFeeCap())
+	require.Equal(t, big.NewInt(20), sent[1].GasTipCap())
+	require.Equal(t, big.NewInt(320), sent[1].GasFeeCap())
+}
+
+func TestTxManager_Send_Deadline(t *testing.T) {
+	client := newMockTxClient(0)
+	m := NewTxManager(&TxManagerConfig{
+		Client:       client,
+		PollInterval: time.Millisecond * 10,
+	})
+
+	send := mockSender(func(_ *ethtypes.Transaction) {})
+	deadline := time.Now().Add(time.Millisecond * 50)
+	_, err := m.Send(context.Background(), &bind.TransactOpts{}, UrgencyNormal, deadline, send)
+	require.True(t, errors.Is(err, ErrTxDeadline))
+}
+
+func TestTxManager_Send_ContextSigner(t *testing.T) {
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer, err := NewExternalSigner(context.Background(), newMockClef(t, &mockClef{keys: []*ecdsa.PrivateKey{pk}}),
+		ethcommon.Address{})
+	require.NoError(t, err)
+
+	chainID := big.NewInt(1337)
+	m := NewTxManager(&TxManagerConfig{
+		Client:       newMockTxClient(0),
+		From:         signer.Address(),
+		Signer:       signer,
+		ChainID:      chainID,
+		PollInterval: time.Millisecond * 10,
+	})
+
+	send := func(opts *bind.TransactOpts) (*ethtypes.Transaction, error) {
+		tx := ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     opts.Nonce.Uint64(),
+			GasFeeCap: opts.GasFeeCap,
+			GasTipCap: opts.GasTipCap,
+			Gas:       21000,
+		})
+		return opts.Signer(opts.From, tx)
+	}
+
+	// the transaction is signed with the context it's sent with, rather than the options' one
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = m.Send(ctx, signer.TransactOpts(chainID), UrgencyNormal, time.Time{}, send)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Contains(t, err.Error(), "external signer failed to sign transaction")
+
+	// or not at all once its deadline has passed
+	_, err = m.Send(context.Background(), signer.TransactOpts(chainID), UrgencyNormal, time.Now(), send)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBumpFee(t *testing.T) {
+	require.Equal(t, big.NewInt(2), bumpFee(big.NewInt(1)))
+	require.Equal(t, big.NewInt(113), bumpFee(big.NewInt(100)))
+	require.True(t, isBumped(big.NewInt(110), big.NewInt(100)))
+	require.False(t, isBumped(big.NewInt(109), big.NewInt(100)))
+	require.False(t, isBumped(big.NewInt(11), big.NewInt(11)))
+}
@@ -62,12 +62,13 @@ func (r *recoverer) WalletFromSecrets(aliceSecret, bobSecret string) (mcrypto.Ad
 		return "", err
 	}
 
-	return monero.CreateMoneroWallet("recovered-wallet", r.env, r.client, kp)
+	addr, _, err := monero.CreateMoneroWallet("recovered-wallet", r.env, r.client, kp)
+	return addr, err
 }
 
 // RecoverFromBobSecretAndContract recovers funds by either claiming ether or reclaiming locked monero.
 func (r *recoverer) RecoverFromBobSecretAndContract(b *bob.Instance,
-	bobSecret, contractAddr string) (*bob.RecoveryResult, error) {
+	bobSecret, contractAddr, swapID string) (*bob.RecoveryResult, error) {
 	bs, err := hex.DecodeString(bobSecret)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode Bob's secret: %w", err)
@@ -79,7 +80,7 @@ func (r *recoverer) RecoverFromBobSecretAndContract(b *bob.Instance,
 	}
 
 	addr := ethcommon.HexToAddress(contractAddr)
-	rs, err := bob.NewRecoveryState(b, bk, addr)
+	rs, err := bob.NewRecoveryState(b, bk, addr, ethcommon.HexToHash(swapID))
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +90,7 @@ func (r *recoverer) RecoverFromBobSecretAndContract(b *bob.Instance,
 
 // RecoverFromAliceSecretAndContract recovers funds by either claiming locked monero or refunding ether.
 func (r *recoverer) RecoverFromAliceSecretAndContract(a *alice.Instance,
-	aliceSecret, contractAddr string) (*alice.RecoveryResult, error) {
+	aliceSecret, contractAddr, swapID string) (*alice.RecoveryResult, error) {
 	as, err := hex.DecodeString(aliceSecret)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode Alice's secret: %w", err)
@@ -101,7 +102,7 @@ func (r *recoverer) RecoverFromAliceSecretAndContract(a *alice.Instance,
 	}
 
 	addr := ethcommon.HexToAddress(contractAddr)
-	rs, err := alice.NewRecoveryState(a, ak, addr)
+	rs, err := alice.NewRecoveryState(a, ak, addr, ethcommon.HexToHash(swapID))
 	if err != nil {
 		return nil, err
 	}
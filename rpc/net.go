@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -8,7 +9,9 @@ import (
 	"github.com/noot/atomic-swap/common"
 	"github.com/noot/atomic-swap/common/types"
 	"github.com/noot/atomic-swap/net"
+	pcommon "github.com/noot/atomic-swap/protocol"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p-core/peer"
 )
 
@@ -122,9 +125,9 @@ func (s *NetService) QueryPeer(_ *http.Request, req *QueryPeerRequest, resp *Que
 
 // TakeOfferRequest ...
 type TakeOfferRequest struct {
-	Multiaddr      string  `json:"multiaddr"`
-	OfferID        string  `json:"offerID"`
-	ProvidesAmount float64 `json:"providesAmount"`
+	Multiaddr      string        `json:"multiaddr"`
+	OfferID        string        `json:"offerID"`
+	ProvidesAmount common.Amount `json:"providesAmount"`
 }
 
 // TakeOfferResponse ...
@@ -134,24 +137,52 @@ type TakeOfferResponse struct {
 
 // TakeOffer initiates a swap with the given peer by taking an offer they've made.
 func (s *NetService) TakeOffer(_ *http.Request, req *TakeOfferRequest, resp *TakeOfferResponse) error {
-	swapState, err := s.alice.InitiateProtocol(req.ProvidesAmount)
+	who, err := net.StringToAddrInfo(req.Multiaddr)
 	if err != nil {
 		return err
 	}
 
-	skm, err := swapState.SendKeysMessage()
+	offerID, err := types.HexToHash(req.OfferID)
 	if err != nil {
 		return err
 	}
 
-	skm.OfferID = req.OfferID
-	skm.ProvidedAmount = req.ProvidesAmount
+	// get the offer from the peer, so that we know the terms we're agreeing to
+	queryResp, err := s.net.Query(who)
+	if err != nil {
+		return err
+	}
 
-	who, err := net.StringToAddrInfo(req.Multiaddr)
+	var offer *types.Offer
+	for _, o := range queryResp.Offers {
+		if o.GetID() == offerID {
+			offer = o
+			break
+		}
+	}
+
+	if offer == nil {
+		return errors.New("peer does not have an offer with the given ID")
+	}
+
+	taker, err := s.taker(offer.Provides)
 	if err != nil {
 		return err
 	}
 
+	swapState, err := taker.InitiateProtocol(req.ProvidesAmount, offer, who.ID)
+	if err != nil {
+		return err
+	}
+
+	skm, err := swapState.SendKeysMessage()
+	if err != nil {
+		return err
+	}
+
+	skm.OfferID = req.OfferID
+	skm.ProvidedAmount = req.ProvidesAmount
+
 	if err = s.net.Initiate(who, skm, swapState); err != nil {
 		return err
 	}
@@ -160,11 +191,52 @@ func (s *NetService) TakeOffer(_ *http.Request, req *TakeOfferRequest, resp *Tak
 	return nil
 }
 
+// taker returns the protocol handler which takes offers providing the given coin,
+// ie. the one which provides the other coin.
+func (s *NetService) taker(offerProvides common.ProvidesCoin) (Taker, error) {
+	switch {
+	case offerProvides == common.ProvidesXMR:
+		return s.alice, nil
+	case offerProvides.IsEthereumAsset():
+		return s.bob, nil
+	default:
+		return nil, fmt.Errorf("cannot take offer providing %q", offerProvides)
+	}
+}
+
+// maker returns the protocol handler which makes offers providing the given coin.
+func (s *NetService) maker(provides common.ProvidesCoin) (Maker, error) {
+	switch {
+	case provides == common.ProvidesXMR:
+		return s.bob, nil
+	case provides.IsEthereumAsset():
+		return s.alice, nil
+	default:
+		return nil, fmt.Errorf("cannot make offer providing %q", provides)
+	}
+}
+
+// offerMaker returns the offer with the given ID, and the protocol handler which made it.
+func (s *NetService) offerMaker(id types.Hash) (Maker, *types.Offer, error) {
+	for _, m := range []Maker{s.alice, s.bob} {
+		for _, o := range m.GetOffers() {
+			if o.GetID() == id {
+				return m, o, nil
+			}
+		}
+	}
+
+	return nil, nil, pcommon.ErrOfferNotFound
+}
+
 // MakeOfferRequest ...
 type MakeOfferRequest struct {
-	MinimumAmount float64             `json:"minimumAmount"`
-	MaximumAmount float64             `json:"maximumAmount"`
+	Provides      common.ProvidesCoin `json:"provides,omitempty"` // defaults to XMR
+	MinimumAmount common.Amount       `json:"minimumAmount"`
+	MaximumAmount common.Amount       `json:"maximumAmount"`
 	ExchangeRate  common.ExchangeRate `json:"exchangeRate"`
+	ExpiresIn     uint64              `json:"expiresIn,omitempty"` // in seconds; zero means the offer doesn't expire
+	Token         ethcommon.Address   `json:"token,omitempty"`     // ERC-20 token to swap for XMR instead of ether
 }
 
 // MakeOfferResponse ...
@@ -172,16 +244,62 @@ type MakeOfferResponse struct {
 	ID string `json:"offerID"`
 }
 
+// newOffer validates the given terms and returns an offer providing the given coin with them.
+// The token is the ERC-20 token swapped for XMR, or the zero address for ether; offers providing
+// an ERC-20 token always use that token.
+func newOffer(provides common.ProvidesCoin, token ethcommon.Address, min, max common.Amount,
+	exchangeRate common.ExchangeRate, expiresIn uint64) (*types.Offer, error) {
+	if providedToken, ok := provides.ERC20Token(); ok {
+		if token != (ethcommon.Address{}) && token != providedToken {
+			return nil, fmt.Errorf("offer provides token %s, and cannot swap token %s", providedToken, token)
+		}
+
+		token = providedToken
+	} else if provides == common.ProvidesETH && token != (ethcommon.Address{}) {
+		return nil, errors.New("offer provides ETH, and cannot swap a token")
+	}
+
+	if min.IsZero() || max.Cmp(min) < 0 {
+		return nil, errors.New("offer amounts must be non-zero, and the maximum at least the minimum")
+	}
+
+	if exchangeRate.IsZero() {
+		return nil, errors.New("exchange rate must be greater than zero")
+	}
+
+	o := &types.Offer{
+		Provides:      provides,
+		MinimumAmount: min,
+		MaximumAmount: max,
+		ExchangeRate:  exchangeRate,
+		Token:         token,
+	}
+
+	if expiresIn != 0 {
+		o.Expiry = time.Now().Add(time.Duration(expiresIn) * time.Second).Unix()
+	}
+
+	return o, nil
+}
+
 // MakeOffer creates and advertises a new swap offer.
 func (s *NetService) MakeOffer(_ *http.Request, req *MakeOfferRequest, resp *MakeOfferResponse) error {
-	o := &types.Offer{
-		Provides:      common.ProvidesXMR,
-		MinimumAmount: req.MinimumAmount,
-		MaximumAmount: req.MaximumAmount,
-		ExchangeRate:  req.ExchangeRate,
+	provides := req.Provides
+	if provides == "" {
+		provides = common.ProvidesXMR
+	}
+
+	maker, err := s.maker(provides)
+	if err != nil {
+		return err
+	}
+
+	o, err := newOffer(provides, req.Token, req.MinimumAmount, req.MaximumAmount, req.ExchangeRate, req.ExpiresIn)
+	if err != nil {
+		return err
 	}
 
-	if err := s.bob.MakeOffer(o); err != nil {
+	if err = maker.MakeOffer(o); err != nil {
 		return err
 	}
 
@@ -191,6 +309,74 @@ func (s *NetService) MakeOffer(_ *http.Request, req *MakeOfferRequest, resp *Mak
 	return nil
 }
 
+// UpdateOfferRequest ...
+type UpdateOfferRequest struct {
+	OfferID string `json:"offerID"`
+	MakeOfferRequest
+}
+
+// UpdateOffer replaces the terms of one of our offers, and re-advertises our offers.
+// The offer keeps its ID.
+func (s *NetService) UpdateOffer(_ *http.Request, req *UpdateOfferRequest, _ *interface{}) error {
+	id, err := types.HexToHash(req.OfferID)
+	if err != nil {
+		return err
+	}
+
+	maker, existing, err := s.offerMaker(id)
+	if err != nil {
+		return err
+	}
+
+	// an offer can't change which coins it swaps
+	if req.Provides != "" && req.Provides != existing.Provides {
+		return fmt.Errorf("offer provides %s, and cannot be changed to provide %s", existing.Provides, req.Provides)
+	}
+
+	if req.Token != (ethcommon.Address{}) && req.Token != existing.Token {
+		return fmt.Errorf("offer swaps token %s, and cannot be changed to swap token %s", existing.Token, req.Token)
+	}
+
+	o, err := newOffer(existing.Provides, existing.Token, req.MinimumAmount, req.MaximumAmount, req.ExchangeRate,
+		req.ExpiresIn)
+	if err != nil {
+		return err
+	}
+
+	o.ID = id
+	if err = maker.UpdateOffer(o); err != nil {
+		return err
+	}
+
+	s.net.Advertise()
+	return nil
+}
+
+// CancelOfferRequest ...
+type CancelOfferRequest struct {
+	OfferID string `json:"offerID"`
+}
+
+// CancelOffer removes one of our offers, and re-advertises our offers.
+func (s *NetService) CancelOffer(_ *http.Request, req *CancelOfferRequest, _ *interface{}) error {
+	id, err := types.HexToHash(req.OfferID)
+	if err != nil {
+		return err
+	}
+
+	maker, _, err := s.offerMaker(id)
+	if err != nil {
+		return err
+	}
+
+	if err = maker.CancelOffer(id); err != nil {
+		return err
+	}
+
+	s.net.Advertise()
+	return nil
+}
+
 // SetGasPriceRequest ...
 type SetGasPriceRequest struct {
 	GasPrice uint64
@@ -0,0 +1,233 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+	"github.com/noot/atomic-swap/net"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSwapState struct {
+	net.SwapState
+}
+
+func (s *mockSwapState) SendKeysMessage() (*net.SendKeysMessage, error) {
+	return &net.SendKeysMessage{}, nil
+}
+
+func (s *mockSwapState) ID() uint64 {
+	return 0
+}
+
+type mockProtocol struct {
+	provides common.ProvidesCoin
+	offers   map[types.Hash]*types.Offer
+	taken    *types.Offer
+}
+
+func newMockProtocol(provides common.ProvidesCoin) *mockProtocol {
+	return &mockProtocol{
+		provides: provides,
+		offers:   make(map[types.Hash]*types.Offer),
+	}
+}
+
+func (p *mockProtocol) Provides() common.ProvidesCoin {
+	return p.provides
+}
+
+func (p *mockProtocol) SetGasPrice(_ uint64) {}
+
+func (p *mockProtocol) ValidateSweepAddress(address mcrypto.Address) error {
+	if address == "invalid" {
+		return errors.New("invalid address")
+	}
+
+	return nil
+}
+
+func (p *mockProtocol) MakeOffer(o *types.Offer) error {
+	p.offers[o.GetID()] = o
+	return nil
+}
+
+func (p *mockProtocol) UpdateOffer(o *types.Offer) error {
+	p.offers[o.GetID()] = o
+	return nil
+}
+
+func (p *mockProtocol) CancelOffer(id types.Hash) error {
+	delete(p.offers, id)
+	return nil
+}
+
+func (p *mockProtocol) GetOffers() []*types.Offer {
+	var offers []*types.Offer
+	for _, o := range p.offers {
+		offers = append(offers, o)
+	}
+	return offers
+}
+
+func (p *mockProtocol) InitiateProtocol(_ common.Amount, offer *types.Offer, _ peer.ID) (net.SwapState, error) {
+	p.taken = offer
+	return &mockSwapState{}, nil
+}
+
+func (p *mockProtocol) SetMoneroWalletFile(_, _ string) error {
+	return nil
+}
+
+func TestNetService_Offers(t *testing.T) {
+	alice := newMockProtocol(common.ProvidesETH)
+	bob := newMockProtocol(common.ProvidesXMR)
+	s := NewNetService(&mockNet{}, alice, bob)
+
+	req := &MakeOfferRequest{
+		MinimumAmount: common.MustNewAmount("1"),
+		MaximumAmount: common.MustNewAmount("2"),
+		ExchangeRate:  common.MustNewExchangeRate("0.1"),
+	}
+
+	// offers provide XMR by default
+	resp := new(MakeOfferResponse)
+	require.NoError(t, s.MakeOffer(nil, req, resp))
+	require.Len(t, bob.offers, 1)
+
+	req.Provides = common.ProvidesETH
+	require.NoError(t, s.MakeOffer(nil, req, resp))
+	require.Len(t, alice.offers, 1)
+	ethOfferID := resp.ID
+
+	// updates and cancellations go to the side which made the offer
+	updateReq := &UpdateOfferRequest{
+		OfferID: ethOfferID,
+		MakeOfferRequest: MakeOfferRequest{
+			MinimumAmount: common.MustNewAmount("1"),
+			MaximumAmount: common.MustNewAmount("3"),
+			ExchangeRate:  common.MustNewExchangeRate("0.1"),
+		},
+	}
+	require.NoError(t, s.UpdateOffer(nil, updateReq, nil))
+	o := alice.GetOffers()[0]
+	require.Equal(t, common.ProvidesETH, o.Provides)
+	require.Equal(t, "3", o.MaximumAmount.String())
+
+	updateReq.Provides = common.ProvidesXMR
+	require.Error(t, s.UpdateOffer(nil, updateReq, nil))
+
+	personalResp := new(GetOffersResponse)
+	require.NoError(t, NewPersonalService(alice, bob, nil).GetOffers(nil, nil, personalResp))
+	require.Len(t, personalResp.Offers, 2)
+
+	require.NoError(t, s.CancelOffer(nil, &CancelOfferRequest{OfferID: ethOfferID}, nil))
+	require.Empty(t, alice.offers)
+	require.Len(t, bob.offers, 1)
+	require.Error(t, s.CancelOffer(nil, &CancelOfferRequest{OfferID: ethOfferID}, nil))
+}
+
+func TestNetService_TokenOffers(t *testing.T) {
+	token := ethcommon.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	otherToken := ethcommon.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+
+	alice := newMockProtocol(common.ProvidesETH)
+	bob := newMockProtocol(common.ProvidesXMR)
+	s := NewNetService(&mockNet{}, alice, bob)
+
+	// offers providing a token are made by the ETH side, and swap that token
+	req := &MakeOfferRequest{
+		Provides:      common.NewProvidesERC20(token),
+		MinimumAmount: common.MustNewAmount("100"),
+		MaximumAmount: common.MustNewAmount("200"),
+		ExchangeRate:  common.MustNewExchangeRate("150"),
+	}
+	resp := new(MakeOfferResponse)
+	require.NoError(t, s.MakeOffer(nil, req, resp))
+	require.Len(t, alice.offers, 1)
+	require.Equal(t, token, alice.GetOffers()[0].Token)
+	tokenOfferID := resp.ID
+
+	req.Token = otherToken
+	require.Error(t, s.MakeOffer(nil, req, resp))
+
+	req.Provides = common.ProvidesETH
+	require.Error(t, s.MakeOffer(nil, req, resp))
+
+	// offers providing XMR can ask for a token in return
+	req.Provides = common.ProvidesXMR
+	require.NoError(t, s.MakeOffer(nil, req, resp))
+	require.Len(t, bob.offers, 1)
+	require.Equal(t, otherToken, bob.GetOffers()[0].Token)
+
+	// updates keep the offer's token
+	updateReq := &UpdateOfferRequest{
+		OfferID: tokenOfferID,
+		MakeOfferRequest: MakeOfferRequest{
+			MinimumAmount: common.MustNewAmount("100"),
+			MaximumAmount: common.MustNewAmount("300"),
+			ExchangeRate:  common.MustNewExchangeRate("150"),
+		},
+	}
+	require.NoError(t, s.UpdateOffer(nil, updateReq, nil))
+	o := alice.GetOffers()[0]
+	require.Equal(t, common.NewProvidesERC20(token), o.Provides)
+	require.Equal(t, token, o.Token)
+
+	updateReq.Token = otherToken
+	require.Error(t, s.UpdateOffer(nil, updateReq, nil))
+
+	// offers providing a token are taken by the XMR side
+	s = NewNetService(&mockNet{offers: []*types.Offer{o}}, alice, bob)
+	takeReq := &TakeOfferRequest{
+		Multiaddr:      fmt.Sprintf("/ip4/127.0.0.1/tcp/9934/p2p/%s", testPeerID),
+		OfferID:        o.GetID().String(),
+		ProvidesAmount: common.MustNewAmount("1"),
+	}
+	require.NoError(t, s.TakeOffer(nil, takeReq, new(TakeOfferResponse)))
+	require.Equal(t, o, bob.taken)
+	require.Nil(t, alice.taken)
+}
+
+func TestNetService_TakeOffer(t *testing.T) {
+	xmrOffer := &types.Offer{
+		Provides:      common.ProvidesXMR,
+		MinimumAmount: common.MustNewAmount("1"),
+		MaximumAmount: common.MustNewAmount("2"),
+		ExchangeRate:  common.MustNewExchangeRate("0.1"),
+	}
+	ethOffer := &types.Offer{
+		Provides:      common.ProvidesETH,
+		MinimumAmount: common.MustNewAmount("0.1"),
+		MaximumAmount: common.MustNewAmount("0.2"),
+		ExchangeRate:  common.MustNewExchangeRate("0.1"),
+	}
+
+	alice := newMockProtocol(common.ProvidesETH)
+	bob := newMockProtocol(common.ProvidesXMR)
+	s := NewNetService(&mockNet{offers: []*types.Offer{xmrOffer, ethOffer}}, alice, bob)
+
+	multiaddr := fmt.Sprintf("/ip4/127.0.0.1/tcp/9934/p2p/%s", testPeerID)
+
+	// XMR offers are taken by the ETH side, and ETH offers by the XMR side
+	req := &TakeOfferRequest{
+		Multiaddr:      multiaddr,
+		OfferID:        xmrOffer.GetID().String(),
+		ProvidesAmount: common.MustNewAmount("0.15"),
+	}
+	require.NoError(t, s.TakeOffer(nil, req, new(TakeOfferResponse)))
+	require.Equal(t, xmrOffer, alice.taken)
+	require.Nil(t, bob.taken)
+
+	req.OfferID = ethOffer.GetID().String()
+	req.ProvidesAmount = common.MustNewAmount("1.5")
+	require.NoError(t, s.TakeOffer(nil, req, new(TakeOfferResponse)))
+	require.Equal(t, ethOffer, bob.taken)
+}
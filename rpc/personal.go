@@ -1,19 +1,49 @@
 package rpc
 
 import (
+	"errors"
+	"net"
 	"net/http"
+
+	"github.com/noot/atomic-swap/common/types"
 )
 
 // PersonalService handles private keys and wallets.
 type PersonalService struct {
-	bob Bob
+	alice  Alice
+	bob    Bob
+	ethKey EthereumKey
 }
 
 // NewPersonalService ...
-func NewPersonalService(bob Bob) *PersonalService {
+func NewPersonalService(alice Alice, bob Bob, ethKey EthereumKey) *PersonalService {
 	return &PersonalService{
-		bob: bob,
+		alice:  alice,
+		bob:    bob,
+		ethKey: ethKey,
+	}
+}
+
+// UnlockRequest ...
+type UnlockRequest struct {
+	Passphrase string `json:"passphrase"`
+}
+
+var errUnlockNotLocal = errors.New("personal_unlock can only be called from localhost")
+
+// Unlock unlocks our ethereum key, if it's from an encrypted keystore, with the given passphrase.
+// Swaps can't be made until it's unlocked. Since the passphrase is sent in plaintext, it's only
+// accepted from localhost.
+func (s *PersonalService) Unlock(r *http.Request, req *UnlockRequest, _ *interface{}) error {
+	if !isLocalRequest(r) {
+		return errUnlockNotLocal
+	}
+
+	if s.ethKey == nil {
+		return errors.New("no ethereum key to unlock")
 	}
+
+	return s.ethKey.Unlock(req.Passphrase)
 }
 
 // SetMoneroWalletFileRequest ...
@@ -27,3 +57,25 @@ type SetMoneroWalletFileRequest struct {
 func (s *PersonalService) SetMoneroWalletFile(_ *http.Request, req *SetMoneroWalletFileRequest, _ *interface{}) error {
 	return s.bob.SetMoneroWalletFile(req.WalletFile, req.WalletPassword)
 }
+
+// GetOffersResponse ...
+type GetOffersResponse struct {
+	Offers []*types.Offer `json:"offers"`
+}
+
+// GetOffers returns our current offers, of both ETH and XMR.
+func (s *PersonalService) GetOffers(_ *http.Request, _ *interface{}, resp *GetOffersResponse) error {
+	resp.Offers = append(s.alice.GetOffers(), s.bob.GetOffers()...)
+	return nil
+}
+
+// isLocalRequest returns whether the given request was made from a loopback address.
+func isLocalRequest(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
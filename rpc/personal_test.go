@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockEthereumKey struct {
+	passphrase string
+	unlocked   bool
+}
+
+func (k *mockEthereumKey) Unlock(passphrase string) error {
+	if passphrase != k.passphrase {
+		return errors.New("wrong passphrase")
+	}
+
+	k.unlocked = true
+	return nil
+}
+
+func TestPersonalService_Unlock(t *testing.T) {
+	key := &mockEthereumKey{passphrase: "passphrase"}
+	s := NewPersonalService(new(mockProtocol), new(mockProtocol), key)
+	local := &http.Request{RemoteAddr: "127.0.0.1:50000"}
+
+	require.Error(t, s.Unlock(local, &UnlockRequest{Passphrase: "wrong"}, nil))
+	require.False(t, key.unlocked)
+	require.NoError(t, s.Unlock(local, &UnlockRequest{Passphrase: "passphrase"}, nil))
+	require.True(t, key.unlocked)
+
+	s = NewPersonalService(new(mockProtocol), new(mockProtocol), nil)
+	require.Error(t, s.Unlock(local, &UnlockRequest{Passphrase: "passphrase"}, nil))
+}
+
+func TestPersonalService_Unlock_notLocal(t *testing.T) {
+	key := &mockEthereumKey{passphrase: "passphrase"}
+	s := NewPersonalService(new(mockProtocol), new(mockProtocol), key)
+
+	remote := &http.Request{RemoteAddr: "203.0.113.1:50000"}
+	require.ErrorIs(t, s.Unlock(remote, &UnlockRequest{Passphrase: "passphrase"}, nil), errUnlockNotLocal)
+	require.False(t, key.unlocked)
+
+	local := &http.Request{RemoteAddr: "[::1]:50000"}
+	require.NoError(t, s.Unlock(local, &UnlockRequest{Passphrase: "passphrase"}, nil))
+	require.True(t, key.unlocked)
+}
@@ -1,17 +1,20 @@
 package rpc
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/noot/atomic-swap/common"
 	"github.com/noot/atomic-swap/common/types"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
 	"github.com/noot/atomic-swap/net"
 	"github.com/noot/atomic-swap/protocol/swap"
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/rpc/v2"
+	"github.com/libp2p/go-libp2p-core/peer"
 
 	logging "github.com/ipfs/go-log"
 )
@@ -21,16 +24,19 @@ var log = logging.Logger("rpc")
 // Server represents the JSON-RPC server
 type Server struct {
 	s    *rpc.Server
+	ws   *wsServer
 	port uint16
 }
 
 // Config ...
 type Config struct {
+	Ctx         context.Context
 	Port        uint16
 	Net         Net
 	Alice       Alice
 	Bob         Bob
 	SwapManager SwapManager
+	EthereumKey EthereumKey
 }
 
 // NewServer ...
@@ -41,16 +47,22 @@ func NewServer(cfg *Config) (*Server, error) {
 		return nil, err
 	}
 
-	if err := s.RegisterService(NewPersonalService(cfg.Bob), "personal"); err != nil {
+	if err := s.RegisterService(NewPersonalService(cfg.Alice, cfg.Bob, cfg.EthereumKey), "personal"); err != nil {
 		return nil, err
 	}
 
-	if err := s.RegisterService(NewSwapService(cfg.SwapManager), "swap"); err != nil {
+	if err := s.RegisterService(NewSwapService(cfg.SwapManager, cfg.Alice, cfg.Bob), "swap"); err != nil {
 		return nil, err
 	}
 
+	ctx := cfg.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	return &Server{
 		s:    s,
+		ws:   newWebSocketServer(ctx, cfg.Net, cfg.SwapManager),
 		port: cfg.Port,
 	}, nil
 }
@@ -62,12 +74,14 @@ func (s *Server) Start() <-chan error {
 	go func() {
 		r := mux.NewRouter()
 		r.Handle("/", s.s)
+		r.Handle("/ws", s.ws)
 
 		headersOk := handlers.AllowedHeaders([]string{"content-type", "username", "password"})
 		methodsOk := handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "OPTIONS"})
 		originsOk := handlers.AllowedOrigins([]string{"*"})
 
 		log.Infof("starting RPC server on http://localhost:%d", s.port)
+		log.Infof("serving subscriptions on ws://localhost:%d/ws", s.port)
 
 		if err := http.ListenAndServe(fmt.Sprintf(":%d", s.port), handlers.CORS(headersOk, methodsOk, originsOk)(r)); err != nil { //nolint:lll
 			log.Errorf("failed to start RPC server: %s", err)
@@ -78,22 +92,45 @@ func (s *Server) Start() <-chan error {
 	return errCh
 }
 
+// EthereumKey represents the functions required by the rpc service to unlock our ethereum key.
+type EthereumKey interface {
+	Unlock(passphrase string) error
+}
+
 // Protocol represents the functions required by the rpc service into the protocol handler.
 type Protocol interface {
 	Provides() common.ProvidesCoin
 	SetGasPrice(gasPrice uint64)
+	ValidateSweepAddress(address mcrypto.Address) error
+}
+
+// Maker represents the functions required by the rpc service to make offers providing the coin
+// the protocol handler provides.
+type Maker interface {
+	Protocol
+	MakeOffer(offer *types.Offer) error
+	UpdateOffer(offer *types.Offer) error
+	CancelOffer(id types.Hash) error
+	GetOffers() []*types.Offer
+}
+
+// Taker represents the functions required by the rpc service to take offers which provide the
+// coin the protocol handler doesn't provide.
+type Taker interface {
+	Protocol
+	InitiateProtocol(providesAmount common.Amount, offer *types.Offer, counterparty peer.ID) (net.SwapState, error)
 }
 
 // Alice ...
 type Alice interface {
-	Protocol
-	InitiateProtocol(providesAmount float64) (net.SwapState, error)
+	Maker
+	Taker
 }
 
 // Bob ...
 type Bob interface {
-	Protocol
-	MakeOffer(offer *types.Offer) error
+	Maker
+	Taker
 	SetMoneroWalletFile(file, password string) error
 }
 
@@ -101,5 +138,7 @@ type Bob interface {
 type SwapManager interface {
 	GetPastIDs() []uint64
 	GetPastSwap(id uint64) *swap.Info
-	GetOngoingSwap() *swap.Info
+	GetOngoingIDs() []uint64
+	GetOngoingSwap(id uint64) *swap.Info
+	SubscribeEvents() (<-chan *swap.Event, func())
 }
@@ -5,17 +5,25 @@ import (
 	"net/http"
 
 	"github.com/noot/atomic-swap/common"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+	"github.com/noot/atomic-swap/protocol/swap"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
 )
 
 // SwapService handles information about ongoing or past swaps.
 type SwapService struct {
-	sm SwapManager
+	sm    SwapManager
+	alice Alice
+	bob   Bob
 }
 
 // NewSwapService ...
-func NewSwapService(sm SwapManager) *SwapService {
+func NewSwapService(sm SwapManager, alice Alice, bob Bob) *SwapService {
 	return &SwapService{
-		sm: sm,
+		sm:    sm,
+		alice: alice,
+		bob:   bob,
 	}
 }
 
@@ -35,13 +43,69 @@ type GetPastRequest struct {
 	ID uint64 `json:"id"`
 }
 
+// SwapInfo contains the details of a swap, as returned by swap_getPast and swap_getOngoing.
+type SwapInfo struct {
+	ID                   uint64              `json:"id"`
+	Provided             common.ProvidesCoin `json:"provided"`
+	ProvidedAmount       common.Amount       `json:"providedAmount"`
+	ReceivedAmount       common.Amount       `json:"receivedAmount"`
+	ExchangeRate         common.ExchangeRate `json:"exchangeRate"`
+	Status               string              `json:"status"`
+	Stage                string              `json:"stage"`
+	AwaitingConfirmation bool                `json:"awaitingConfirmation,omitempty"`
+	Counterparty         string              `json:"counterparty,omitempty"`
+	ContractAddress      string              `json:"contractAddress,omitempty"`
+	ContractSwapID       string              `json:"contractSwapID,omitempty"`
+	XMRLockAddress       string              `json:"xmrLockAddress,omitempty"`
+	XMRLockTxHash        string              `json:"xmrLockTxHash,omitempty"`
+	SweepAddress         string              `json:"sweepAddress,omitempty"`
+	SweepTxHashes        []string            `json:"sweepTxHashes,omitempty"`
+	Timeout0             int64               `json:"timeout0,omitempty"` // unix timestamp
+	Timeout1             int64               `json:"timeout1,omitempty"` // unix timestamp
+}
+
+func newSwapInfo(info *swap.Info) SwapInfo {
+	si := SwapInfo{
+		ID:                   info.ID(),
+		Provided:             info.Provides(),
+		ProvidedAmount:       info.ProvidedAmount(),
+		ReceivedAmount:       info.ReceivedAmount(),
+		ExchangeRate:         info.ExchangeRate(),
+		Status:               info.Status().String(),
+		Stage:                info.Stage().String(),
+		AwaitingConfirmation: info.AwaitingConfirmation(),
+		XMRLockAddress:       string(info.XMRLockAddress()),
+		XMRLockTxHash:        info.XMRLockTxHash(),
+		SweepAddress:         string(info.SweepAddress()),
+		SweepTxHashes:        info.SweepTxHashes(),
+	}
+
+	if info.Counterparty() != "" {
+		si.Counterparty = info.Counterparty().Pretty()
+	}
+
+	if (info.ContractAddress() != ethcommon.Address{}) {
+		si.ContractAddress = info.ContractAddress().String()
+	}
+
+	if (info.ContractSwapID() != ethcommon.Hash{}) {
+		si.ContractSwapID = info.ContractSwapID().String()
+	}
+
+	if !info.Timeout0().IsZero() {
+		si.Timeout0 = info.Timeout0().Unix()
+	}
+
+	if !info.Timeout1().IsZero() {
+		si.Timeout1 = info.Timeout1().Unix()
+	}
+
+	return si
+}
+
 // GetPastResponse ...
 type GetPastResponse struct {
-	Provided       common.ProvidesCoin `json:"provided"`
-	ProvidedAmount float64             `json:"providedAmount"`
-	ReceivedAmount float64             `json:"receivedAmount"`
-	ExchangeRate   common.ExchangeRate `json:"exchangeRate"`
-	Status         string              `json:"status"`
+	SwapInfo
 }
 
 // GetPast returns information about a past swap, given its ID.
@@ -51,36 +115,86 @@ func (s *SwapService) GetPast(_ *http.Request, req *GetPastRequest, resp *GetPas
 		return errors.New("unable to find swap with given ID")
 	}
 
-	resp.Provided = info.Provides()
-	resp.ProvidedAmount = info.ProvidedAmount()
-	resp.ReceivedAmount = info.ReceivedAmount()
-	resp.ExchangeRate = info.ExchangeRate()
-	resp.Status = info.Status().String()
+	resp.SwapInfo = newSwapInfo(info)
+	return nil
+}
+
+// GetOngoingIDsResponse ...
+type GetOngoingIDsResponse struct {
+	IDs []uint64 `json:"ids"`
+}
+
+// GetOngoingIDs returns the IDs of all ongoing swaps
+func (s *SwapService) GetOngoingIDs(_ *http.Request, _ *interface{}, resp *GetOngoingIDsResponse) error {
+	resp.IDs = s.sm.GetOngoingIDs()
 	return nil
 }
 
+// GetOngoingRequest ...
+type GetOngoingRequest struct {
+	ID uint64 `json:"id"`
+}
+
 // GetOngoingResponse ...
 type GetOngoingResponse struct {
-	ID             uint64              `json:"id"`
-	Provided       common.ProvidesCoin `json:"provided"`
-	ProvidedAmount float64             `json:"providedAmount"`
-	ReceivedAmount float64             `json:"receivedAmount"`
-	ExchangeRate   common.ExchangeRate `json:"exchangeRate"`
-	Status         string              `json:"status"`
+	SwapInfo
+}
+
+// GetOngoing returns information about an ongoing swap, given its ID.
+func (s *SwapService) GetOngoing(_ *http.Request, req *GetOngoingRequest, resp *GetOngoingResponse) error {
+	info := s.sm.GetOngoingSwap(req.ID)
+	if info == nil {
+		return errors.New("unable to find ongoing swap with given ID")
+	}
+
+	resp.SwapInfo = newSwapInfo(info)
+	return nil
+}
+
+// ConfirmRequest ...
+type ConfirmRequest struct {
+	ID     uint64 `json:"id"`
+	Accept bool   `json:"accept"`
 }
 
-// GetOngoing returns information about the ongoing swap, if there is one.
-func (s *SwapService) GetOngoing(_ *http.Request, _ *interface{}, resp *GetOngoingResponse) error {
-	info := s.sm.GetOngoingSwap()
+// Confirm accepts or declines an ongoing swap which is awaiting confirmation by the user.
+// Declining a swap aborts it, and the counterparty is told it was declined.
+func (s *SwapService) Confirm(_ *http.Request, req *ConfirmRequest, _ *interface{}) error {
+	info := s.sm.GetOngoingSwap(req.ID)
 	if info == nil {
-		return errors.New("no current ongoing swap")
+		return errors.New("unable to find ongoing swap with given ID")
+	}
+
+	return info.Confirm(req.Accept)
+}
+
+// SetSweepAddressRequest ...
+type SetSweepAddressRequest struct {
+	ID      uint64 `json:"id"`
+	Address string `json:"address"`
+}
+
+// SetSweepAddress sets the address the XMR received in an ongoing swap is swept to once it's
+// unlocked. An empty address leaves the XMR in the swap wallet.
+func (s *SwapService) SetSweepAddress(_ *http.Request, req *SetSweepAddressRequest, _ *interface{}) error {
+	info := s.sm.GetOngoingSwap(req.ID)
+	if info == nil {
+		return errors.New("unable to find ongoing swap with given ID")
+	}
+
+	address := mcrypto.Address(req.Address)
+	if address != "" {
+		// the swap was made by the protocol handler providing the swap's coin
+		var p Protocol = s.alice
+		if info.Provides() == common.ProvidesXMR {
+			p = s.bob
+		}
+
+		if err := p.ValidateSweepAddress(address); err != nil {
+			return err
+		}
 	}
 
-	resp.ID = info.ID()
-	resp.Provided = info.Provides()
-	resp.ProvidedAmount = info.ProvidedAmount()
-	resp.ReceivedAmount = info.ReceivedAmount()
-	resp.ExchangeRate = info.ExchangeRate()
-	resp.Status = info.Status().String()
+	info.SetSweepAddress(address)
 	return nil
 }
@@ -0,0 +1,108 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/noot/atomic-swap/common"
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+	"github.com/noot/atomic-swap/protocol/swap"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwapService_GetOngoing(t *testing.T) {
+	sm, err := swap.NewManager(memorydb.New())
+	require.NoError(t, err)
+
+	who, err := peer.Decode(testPeerID)
+	require.NoError(t, err)
+
+	info := swap.NewInfo(common.ProvidesETH, common.MustNewAmount("1"), common.MustNewAmount("10"),
+		common.MustNewExchangeRate("0.1"), swap.Ongoing)
+	require.NoError(t, sm.AddSwap(info))
+	info.SetCounterparty(who)
+	info.SetContractAddress(ethcommon.HexToAddress("0xabcd"))
+	info.SetContractSwapID(ethcommon.HexToHash("0x1234"))
+	info.SetTimeouts(time.Unix(1642175017, 0), time.Unix(1642261417, 0))
+	info.SetStage(swap.StageETHLocked, "")
+
+	s := NewSwapService(sm, newMockProtocol(common.ProvidesETH), newMockProtocol(common.ProvidesXMR))
+	resp := new(GetOngoingResponse)
+	err = s.GetOngoing(nil, &GetOngoingRequest{ID: info.ID()}, resp)
+	require.NoError(t, err)
+	require.Equal(t, info.ID(), resp.ID)
+	require.Equal(t, "ongoing", resp.Status)
+	require.Equal(t, swap.StageETHLocked.String(), resp.Stage)
+	require.Equal(t, testPeerID, resp.Counterparty)
+	require.Equal(t, ethcommon.HexToAddress("0xabcd").String(), resp.ContractAddress)
+	require.Equal(t, ethcommon.HexToHash("0x1234").String(), resp.ContractSwapID)
+	require.Equal(t, "", resp.XMRLockAddress)
+	require.Equal(t, int64(1642175017), resp.Timeout0)
+	require.Equal(t, int64(1642261417), resp.Timeout1)
+
+	err = s.GetPast(nil, &GetPastRequest{ID: info.ID()}, new(GetPastResponse))
+	require.Error(t, err)
+}
+
+func TestSwapService_Confirm(t *testing.T) {
+	sm, err := swap.NewManager(memorydb.New())
+	require.NoError(t, err)
+
+	info := swap.NewInfo(common.ProvidesXMR, common.MustNewAmount("10"), common.MustNewAmount("1"),
+		common.MustNewExchangeRate("10"), swap.Ongoing)
+	require.NoError(t, sm.AddSwap(info))
+
+	s := NewSwapService(sm, newMockProtocol(common.ProvidesETH), newMockProtocol(common.ProvidesXMR))
+	err = s.Confirm(nil, &ConfirmRequest{ID: info.ID(), Accept: true}, nil)
+	require.Error(t, err)
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- info.AwaitConfirmation(context.Background(), time.Minute)
+	}()
+
+	require.Eventually(t, info.AwaitingConfirmation, time.Second*5, time.Millisecond*10)
+
+	resp := new(GetOngoingResponse)
+	err = s.GetOngoing(nil, &GetOngoingRequest{ID: info.ID()}, resp)
+	require.NoError(t, err)
+	require.True(t, resp.AwaitingConfirmation)
+
+	err = s.Confirm(nil, &ConfirmRequest{ID: info.ID(), Accept: false}, nil)
+	require.NoError(t, err)
+	require.ErrorIs(t, <-errCh, swap.ErrSwapDeclined)
+}
+
+func TestSwapService_SetSweepAddress(t *testing.T) {
+	sm, err := swap.NewManager(memorydb.New())
+	require.NoError(t, err)
+
+	info := swap.NewInfo(common.ProvidesETH, common.MustNewAmount("1"), common.MustNewAmount("10"),
+		common.MustNewExchangeRate("0.1"), swap.Ongoing)
+	require.NoError(t, sm.AddSwap(info))
+
+	s := NewSwapService(sm, newMockProtocol(common.ProvidesETH), newMockProtocol(common.ProvidesXMR))
+	err = s.SetSweepAddress(nil, &SetSweepAddressRequest{ID: info.ID(), Address: "invalid"}, nil)
+	require.Error(t, err)
+
+	err = s.SetSweepAddress(nil, &SetSweepAddressRequest{ID: info.ID(), Address: "sweep-address"}, nil)
+	require.NoError(t, err)
+
+	resp := new(GetOngoingResponse)
+	err = s.GetOngoing(nil, &GetOngoingRequest{ID: info.ID()}, resp)
+	require.NoError(t, err)
+	require.Equal(t, "sweep-address", resp.SweepAddress)
+
+	// an empty address leaves the XMR in the swap wallet
+	err = s.SetSweepAddress(nil, &SetSweepAddressRequest{ID: info.ID()}, nil)
+	require.NoError(t, err)
+	require.Equal(t, mcrypto.Address(""), info.SweepAddress())
+
+	err = s.SetSweepAddress(nil, &SetSweepAddressRequest{ID: info.ID() + 1, Address: "sweep-address"}, nil)
+	require.Error(t, err)
+}
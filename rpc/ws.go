@@ -0,0 +1,315 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
+	"github.com/noot/atomic-swap/protocol/swap"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	subscribeSwapStatusMethod = "swap_subscribeStatus"
+	subscribeOffersMethod     = "net_subscribeOffers"
+
+	defaultOfferPollInterval = time.Minute
+
+	// JSON-RPC error codes
+	errCodeParse          = -32700
+	errCodeMethodNotFound = -32601
+	errCodeInvalidParams  = -32602
+	errCodeInternal       = -32603
+)
+
+// upgrader only accepts connections without an Origin header, eg. from non-browser clients, or
+// whose Origin host is the host the connection was made to, so that web pages served elsewhere
+// can't use the daemon from a browser.
+var upgrader = websocket.Upgrader{}
+
+// wsRequest is a JSON-RPC request received over a websocket connection.
+type wsRequest struct {
+	Version string           `json:"jsonrpc"`
+	Method  string           `json:"method"`
+	Params  json.RawMessage  `json:"params"`
+	ID      *json.RawMessage `json:"id"`
+}
+
+// wsResponse is a JSON-RPC response sent over a websocket connection. A subscription
+// sends a response with the subscribing request's ID for each event.
+type wsResponse struct {
+	Version string           `json:"jsonrpc"`
+	Result  interface{}      `json:"result,omitempty"`
+	Error   *wsError         `json:"error,omitempty"`
+	ID      *json.RawMessage `json:"id"`
+}
+
+type wsError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// SubscribeSwapStatusRequest ...
+type SubscribeSwapStatusRequest struct {
+	ID uint64 `json:"id"`
+}
+
+// SwapStatusEvent is sent to swap_subscribeStatus subscribers each time the swap
+// moves to a new stage or status.
+type SwapStatusEvent struct {
+	ID                   uint64    `json:"id"`
+	Stage                string    `json:"stage"`
+	Status               string    `json:"status"`
+	AwaitingConfirmation bool      `json:"awaitingConfirmation,omitempty"`
+	TxHash               string    `json:"txHash,omitempty"`
+	Time                 time.Time `json:"time"`
+}
+
+// SubscribeOffersRequest ...
+type SubscribeOffersRequest struct {
+	Provides     common.ProvidesCoin `json:"provides"`
+	SearchTime   uint64              `json:"searchTime"`   // in seconds
+	PollInterval uint64              `json:"pollInterval"` // in seconds
+}
+
+// OfferEvent is sent to net_subscribeOffers subscribers for each newly discovered offer.
+type OfferEvent struct {
+	Peer  []string     `json:"peer"`
+	Offer *types.Offer `json:"offer"`
+}
+
+// wsServer serves subscriptions over websocket connections.
+type wsServer struct {
+	ctx context.Context
+	net Net
+	sm  SwapManager
+}
+
+func newWebSocketServer(ctx context.Context, net Net, sm SwapManager) *wsServer {
+	return &wsServer{
+		ctx: ctx,
+		net: net,
+		sm:  sm,
+	}
+}
+
+// wsConn is a websocket connection, which may have multiple subscriptions writing to it.
+type wsConn struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+}
+
+func (c *wsConn) write(resp *wsResponse) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	resp.Version = "2.0"
+	return c.conn.WriteJSON(resp)
+}
+
+func (c *wsConn) writeError(id *json.RawMessage, code int, err error) {
+	if err := c.write(&wsResponse{
+		Error: &wsError{
+			Code:    code,
+			Message: err.Error(),
+		},
+		ID: id,
+	}); err != nil {
+		log.Debugf("failed to write to websocket: err=%s", err)
+	}
+}
+
+// ServeHTTP upgrades the connection to a websocket and handles subscription requests
+// until the connection is closed.
+func (s *wsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Warnf("failed to upgrade connection to websocket: err=%s", err)
+		return
+	}
+
+	c := &wsConn{conn: conn}
+	ctx, cancel := context.WithCancel(s.ctx)
+
+	defer func() {
+		cancel()
+		_ = conn.Close()
+	}()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			log.Debugf("websocket connection closed: err=%s", err)
+			return
+		}
+
+		var req *wsRequest
+		if err = json.Unmarshal(msg, &req); err != nil {
+			c.writeError(nil, errCodeParse, err)
+			continue
+		}
+
+		if err = s.handleRequest(ctx, c, req); err != nil {
+			c.writeError(req.ID, errCodeInvalidParams, err)
+		}
+	}
+}
+
+func (s *wsServer) handleRequest(ctx context.Context, c *wsConn, req *wsRequest) error {
+	switch req.Method {
+	case subscribeSwapStatusMethod:
+		var params SubscribeSwapStatusRequest
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return err
+		}
+
+		return s.subscribeSwapStatus(ctx, c, req.ID, params.ID)
+	case subscribeOffersMethod:
+		var params SubscribeOffersRequest
+		if len(req.Params) != 0 {
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				return err
+			}
+		}
+
+		go s.subscribeOffers(ctx, c, req.ID, &params)
+		return nil
+	default:
+		c.writeError(req.ID, errCodeMethodNotFound, fmt.Errorf("unsupported method %s", req.Method))
+		return nil
+	}
+}
+
+// subscribeSwapStatus sends the current stage and status of the swap with the given ID, followed
+// by an event for each change, until the swap completes.
+func (s *wsServer) subscribeSwapStatus(ctx context.Context, c *wsConn, reqID *json.RawMessage, id uint64) error {
+	// subscribe before looking up the swap, so that no events are missed
+	events, unsubscribe := s.sm.SubscribeEvents()
+
+	info := s.sm.GetOngoingSwap(id)
+	if info == nil {
+		info = s.sm.GetPastSwap(id)
+	}
+
+	if info == nil {
+		unsubscribe()
+		return errors.New("unable to find swap with given ID")
+	}
+
+	current := &SwapStatusEvent{
+		ID:                   id,
+		Stage:                info.Stage().String(),
+		Status:               info.Status().String(),
+		AwaitingConfirmation: info.AwaitingConfirmation(),
+		Time:                 time.Now(),
+	}
+
+	if err := c.write(&wsResponse{Result: current, ID: reqID}); err != nil {
+		unsubscribe()
+		return nil
+	}
+
+	if info.Status() != swap.Ongoing {
+		unsubscribe()
+		return nil
+	}
+
+	go func() {
+		defer unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-events:
+				if ev.ID != id {
+					continue
+				}
+
+				if err := c.write(&wsResponse{
+					Result: &SwapStatusEvent{
+						ID:                   ev.ID,
+						Stage:                ev.Stage.String(),
+						Status:               ev.Status.String(),
+						AwaitingConfirmation: ev.AwaitingConfirmation,
+						TxHash:               ev.TxHash,
+						Time:                 ev.Time,
+					},
+					ID: reqID,
+				}); err != nil {
+					log.Debugf("failed to write to websocket: err=%s", err)
+					return
+				}
+
+				if ev.Status != swap.Ongoing {
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// subscribeOffers periodically searches the network for peers providing the requested coin,
+// and sends each offer that hasn't been seen before.
+func (s *wsServer) subscribeOffers(ctx context.Context, c *wsConn, reqID *json.RawMessage,
+	params *SubscribeOffersRequest) {
+	searchTime := time.Duration(params.SearchTime) * time.Second
+	if searchTime == 0 {
+		searchTime = defaultSearchTime
+	}
+
+	interval := time.Duration(params.PollInterval) * time.Second
+	if interval == 0 {
+		interval = defaultOfferPollInterval
+	}
+
+	seen := make(map[types.Hash]struct{})
+
+	for {
+		peers, err := s.net.Discover(params.Provides, searchTime)
+		if err != nil {
+			c.writeError(reqID, errCodeInternal, err)
+			return
+		}
+
+		for _, p := range peers {
+			resp, err := s.net.Query(p)
+			if err != nil {
+				log.Debugf("failed to query peer: peer=%s err=%s", p.ID, err)
+				continue
+			}
+
+			for _, o := range resp.Offers {
+				if _, has := seen[o.GetID()]; has {
+					continue
+				}
+
+				seen[o.GetID()] = struct{}{}
+				if err = c.write(&wsResponse{
+					Result: &OfferEvent{
+						Peer:  addrInfoToStrings(p),
+						Offer: o,
+					},
+					ID: reqID,
+				}); err != nil {
+					log.Debugf("failed to write to websocket: err=%s", err)
+					return
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
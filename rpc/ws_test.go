@@ -0,0 +1,176 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/common/types"
+	"github.com/noot/atomic-swap/net"
+	"github.com/noot/atomic-swap/protocol/swap"
+
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/gorilla/websocket"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+const testPeerID = "12D3KooWHLUrLnJtUbaGzTSi6azZavKhNgUZTtSiUZ9Uy12v1eZ7"
+
+type mockNet struct {
+	offers []*types.Offer
+}
+
+func (n *mockNet) Addresses() []string {
+	return nil
+}
+
+func (n *mockNet) Advertise() {}
+
+func (n *mockNet) Discover(_ common.ProvidesCoin, _ time.Duration) ([]peer.AddrInfo, error) {
+	who, err := peer.Decode(testPeerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return []peer.AddrInfo{{ID: who}}, nil
+}
+
+func (n *mockNet) Query(_ peer.AddrInfo) (*net.QueryResponse, error) {
+	return &net.QueryResponse{
+		Offers: n.offers,
+	}, nil
+}
+
+func (n *mockNet) Initiate(_ peer.AddrInfo, _ *net.SendKeysMessage, _ net.SwapState) error {
+	return nil
+}
+
+func newTestWebSocket(t *testing.T, n Net, sm SwapManager) *websocket.Conn {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	srv := httptest.NewServer(newWebSocketServer(ctx, n, sm))
+	t.Cleanup(srv.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil) //nolint:bodyclose
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+func readWSResponse(t *testing.T, conn *websocket.Conn, result interface{}) *wsError {
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *wsError        `json:"error"`
+	}
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second*5)))
+	require.NoError(t, conn.ReadJSON(&resp))
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	require.NoError(t, json.Unmarshal(resp.Result, result))
+	return nil
+}
+
+func TestWebSocket_SubscribeSwapStatus(t *testing.T) {
+	sm, err := swap.NewManager(memorydb.New())
+	require.NoError(t, err)
+
+	info := swap.NewInfo(common.ProvidesETH, common.MustNewAmount("1"), common.MustNewAmount("10"),
+		common.MustNewExchangeRate("0.1"), swap.Ongoing)
+	require.NoError(t, sm.AddSwap(info))
+
+	conn := newTestWebSocket(t, &mockNet{}, sm)
+	err = conn.WriteMessage(websocket.TextMessage,
+		[]byte(`{"jsonrpc":"2.0","method":"swap_subscribeStatus","params":{"id":0},"id":1}`))
+	require.NoError(t, err)
+
+	var ev *SwapStatusEvent
+	require.Nil(t, readWSResponse(t, conn, &ev))
+	require.Equal(t, swap.StageInitiated.String(), ev.Stage)
+	require.Equal(t, swap.Ongoing.String(), ev.Status)
+
+	info.SetStage(swap.StageETHLocked, "0xabcd")
+	require.Nil(t, readWSResponse(t, conn, &ev))
+	require.Equal(t, swap.StageETHLocked.String(), ev.Stage)
+	require.Equal(t, "0xabcd", ev.TxHash)
+
+	info.SetStatus(swap.Refunded)
+	require.Nil(t, readWSResponse(t, conn, &ev))
+	require.Equal(t, swap.Refunded.String(), ev.Status)
+
+	// unknown swap
+	err = conn.WriteMessage(websocket.TextMessage,
+		[]byte(`{"jsonrpc":"2.0","method":"swap_subscribeStatus","params":{"id":99},"id":2}`))
+	require.NoError(t, err)
+	require.NotNil(t, readWSResponse(t, conn, &ev))
+}
+
+func TestWebSocket_SubscribeOffers(t *testing.T) {
+	sm, err := swap.NewManager(memorydb.New())
+	require.NoError(t, err)
+
+	offer := &types.Offer{
+		Provides:      common.ProvidesXMR,
+		MinimumAmount: common.MustNewAmount("1"),
+		MaximumAmount: common.MustNewAmount("2"),
+		ExchangeRate:  common.MustNewExchangeRate("0.1"),
+	}
+
+	conn := newTestWebSocket(t, &mockNet{offers: []*types.Offer{offer}}, sm)
+	err = conn.WriteMessage(websocket.TextMessage,
+		[]byte(`{"jsonrpc":"2.0","method":"net_subscribeOffers","params":{"provides":"XMR"},"id":1}`))
+	require.NoError(t, err)
+
+	var ev *OfferEvent
+	require.Nil(t, readWSResponse(t, conn, &ev))
+	require.Equal(t, offer.GetID(), ev.Offer.GetID())
+}
+
+func TestWebSocket_UnknownMethod(t *testing.T) {
+	sm, err := swap.NewManager(memorydb.New())
+	require.NoError(t, err)
+
+	conn := newTestWebSocket(t, &mockNet{}, sm)
+	err = conn.WriteMessage(websocket.TextMessage,
+		[]byte(`{"jsonrpc":"2.0","method":"swap_subscribeNothing","params":{},"id":1}`))
+	require.NoError(t, err)
+
+	wsErr := readWSResponse(t, conn, nil)
+	require.NotNil(t, wsErr)
+	require.Equal(t, errCodeMethodNotFound, wsErr.Code)
+}
+
+func TestWebSocket_CheckOrigin(t *testing.T) {
+	sm, err := swap.NewManager(memorydb.New())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	srv := httptest.NewServer(newWebSocketServer(ctx, &mockNet{}, sm))
+	t.Cleanup(srv.Close)
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	// connections from pages served by the daemon's host are accepted
+	conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {srv.URL}}) //nolint:bodyclose
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	// connections from pages served elsewhere are rejected
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"https://example.com"}})
+	require.ErrorIs(t, err, websocket.ErrBadHandshake)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+}
@@ -1,6 +1,12 @@
 #!/bin/bash
 
-$SOLC_BIN --abi ethereum/contracts/Swap.sol -o ethereum/abi/ --overwrite
-$SOLC_BIN --bin ethereum/contracts/Swap.sol -o ethereum/bin/ --overwrite
-abigen --abi ethereum/abi/Swap.abi --pkg swap --type Swap --out swap.go --bin ethereum/bin/Swap.bin
-mv swap.go ./swap-contract
\ No newline at end of file
+$SOLC_BIN --abi ethereum/contracts/SwapFactory.sol -o ethereum/abi/ --overwrite
+$SOLC_BIN --bin ethereum/contracts/SwapFactory.sol -o ethereum/bin/ --overwrite
+abigen --abi ethereum/abi/SwapFactory.abi --pkg swap --type SwapFactory --out swap_factory.go --bin ethereum/bin/SwapFactory.bin
+abigen --abi ethereum/abi/IERC20.abi --pkg swap --type IERC20 --out ierc20.go
+mv swap_factory.go ierc20.go ./swap-contract
+
+$SOLC_BIN --abi ethereum/contracts/ERC20Mock.sol -o ethereum/abi/ --overwrite
+$SOLC_BIN --bin ethereum/contracts/ERC20Mock.sol -o ethereum/bin/ --overwrite
+abigen --abi ethereum/abi/ERC20Mock.abi --pkg swap --type ERC20Mock --out erc20_mock.go --bin ethereum/bin/ERC20Mock.bin
+mv erc20_mock.go ./swap-contract
@@ -0,0 +1,40 @@
+#!/bin/bash
+# Records DLEq proofs generated by the farcaster-dleq binaries, and the keys they verify to, as
+# test vectors for dleq.GoDLEq. The binaries must be built first with `make build-dleq`.
+
+set -e
+
+BIN=./farcaster-dleq/target/release
+OUT=./dleq/testdata/farcaster_vectors.json
+NUM_VECTORS=${NUM_VECTORS:-2}
+DIR=$(mktemp -d)
+trap 'rm -rf $DIR' EXIT
+
+hex() {
+	od -An -v -tx1 "$1" | tr -d ' \n'
+}
+
+mkdir -p "$(dirname $OUT)"
+echo "[" > $OUT
+for i in $(seq 1 $NUM_VECTORS); do
+	$BIN/dleq-gen $DIR/proof-$i > /dev/null
+	read -r ED25519_PUB SECP256K1_X SECP256K1_Y <<< "$($BIN/dleq-verify $DIR/proof-$i)"
+
+	SEP=","
+	if [ $i -eq $NUM_VECTORS ]; then
+		SEP=""
+	fi
+
+	cat >> $OUT <<VECTOR
+	{
+		"secret": "$(hex $DIR/proof-$i.key)",
+		"ed25519Pub": "$ED25519_PUB",
+		"secp256k1X": "$SECP256K1_X",
+		"secp256k1Y": "$SECP256K1_Y",
+		"proof": "$(hex $DIR/proof-$i)"
+	}$SEP
+VECTOR
+done
+echo "]" >> $OUT
+
+echo "wrote $NUM_VECTORS vectors to $OUT"
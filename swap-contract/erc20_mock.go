@@ -0,0 +1,418 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package swap
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ERC20MockABI is the input ABI used to generate the binding from.
+const ERC20MockABI = "[{\"inputs\":[{\"internalType\":\"uint8\",\"name\":\"_decimals\",\"type\":\"uint8\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"mint\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"fee\",\"type\":\"uint256\"}],\"name\":\"setTransferFee\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"transferFee\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+
+// ERC20MockBin is the compiled bytecode used for deploying new contracts.
+var ERC20MockBin = "0x60a060405234801561001057600080fd5b5060405161096838038061096883398181016040528101906100329190610084565b8060ff1660808160ff1681525050506100b1565b600080fd5b600060ff82169050919050565b6100618161004b565b811461006c57600080fd5b50565b60008151905061007e81610058565b92915050565b60006020828403121561009a57610099610046565b5b60006100a88482850161006f565b91505092915050565b60805161089c6100cc60003960006103e4015261089c6000f3fe608060405234801561001057600080fd5b50600436106100935760003560e01c806370a082311161006657806370a08231146101325780638f02bb5b14610162578063a9059cbb1461017e578063acb2ad6f146101ae578063dd62ed3e146101cc57610093565b8063095ea7b31461009857806323b872dd146100c8578063313ce567146100f857806340c10f1914610116575b600080fd5b6100b260048036038101906100ad919061060b565b6101fc565b6040516100bf9190610666565b60405180910390f35b6100e260048036038101906100dd9190610681565b610289565b6040516100ef9190610666565b60405180910390f35b6101006103e2565b60405161010d91906106f0565b60405180910390f35b610130600480360381019061012b919061060b565b610406565b005b61014c6004803603810190610147919061070b565b610460565b6040516101599190610747565b60405180910390f35b61017c60048036038101906101779190610762565b610478565b005b6101986004803603810190610193919061060b565b610482565b6040516101a59190610666565b60405180910390f35b6101b6610547565b6040516101c39190610747565b60405180910390f35b6101e660048036038101906101e1919061078f565b61054d565b6040516101f39190610747565b60405180910390f35b600081600260003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055506001905092915050565b600081600260008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600082825461031791906107fe565b9250508190555081600160008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600082825461036d91906107fe565b925050819055506000548261038291906107fe565b600160008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546103d09190610832565b92505081905550600190509392505050565b7f000000000000000000000000000000000000000000000000000000000000000081565b80600160008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546104559190610832565b925050819055505050565b60016020528060005260406000206000915090505481565b8060008190555050565b600081600160003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546104d391906107fe565b92505081905550600054826104e891906107fe565b600160008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008282546105369190610832565b925050819055506001905092915050565b60005481565b6002602052816000526040600020602052806000526040600020600091509150505481565b600080fd5b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b60006105a282610577565b9050919050565b6105b281610597565b81146105bd57600080fd5b50565b6000813590506105cf816105a9565b92915050565b6000819050919050565b6105e8816105d5565b81146105f357600080fd5b50565b600081359050610605816105df565b92915050565b6000806040838503121561062257610621610572565b5b6000610630858286016105c0565b9250506020610641858286016105f6565b9150509250929050565b60008115159050919050565b6106608161064b565b82525050565b600060208201905061067b6000830184610657565b92915050565b60008060006060848603121561069a57610699610572565b5b60006106a8868287016105c0565b93505060206106b9868287016105c0565b92505060406106ca868287016105f6565b9150509250925092565b600060ff82169050919050565b6106ea816106d4565b82525050565b600060208201905061070560008301846106e1565b92915050565b60006020828403121561072157610720610572565b5b600061072f848285016105c0565b91505092915050565b610741816105d5565b82525050565b600060208201905061075c6000830184610738565b92915050565b60006020828403121561077857610777610572565b5b6000610786848285016105f6565b91505092915050565b600080604083850312156107a6576107a5610572565b5b60006107b4858286016105c0565b92505060206107c5858286016105c0565b9150509250929050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b6000610809826105d5565b9150610814836105d5565b925082820390508181111561082c5761082b6107cf565b5b92915050565b600061083d826105d5565b9150610848836105d5565b92508282019050808211156108605761085f6107cf565b5b9291505056fea26469706673582212207392421744f5c2462b500b4f3e13aca9393a61ae5e4b28924203d6b0a615463d64736f6c63430008150033"
+
+// DeployERC20Mock deploys a new Ethereum contract, binding an instance of ERC20Mock to it.
+func DeployERC20Mock(auth *bind.TransactOpts, backend bind.ContractBackend, _decimals uint8) (common.Address, *types.Transaction, *ERC20Mock, error) {
+	parsed, err := abi.JSON(strings.NewReader(ERC20MockABI))
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, parsed, common.FromHex(ERC20MockBin), backend, _decimals)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &ERC20Mock{ERC20MockCaller: ERC20MockCaller{contract: contract}, ERC20MockTransactor: ERC20MockTransactor{contract: contract}, ERC20MockFilterer: ERC20MockFilterer{contract: contract}}, nil
+}
+
+// ERC20Mock is an auto generated Go binding around an Ethereum contract.
+type ERC20Mock struct {
+	ERC20MockCaller     // Read-only binding to the contract
+	ERC20MockTransactor // Write-only binding to the contract
+	ERC20MockFilterer   // Log filterer for contract events
+}
+
+// ERC20MockCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ERC20MockCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ERC20MockTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ERC20MockTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ERC20MockFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ERC20MockFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ERC20MockSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ERC20MockSession struct {
+	Contract     *ERC20Mock        // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ERC20MockCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ERC20MockCallerSession struct {
+	Contract *ERC20MockCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts    // Call options to use throughout this session
+}
+
+// ERC20MockTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ERC20MockTransactorSession struct {
+	Contract     *ERC20MockTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts    // Transaction auth options to use throughout this session
+}
+
+// ERC20MockRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ERC20MockRaw struct {
+	Contract *ERC20Mock // Generic contract binding to access the raw methods on
+}
+
+// ERC20MockCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ERC20MockCallerRaw struct {
+	Contract *ERC20MockCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ERC20MockTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ERC20MockTransactorRaw struct {
+	Contract *ERC20MockTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewERC20Mock creates a new instance of ERC20Mock, bound to a specific deployed contract.
+func NewERC20Mock(address common.Address, backend bind.ContractBackend) (*ERC20Mock, error) {
+	contract, err := bindERC20Mock(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20Mock{ERC20MockCaller: ERC20MockCaller{contract: contract}, ERC20MockTransactor: ERC20MockTransactor{contract: contract}, ERC20MockFilterer: ERC20MockFilterer{contract: contract}}, nil
+}
+
+// NewERC20MockCaller creates a new read-only instance of ERC20Mock, bound to a specific deployed contract.
+func NewERC20MockCaller(address common.Address, caller bind.ContractCaller) (*ERC20MockCaller, error) {
+	contract, err := bindERC20Mock(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20MockCaller{contract: contract}, nil
+}
+
+// NewERC20MockTransactor creates a new write-only instance of ERC20Mock, bound to a specific deployed contract.
+func NewERC20MockTransactor(address common.Address, transactor bind.ContractTransactor) (*ERC20MockTransactor, error) {
+	contract, err := bindERC20Mock(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20MockTransactor{contract: contract}, nil
+}
+
+// NewERC20MockFilterer creates a new log filterer instance of ERC20Mock, bound to a specific deployed contract.
+func NewERC20MockFilterer(address common.Address, filterer bind.ContractFilterer) (*ERC20MockFilterer, error) {
+	contract, err := bindERC20Mock(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ERC20MockFilterer{contract: contract}, nil
+}
+
+// bindERC20Mock binds a generic wrapper to an already deployed contract.
+func bindERC20Mock(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ERC20MockABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ERC20Mock *ERC20MockRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ERC20Mock.Contract.ERC20MockCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ERC20Mock *ERC20MockRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.ERC20MockTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ERC20Mock *ERC20MockRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.ERC20MockTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ERC20Mock *ERC20MockCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ERC20Mock.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ERC20Mock *ERC20MockTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ERC20Mock *ERC20MockTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.contract.Transact(opts, method, params...)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_ERC20Mock *ERC20MockCaller) Allowance(opts *bind.CallOpts, arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _ERC20Mock.contract.Call(opts, &out, "allowance", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_ERC20Mock *ERC20MockSession) Allowance(arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	return _ERC20Mock.Contract.Allowance(&_ERC20Mock.CallOpts, arg0, arg1)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_ERC20Mock *ERC20MockCallerSession) Allowance(arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	return _ERC20Mock.Contract.Allowance(&_ERC20Mock.CallOpts, arg0, arg1)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_ERC20Mock *ERC20MockCaller) BalanceOf(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _ERC20Mock.contract.Call(opts, &out, "balanceOf", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_ERC20Mock *ERC20MockSession) BalanceOf(arg0 common.Address) (*big.Int, error) {
+	return _ERC20Mock.Contract.BalanceOf(&_ERC20Mock.CallOpts, arg0)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_ERC20Mock *ERC20MockCallerSession) BalanceOf(arg0 common.Address) (*big.Int, error) {
+	return _ERC20Mock.Contract.BalanceOf(&_ERC20Mock.CallOpts, arg0)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_ERC20Mock *ERC20MockCaller) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _ERC20Mock.contract.Call(opts, &out, "decimals")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_ERC20Mock *ERC20MockSession) Decimals() (uint8, error) {
+	return _ERC20Mock.Contract.Decimals(&_ERC20Mock.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_ERC20Mock *ERC20MockCallerSession) Decimals() (uint8, error) {
+	return _ERC20Mock.Contract.Decimals(&_ERC20Mock.CallOpts)
+}
+
+// TransferFee is a free data retrieval call binding the contract method 0xacb2ad6f.
+//
+// Solidity: function transferFee() view returns(uint256)
+func (_ERC20Mock *ERC20MockCaller) TransferFee(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ERC20Mock.contract.Call(opts, &out, "transferFee")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TransferFee is a free data retrieval call binding the contract method 0xacb2ad6f.
+//
+// Solidity: function transferFee() view returns(uint256)
+func (_ERC20Mock *ERC20MockSession) TransferFee() (*big.Int, error) {
+	return _ERC20Mock.Contract.TransferFee(&_ERC20Mock.CallOpts)
+}
+
+// TransferFee is a free data retrieval call binding the contract method 0xacb2ad6f.
+//
+// Solidity: function transferFee() view returns(uint256)
+func (_ERC20Mock *ERC20MockCallerSession) TransferFee() (*big.Int, error) {
+	return _ERC20Mock.Contract.TransferFee(&_ERC20Mock.CallOpts)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_ERC20Mock *ERC20MockTransactor) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.contract.Transact(opts, "approve", spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_ERC20Mock *ERC20MockSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.Approve(&_ERC20Mock.TransactOpts, spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_ERC20Mock *ERC20MockTransactorSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.Approve(&_ERC20Mock.TransactOpts, spender, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 amount) returns()
+func (_ERC20Mock *ERC20MockTransactor) Mint(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.contract.Transact(opts, "mint", to, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 amount) returns()
+func (_ERC20Mock *ERC20MockSession) Mint(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.Mint(&_ERC20Mock.TransactOpts, to, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 amount) returns()
+func (_ERC20Mock *ERC20MockTransactorSession) Mint(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.Mint(&_ERC20Mock.TransactOpts, to, amount)
+}
+
+// SetTransferFee is a paid mutator transaction binding the contract method 0x8f02bb5b.
+//
+// Solidity: function setTransferFee(uint256 fee) returns()
+func (_ERC20Mock *ERC20MockTransactor) SetTransferFee(opts *bind.TransactOpts, fee *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.contract.Transact(opts, "setTransferFee", fee)
+}
+
+// SetTransferFee is a paid mutator transaction binding the contract method 0x8f02bb5b.
+//
+// Solidity: function setTransferFee(uint256 fee) returns()
+func (_ERC20Mock *ERC20MockSession) SetTransferFee(fee *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.SetTransferFee(&_ERC20Mock.TransactOpts, fee)
+}
+
+// SetTransferFee is a paid mutator transaction binding the contract method 0x8f02bb5b.
+//
+// Solidity: function setTransferFee(uint256 fee) returns()
+func (_ERC20Mock *ERC20MockTransactorSession) SetTransferFee(fee *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.SetTransferFee(&_ERC20Mock.TransactOpts, fee)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_ERC20Mock *ERC20MockTransactor) Transfer(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.contract.Transact(opts, "transfer", to, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_ERC20Mock *ERC20MockSession) Transfer(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.Transfer(&_ERC20Mock.TransactOpts, to, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_ERC20Mock *ERC20MockTransactorSession) Transfer(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.Transfer(&_ERC20Mock.TransactOpts, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_ERC20Mock *ERC20MockTransactor) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.contract.Transact(opts, "transferFrom", from, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_ERC20Mock *ERC20MockSession) TransferFrom(from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.TransferFrom(&_ERC20Mock.TransactOpts, from, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_ERC20Mock *ERC20MockTransactorSession) TransferFrom(from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _ERC20Mock.Contract.TransferFrom(&_ERC20Mock.TransactOpts, from, to, amount)
+}
@@ -1,944 +0,0 @@
-// Code generated - DO NOT EDIT.
-// This file is a generated binding and any manual changes will be lost.
-
-package swap
-
-import (
-	"math/big"
-	"strings"
-
-	ethereum "github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/event"
-)
-
-// Reference imports to suppress errors if they are not otherwise used.
-var (
-	_ = big.NewInt
-	_ = strings.NewReader
-	_ = ethereum.NotFound
-	_ = bind.Bind
-	_ = common.Big1
-	_ = types.BloomLookup
-	_ = event.NewSubscription
-)
-
-// SwapABI is the input ABI used to generate the binding from.
-const SwapABI = "[{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_pubKeyClaim\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_pubKeyRefund\",\"type\":\"bytes32\"},{\"internalType\":\"addresspayable\",\"name\":\"_claimer\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_timeoutDuration\",\"type\":\"uint256\"}],\"stateMutability\":\"payable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"Claimed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"claimKey\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"refundKey\",\"type\":\"bytes32\"}],\"name\":\"Constructed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"b\",\"type\":\"bool\"}],\"name\":\"Ready\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"Refunded\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_s\",\"type\":\"bytes32\"}],\"name\":\"claim\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"isReady\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pubKeyClaim\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pubKeyRefund\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_s\",\"type\":\"bytes32\"}],\"name\":\"refund\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"set_ready\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"timeout_0\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"timeout_1\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]"
-
-// SwapBin is the compiled bytecode used for deploying new contracts.
-var SwapBin = "0x61016060405260008060006101000a81548160ff0219169083151502179055506040516200142838038062001428833981810160405281019062000044919062000289565b3373ffffffffffffffffffffffffffffffffffffffff1660a08173ffffffffffffffffffffffffffffffffffffffff16815250508360e081815250508261010081815250508173ffffffffffffffffffffffffffffffffffffffff1660c08173ffffffffffffffffffffffffffffffffffffffff16815250508042620000cb91906200032a565b6101208181525050600281620000e2919062000387565b42620000ef91906200032a565b610140818152505060405162000105906200019b565b604051809103906000f08015801562000122573d6000803e3d6000fd5b5073ffffffffffffffffffffffffffffffffffffffff1660808173ffffffffffffffffffffffffffffffffffffffff16815250507f8d36aa70807342c3036697a846281194626fd4afa892356ad5979e03831ab080848460405162000189929190620003f9565b60405180910390a15050505062000426565b610393806200109583390190565b600080fd5b6000819050919050565b620001c381620001ae565b8114620001cf57600080fd5b50565b600081519050620001e381620001b8565b92915050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b60006200021682620001e9565b9050919050565b620002288162000209565b81146200023457600080fd5b50565b60008151905062000248816200021d565b92915050565b6000819050919050565b62000263816200024e565b81146200026f57600080fd5b50565b600081519050620002838162000258565b92915050565b60008060008060808587031215620002a657620002a5620001a9565b5b6000620002b687828801620001d2565b9450506020620002c987828801620001d2565b9350506040620002dc8782880162000237565b9250506060620002ef8782880162000272565b91505092959194509250565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b600062000337826200024e565b915062000344836200024e565b9250827fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff038211156200037c576200037b620002fb565b5b828201905092915050565b600062000394826200024e565b9150620003a1836200024e565b9250817fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff0483118215151615620003dd57620003dc620002fb565b5b828202905092915050565b620003f381620001ae565b82525050565b6000604082019050620004106000830185620003e8565b6200041f6020830184620003e8565b9392505050565b60805160a05160c05160e051610100516101205161014051610bd5620004c06000396000818161018b0152818161022b01526105980152600081816101af01528181610255015261052001526000818161016701526102d301526000818161039a01526105fe015260008181610492015261065b0152600081816101d30152818161033001526103d4015260006106c50152610bd56000f3fe608060405234801561001057600080fd5b50600436106100885760003560e01c8063736290f81161005b578063736290f81461010357806374d7c13814610121578063a094a0311461012b578063bd66528a1461014957610088565b806303f7e2461461008d57806345bb8e09146100ab5780634ded8d52146100c95780637249fbb6146100e7575b600080fd5b610095610165565b6040516100a291906107c1565b60405180910390f35b6100b3610189565b6040516100c091906107f5565b60405180910390f35b6100d16101ad565b6040516100de91906107f5565b60405180910390f35b61010160048036038101906100fc9190610841565b6101d1565b005b61010b610398565b60405161011891906107c1565b60405180910390f35b6101296103bc565b005b61013361047f565b6040516101409190610889565b60405180910390f35b610163600480360381019061015e9190610841565b610490565b005b7f000000000000000000000000000000000000000000000000000000000000000081565b7f000000000000000000000000000000000000000000000000000000000000000081565b7f000000000000000000000000000000000000000000000000000000000000000081565b7f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161461022957600080fd5b7f00000000000000000000000000000000000000000000000000000000000000004210158061028e57507f00000000000000000000000000000000000000000000000000000000000000004210801561028d575060008054906101000a900460ff16155b5b6102cd576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016102c490610927565b60405180910390fd5b6102f7817f00000000000000000000000000000000000000000000000000000000000000006106c3565b7ffe509803c09416b28ff3d8f690c8b0c61462a892c46d5430c8fb20abe472daf08160405161032691906107c1565b60405180910390a17f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff166108fc479081150290604051600060405180830381858888f19350505050158015610394573d6000803e3d6000fd5b5050565b7f000000000000000000000000000000000000000000000000000000000000000081565b60008054906101000a900460ff1615801561042257507f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16145b61042b57600080fd5b60016000806101000a81548160ff0219169083151502179055507fb54ee60cc7bf27004d4c21b3226232af966dcdb31a046c95533970b3eea24ae960016040516104759190610889565b60405180910390a1565b60008054906101000a900460ff1681565b7f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161461051e576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161051590610993565b60405180910390fd5b7f000000000000000000000000000000000000000000000000000000000000000042101580610557575060008054906101000a900460ff165b610596576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161058d906109ff565b60405180910390fd5b7f000000000000000000000000000000000000000000000000000000000000000042106105f8576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016105ef90610a6b565b60405180910390fd5b610622817f00000000000000000000000000000000000000000000000000000000000000006106c3565b7feddf608ef698454af2fb41c1df7b7e5154ff0d46969f895e0f39c7dfe7e6380a8160405161065191906107c1565b60405180910390a17f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff166108fc479081150290604051600060405180830381858888f193505050501580156106bf573d6000803e3d6000fd5b5050565b7f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff1663b32d1b4f8360001c8360001c6040518363ffffffff1660e01b8152600401610724929190610a8b565b602060405180830381865afa158015610741573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906107659190610ae0565b6107a4576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161079b90610b7f565b60405180910390fd5b5050565b6000819050919050565b6107bb816107a8565b82525050565b60006020820190506107d660008301846107b2565b92915050565b6000819050919050565b6107ef816107dc565b82525050565b600060208201905061080a60008301846107e6565b92915050565b600080fd5b61081e816107a8565b811461082957600080fd5b50565b60008135905061083b81610815565b92915050565b60006020828403121561085757610856610810565b5b60006108658482850161082c565b91505092915050565b60008115159050919050565b6108838161086e565b82525050565b600060208201905061089e600083018461087a565b92915050565b600082825260208201905092915050565b7f4974277320426f622773207475726e206e6f772c20706c65617365207761697460008201527f2100000000000000000000000000000000000000000000000000000000000000602082015250565b60006109116021836108a4565b915061091c826108b5565b604082019050919050565b6000602082019050818103600083015261094081610904565b9050919050565b7f6f6e6c7920636c61696d65722063616e20636c61696d21000000000000000000600082015250565b600061097d6017836108a4565b915061098882610947565b602082019050919050565b600060208201905081810360008301526109ac81610970565b9050919050565b7f746f6f206561726c7920746f20636c61696d2100000000000000000000000000600082015250565b60006109e96013836108a4565b91506109f4826109b3565b602082019050919050565b60006020820190508181036000830152610a18816109dc565b9050919050565b7f746f6f206c61746520746f20636c61696d210000000000000000000000000000600082015250565b6000610a556012836108a4565b9150610a6082610a1f565b602082019050919050565b60006020820190508181036000830152610a8481610a48565b9050919050565b6000604082019050610aa060008301856107e6565b610aad60208301846107e6565b9392505050565b610abd8161086e565b8114610ac857600080fd5b50565b600081519050610ada81610ab4565b92915050565b600060208284031215610af657610af5610810565b5b6000610b0484828501610acb565b91505092915050565b7f70726f76696465642073656372657420646f6573206e6f74206d61746368207460008201527f6865206578706563746564207075624b65790000000000000000000000000000602082015250565b6000610b696032836108a4565b9150610b7482610b0d565b604082019050919050565b60006020820190508181036000830152610b9881610b5c565b905091905056fea2646970667358221220f3e479ca3bdf78fda3bde7ba25f8da3d3690710a6d422322d1268505f25d15d164736f6c634300080a0033608060405234801561001057600080fd5b50610373806100206000396000f3fe608060405234801561001057600080fd5b506004361061002b5760003560e01c8063b32d1b4f14610030575b600080fd5b61004a600480360381019061004591906101a0565b610060565b60405161005791906101fb565b60405180910390f35b60008060016000601b7f79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f8179860001b7ffffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141806100bc576100bb610216565b5b7f79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798890960001b604051600081526020016040526040516100ff94939291906102f8565b6020604051602081039080840390855afa158015610121573d6000803e3d6000fd5b5050506020604051035190508073ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff161491505092915050565b600080fd5b6000819050919050565b61017d8161016a565b811461018857600080fd5b50565b60008135905061019a81610174565b92915050565b600080604083850312156101b7576101b6610165565b5b60006101c58582860161018b565b92505060206101d68582860161018b565b9150509250929050565b60008115159050919050565b6101f5816101e0565b82525050565b600060208201905061021060008301846101ec565b92915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601260045260246000fd5b6000819050919050565b6000819050919050565b60008160001b9050919050565b600061028161027c61027784610245565b610259565b61024f565b9050919050565b61029181610266565b82525050565b6000819050919050565b600060ff82169050919050565b6000819050919050565b60006102d36102ce6102c984610297565b6102ae565b6102a1565b9050919050565b6102e3816102b8565b82525050565b6102f28161024f565b82525050565b600060808201905061030d6000830187610288565b61031a60208301866102da565b61032760408301856102e9565b61033460608301846102e9565b9594505050505056fea2646970667358221220ee85e0d8d34d053fc1c14aef7a8d86a3f1f8da9061855ddfe8b5075730286b0364736f6c634300080a0033"
-
-// DeploySwap deploys a new Ethereum contract, binding an instance of Swap to it.
-func DeploySwap(auth *bind.TransactOpts, backend bind.ContractBackend, _pubKeyClaim [32]byte, _pubKeyRefund [32]byte, _claimer common.Address, _timeoutDuration *big.Int) (common.Address, *types.Transaction, *Swap, error) {
-	parsed, err := abi.JSON(strings.NewReader(SwapABI))
-	if err != nil {
-		return common.Address{}, nil, nil, err
-	}
-
-	address, tx, contract, err := bind.DeployContract(auth, parsed, common.FromHex(SwapBin), backend, _pubKeyClaim, _pubKeyRefund, _claimer, _timeoutDuration)
-	if err != nil {
-		return common.Address{}, nil, nil, err
-	}
-	return address, tx, &Swap{SwapCaller: SwapCaller{contract: contract}, SwapTransactor: SwapTransactor{contract: contract}, SwapFilterer: SwapFilterer{contract: contract}}, nil
-}
-
-// Swap is an auto generated Go binding around an Ethereum contract.
-type Swap struct {
-	SwapCaller     // Read-only binding to the contract
-	SwapTransactor // Write-only binding to the contract
-	SwapFilterer   // Log filterer for contract events
-}
-
-// SwapCaller is an auto generated read-only Go binding around an Ethereum contract.
-type SwapCaller struct {
-	contract *bind.BoundContract // Generic contract wrapper for the low level calls
-}
-
-// SwapTransactor is an auto generated write-only Go binding around an Ethereum contract.
-type SwapTransactor struct {
-	contract *bind.BoundContract // Generic contract wrapper for the low level calls
-}
-
-// SwapFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
-type SwapFilterer struct {
-	contract *bind.BoundContract // Generic contract wrapper for the low level calls
-}
-
-// SwapSession is an auto generated Go binding around an Ethereum contract,
-// with pre-set call and transact options.
-type SwapSession struct {
-	Contract     *Swap             // Generic contract binding to set the session for
-	CallOpts     bind.CallOpts     // Call options to use throughout this session
-	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
-}
-
-// SwapCallerSession is an auto generated read-only Go binding around an Ethereum contract,
-// with pre-set call options.
-type SwapCallerSession struct {
-	Contract *SwapCaller   // Generic contract caller binding to set the session for
-	CallOpts bind.CallOpts // Call options to use throughout this session
-}
-
-// SwapTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
-// with pre-set transact options.
-type SwapTransactorSession struct {
-	Contract     *SwapTransactor   // Generic contract transactor binding to set the session for
-	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
-}
-
-// SwapRaw is an auto generated low-level Go binding around an Ethereum contract.
-type SwapRaw struct {
-	Contract *Swap // Generic contract binding to access the raw methods on
-}
-
-// SwapCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
-type SwapCallerRaw struct {
-	Contract *SwapCaller // Generic read-only contract binding to access the raw methods on
-}
-
-// SwapTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
-type SwapTransactorRaw struct {
-	Contract *SwapTransactor // Generic write-only contract binding to access the raw methods on
-}
-
-// NewSwap creates a new instance of Swap, bound to a specific deployed contract.
-func NewSwap(address common.Address, backend bind.ContractBackend) (*Swap, error) {
-	contract, err := bindSwap(address, backend, backend, backend)
-	if err != nil {
-		return nil, err
-	}
-	return &Swap{SwapCaller: SwapCaller{contract: contract}, SwapTransactor: SwapTransactor{contract: contract}, SwapFilterer: SwapFilterer{contract: contract}}, nil
-}
-
-// NewSwapCaller creates a new read-only instance of Swap, bound to a specific deployed contract.
-func NewSwapCaller(address common.Address, caller bind.ContractCaller) (*SwapCaller, error) {
-	contract, err := bindSwap(address, caller, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-	return &SwapCaller{contract: contract}, nil
-}
-
-// NewSwapTransactor creates a new write-only instance of Swap, bound to a specific deployed contract.
-func NewSwapTransactor(address common.Address, transactor bind.ContractTransactor) (*SwapTransactor, error) {
-	contract, err := bindSwap(address, nil, transactor, nil)
-	if err != nil {
-		return nil, err
-	}
-	return &SwapTransactor{contract: contract}, nil
-}
-
-// NewSwapFilterer creates a new log filterer instance of Swap, bound to a specific deployed contract.
-func NewSwapFilterer(address common.Address, filterer bind.ContractFilterer) (*SwapFilterer, error) {
-	contract, err := bindSwap(address, nil, nil, filterer)
-	if err != nil {
-		return nil, err
-	}
-	return &SwapFilterer{contract: contract}, nil
-}
-
-// bindSwap binds a generic wrapper to an already deployed contract.
-func bindSwap(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
-	parsed, err := abi.JSON(strings.NewReader(SwapABI))
-	if err != nil {
-		return nil, err
-	}
-	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
-}
-
-// Call invokes the (constant) contract method with params as input values and
-// sets the output to result. The result type might be a single field for simple
-// returns, a slice of interfaces for anonymous returns and a struct for named
-// returns.
-func (_Swap *SwapRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
-	return _Swap.Contract.SwapCaller.contract.Call(opts, result, method, params...)
-}
-
-// Transfer initiates a plain transaction to move funds to the contract, calling
-// its default method if one is available.
-func (_Swap *SwapRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
-	return _Swap.Contract.SwapTransactor.contract.Transfer(opts)
-}
-
-// Transact invokes the (paid) contract method with params as input values.
-func (_Swap *SwapRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
-	return _Swap.Contract.SwapTransactor.contract.Transact(opts, method, params...)
-}
-
-// Call invokes the (constant) contract method with params as input values and
-// sets the output to result. The result type might be a single field for simple
-// returns, a slice of interfaces for anonymous returns and a struct for named
-// returns.
-func (_Swap *SwapCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
-	return _Swap.Contract.contract.Call(opts, result, method, params...)
-}
-
-// Transfer initiates a plain transaction to move funds to the contract, calling
-// its default method if one is available.
-func (_Swap *SwapTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
-	return _Swap.Contract.contract.Transfer(opts)
-}
-
-// Transact invokes the (paid) contract method with params as input values.
-func (_Swap *SwapTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
-	return _Swap.Contract.contract.Transact(opts, method, params...)
-}
-
-// IsReady is a free data retrieval call binding the contract method 0xa094a031.
-//
-// Solidity: function isReady() view returns(bool)
-func (_Swap *SwapCaller) IsReady(opts *bind.CallOpts) (bool, error) {
-	var out []interface{}
-	err := _Swap.contract.Call(opts, &out, "isReady")
-
-	if err != nil {
-		return *new(bool), err
-	}
-
-	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
-
-	return out0, err
-
-}
-
-// IsReady is a free data retrieval call binding the contract method 0xa094a031.
-//
-// Solidity: function isReady() view returns(bool)
-func (_Swap *SwapSession) IsReady() (bool, error) {
-	return _Swap.Contract.IsReady(&_Swap.CallOpts)
-}
-
-// IsReady is a free data retrieval call binding the contract method 0xa094a031.
-//
-// Solidity: function isReady() view returns(bool)
-func (_Swap *SwapCallerSession) IsReady() (bool, error) {
-	return _Swap.Contract.IsReady(&_Swap.CallOpts)
-}
-
-// PubKeyClaim is a free data retrieval call binding the contract method 0x736290f8.
-//
-// Solidity: function pubKeyClaim() view returns(bytes32)
-func (_Swap *SwapCaller) PubKeyClaim(opts *bind.CallOpts) ([32]byte, error) {
-	var out []interface{}
-	err := _Swap.contract.Call(opts, &out, "pubKeyClaim")
-
-	if err != nil {
-		return *new([32]byte), err
-	}
-
-	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
-
-	return out0, err
-
-}
-
-// PubKeyClaim is a free data retrieval call binding the contract method 0x736290f8.
-//
-// Solidity: function pubKeyClaim() view returns(bytes32)
-func (_Swap *SwapSession) PubKeyClaim() ([32]byte, error) {
-	return _Swap.Contract.PubKeyClaim(&_Swap.CallOpts)
-}
-
-// PubKeyClaim is a free data retrieval call binding the contract method 0x736290f8.
-//
-// Solidity: function pubKeyClaim() view returns(bytes32)
-func (_Swap *SwapCallerSession) PubKeyClaim() ([32]byte, error) {
-	return _Swap.Contract.PubKeyClaim(&_Swap.CallOpts)
-}
-
-// PubKeyRefund is a free data retrieval call binding the contract method 0x03f7e246.
-//
-// Solidity: function pubKeyRefund() view returns(bytes32)
-func (_Swap *SwapCaller) PubKeyRefund(opts *bind.CallOpts) ([32]byte, error) {
-	var out []interface{}
-	err := _Swap.contract.Call(opts, &out, "pubKeyRefund")
-
-	if err != nil {
-		return *new([32]byte), err
-	}
-
-	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
-
-	return out0, err
-
-}
-
-// PubKeyRefund is a free data retrieval call binding the contract method 0x03f7e246.
-//
-// Solidity: function pubKeyRefund() view returns(bytes32)
-func (_Swap *SwapSession) PubKeyRefund() ([32]byte, error) {
-	return _Swap.Contract.PubKeyRefund(&_Swap.CallOpts)
-}
-
-// PubKeyRefund is a free data retrieval call binding the contract method 0x03f7e246.
-//
-// Solidity: function pubKeyRefund() view returns(bytes32)
-func (_Swap *SwapCallerSession) PubKeyRefund() ([32]byte, error) {
-	return _Swap.Contract.PubKeyRefund(&_Swap.CallOpts)
-}
-
-// Timeout0 is a free data retrieval call binding the contract method 0x4ded8d52.
-//
-// Solidity: function timeout_0() view returns(uint256)
-func (_Swap *SwapCaller) Timeout0(opts *bind.CallOpts) (*big.Int, error) {
-	var out []interface{}
-	err := _Swap.contract.Call(opts, &out, "timeout_0")
-
-	if err != nil {
-		return *new(*big.Int), err
-	}
-
-	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
-
-	return out0, err
-
-}
-
-// Timeout0 is a free data retrieval call binding the contract method 0x4ded8d52.
-//
-// Solidity: function timeout_0() view returns(uint256)
-func (_Swap *SwapSession) Timeout0() (*big.Int, error) {
-	return _Swap.Contract.Timeout0(&_Swap.CallOpts)
-}
-
-// Timeout0 is a free data retrieval call binding the contract method 0x4ded8d52.
-//
-// Solidity: function timeout_0() view returns(uint256)
-func (_Swap *SwapCallerSession) Timeout0() (*big.Int, error) {
-	return _Swap.Contract.Timeout0(&_Swap.CallOpts)
-}
-
-// Timeout1 is a free data retrieval call binding the contract method 0x45bb8e09.
-//
-// Solidity: function timeout_1() view returns(uint256)
-func (_Swap *SwapCaller) Timeout1(opts *bind.CallOpts) (*big.Int, error) {
-	var out []interface{}
-	err := _Swap.contract.Call(opts, &out, "timeout_1")
-
-	if err != nil {
-		return *new(*big.Int), err
-	}
-
-	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
-
-	return out0, err
-
-}
-
-// Timeout1 is a free data retrieval call binding the contract method 0x45bb8e09.
-//
-// Solidity: function timeout_1() view returns(uint256)
-func (_Swap *SwapSession) Timeout1() (*big.Int, error) {
-	return _Swap.Contract.Timeout1(&_Swap.CallOpts)
-}
-
-// Timeout1 is a free data retrieval call binding the contract method 0x45bb8e09.
-//
-// Solidity: function timeout_1() view returns(uint256)
-func (_Swap *SwapCallerSession) Timeout1() (*big.Int, error) {
-	return _Swap.Contract.Timeout1(&_Swap.CallOpts)
-}
-
-// Claim is a paid mutator transaction binding the contract method 0xbd66528a.
-//
-// Solidity: function claim(bytes32 _s) returns()
-func (_Swap *SwapTransactor) Claim(opts *bind.TransactOpts, _s [32]byte) (*types.Transaction, error) {
-	return _Swap.contract.Transact(opts, "claim", _s)
-}
-
-// Claim is a paid mutator transaction binding the contract method 0xbd66528a.
-//
-// Solidity: function claim(bytes32 _s) returns()
-func (_Swap *SwapSession) Claim(_s [32]byte) (*types.Transaction, error) {
-	return _Swap.Contract.Claim(&_Swap.TransactOpts, _s)
-}
-
-// Claim is a paid mutator transaction binding the contract method 0xbd66528a.
-//
-// Solidity: function claim(bytes32 _s) returns()
-func (_Swap *SwapTransactorSession) Claim(_s [32]byte) (*types.Transaction, error) {
-	return _Swap.Contract.Claim(&_Swap.TransactOpts, _s)
-}
-
-// Refund is a paid mutator transaction binding the contract method 0x7249fbb6.
-//
-// Solidity: function refund(bytes32 _s) returns()
-func (_Swap *SwapTransactor) Refund(opts *bind.TransactOpts, _s [32]byte) (*types.Transaction, error) {
-	return _Swap.contract.Transact(opts, "refund", _s)
-}
-
-// Refund is a paid mutator transaction binding the contract method 0x7249fbb6.
-//
-// Solidity: function refund(bytes32 _s) returns()
-func (_Swap *SwapSession) Refund(_s [32]byte) (*types.Transaction, error) {
-	return _Swap.Contract.Refund(&_Swap.TransactOpts, _s)
-}
-
-// Refund is a paid mutator transaction binding the contract method 0x7249fbb6.
-//
-// Solidity: function refund(bytes32 _s) returns()
-func (_Swap *SwapTransactorSession) Refund(_s [32]byte) (*types.Transaction, error) {
-	return _Swap.Contract.Refund(&_Swap.TransactOpts, _s)
-}
-
-// SetReady is a paid mutator transaction binding the contract method 0x74d7c138.
-//
-// Solidity: function set_ready() returns()
-func (_Swap *SwapTransactor) SetReady(opts *bind.TransactOpts) (*types.Transaction, error) {
-	return _Swap.contract.Transact(opts, "set_ready")
-}
-
-// SetReady is a paid mutator transaction binding the contract method 0x74d7c138.
-//
-// Solidity: function set_ready() returns()
-func (_Swap *SwapSession) SetReady() (*types.Transaction, error) {
-	return _Swap.Contract.SetReady(&_Swap.TransactOpts)
-}
-
-// SetReady is a paid mutator transaction binding the contract method 0x74d7c138.
-//
-// Solidity: function set_ready() returns()
-func (_Swap *SwapTransactorSession) SetReady() (*types.Transaction, error) {
-	return _Swap.Contract.SetReady(&_Swap.TransactOpts)
-}
-
-// SwapClaimedIterator is returned from FilterClaimed and is used to iterate over the raw logs and unpacked data for Claimed events raised by the Swap contract.
-type SwapClaimedIterator struct {
-	Event *SwapClaimed // Event containing the contract specifics and raw log
-
-	contract *bind.BoundContract // Generic contract to use for unpacking event data
-	event    string              // Event name to use for unpacking event data
-
-	logs chan types.Log        // Log channel receiving the found contract events
-	sub  ethereum.Subscription // Subscription for errors, completion and termination
-	done bool                  // Whether the subscription completed delivering logs
-	fail error                 // Occurred error to stop iteration
-}
-
-// Next advances the iterator to the subsequent event, returning whether there
-// are any more events found. In case of a retrieval or parsing error, false is
-// returned and Error() can be queried for the exact failure.
-func (it *SwapClaimedIterator) Next() bool {
-	// If the iterator failed, stop iterating
-	if it.fail != nil {
-		return false
-	}
-	// If the iterator completed, deliver directly whatever's available
-	if it.done {
-		select {
-		case log := <-it.logs:
-			it.Event = new(SwapClaimed)
-			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
-				it.fail = err
-				return false
-			}
-			it.Event.Raw = log
-			return true
-
-		default:
-			return false
-		}
-	}
-	// Iterator still in progress, wait for either a data or an error event
-	select {
-	case log := <-it.logs:
-		it.Event = new(SwapClaimed)
-		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
-			it.fail = err
-			return false
-		}
-		it.Event.Raw = log
-		return true
-
-	case err := <-it.sub.Err():
-		it.done = true
-		it.fail = err
-		return it.Next()
-	}
-}
-
-// Error returns any retrieval or parsing error occurred during filtering.
-func (it *SwapClaimedIterator) Error() error {
-	return it.fail
-}
-
-// Close terminates the iteration process, releasing any pending underlying
-// resources.
-func (it *SwapClaimedIterator) Close() error {
-	it.sub.Unsubscribe()
-	return nil
-}
-
-// SwapClaimed represents a Claimed event raised by the Swap contract.
-type SwapClaimed struct {
-	S   [32]byte
-	Raw types.Log // Blockchain specific contextual infos
-}
-
-// FilterClaimed is a free log retrieval operation binding the contract event 0xeddf608ef698454af2fb41c1df7b7e5154ff0d46969f895e0f39c7dfe7e6380a.
-//
-// Solidity: event Claimed(bytes32 s)
-func (_Swap *SwapFilterer) FilterClaimed(opts *bind.FilterOpts) (*SwapClaimedIterator, error) {
-
-	logs, sub, err := _Swap.contract.FilterLogs(opts, "Claimed")
-	if err != nil {
-		return nil, err
-	}
-	return &SwapClaimedIterator{contract: _Swap.contract, event: "Claimed", logs: logs, sub: sub}, nil
-}
-
-// WatchClaimed is a free log subscription operation binding the contract event 0xeddf608ef698454af2fb41c1df7b7e5154ff0d46969f895e0f39c7dfe7e6380a.
-//
-// Solidity: event Claimed(bytes32 s)
-func (_Swap *SwapFilterer) WatchClaimed(opts *bind.WatchOpts, sink chan<- *SwapClaimed) (event.Subscription, error) {
-
-	logs, sub, err := _Swap.contract.WatchLogs(opts, "Claimed")
-	if err != nil {
-		return nil, err
-	}
-	return event.NewSubscription(func(quit <-chan struct{}) error {
-		defer sub.Unsubscribe()
-		for {
-			select {
-			case log := <-logs:
-				// New log arrived, parse the event and forward to the user
-				event := new(SwapClaimed)
-				if err := _Swap.contract.UnpackLog(event, "Claimed", log); err != nil {
-					return err
-				}
-				event.Raw = log
-
-				select {
-				case sink <- event:
-				case err := <-sub.Err():
-					return err
-				case <-quit:
-					return nil
-				}
-			case err := <-sub.Err():
-				return err
-			case <-quit:
-				return nil
-			}
-		}
-	}), nil
-}
-
-// ParseClaimed is a log parse operation binding the contract event 0xeddf608ef698454af2fb41c1df7b7e5154ff0d46969f895e0f39c7dfe7e6380a.
-//
-// Solidity: event Claimed(bytes32 s)
-func (_Swap *SwapFilterer) ParseClaimed(log types.Log) (*SwapClaimed, error) {
-	event := new(SwapClaimed)
-	if err := _Swap.contract.UnpackLog(event, "Claimed", log); err != nil {
-		return nil, err
-	}
-	event.Raw = log
-	return event, nil
-}
-
-// SwapConstructedIterator is returned from FilterConstructed and is used to iterate over the raw logs and unpacked data for Constructed events raised by the Swap contract.
-type SwapConstructedIterator struct {
-	Event *SwapConstructed // Event containing the contract specifics and raw log
-
-	contract *bind.BoundContract // Generic contract to use for unpacking event data
-	event    string              // Event name to use for unpacking event data
-
-	logs chan types.Log        // Log channel receiving the found contract events
-	sub  ethereum.Subscription // Subscription for errors, completion and termination
-	done bool                  // Whether the subscription completed delivering logs
-	fail error                 // Occurred error to stop iteration
-}
-
-// Next advances the iterator to the subsequent event, returning whether there
-// are any more events found. In case of a retrieval or parsing error, false is
-// returned and Error() can be queried for the exact failure.
-func (it *SwapConstructedIterator) Next() bool {
-	// If the iterator failed, stop iterating
-	if it.fail != nil {
-		return false
-	}
-	// If the iterator completed, deliver directly whatever's available
-	if it.done {
-		select {
-		case log := <-it.logs:
-			it.Event = new(SwapConstructed)
-			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
-				it.fail = err
-				return false
-			}
-			it.Event.Raw = log
-			return true
-
-		default:
-			return false
-		}
-	}
-	// Iterator still in progress, wait for either a data or an error event
-	select {
-	case log := <-it.logs:
-		it.Event = new(SwapConstructed)
-		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
-			it.fail = err
-			return false
-		}
-		it.Event.Raw = log
-		return true
-
-	case err := <-it.sub.Err():
-		it.done = true
-		it.fail = err
-		return it.Next()
-	}
-}
-
-// Error returns any retrieval or parsing error occurred during filtering.
-func (it *SwapConstructedIterator) Error() error {
-	return it.fail
-}
-
-// Close terminates the iteration process, releasing any pending underlying
-// resources.
-func (it *SwapConstructedIterator) Close() error {
-	it.sub.Unsubscribe()
-	return nil
-}
-
-// SwapConstructed represents a Constructed event raised by the Swap contract.
-type SwapConstructed struct {
-	ClaimKey  [32]byte
-	RefundKey [32]byte
-	Raw       types.Log // Blockchain specific contextual infos
-}
-
-// FilterConstructed is a free log retrieval operation binding the contract event 0x8d36aa70807342c3036697a846281194626fd4afa892356ad5979e03831ab080.
-//
-// Solidity: event Constructed(bytes32 claimKey, bytes32 refundKey)
-func (_Swap *SwapFilterer) FilterConstructed(opts *bind.FilterOpts) (*SwapConstructedIterator, error) {
-
-	logs, sub, err := _Swap.contract.FilterLogs(opts, "Constructed")
-	if err != nil {
-		return nil, err
-	}
-	return &SwapConstructedIterator{contract: _Swap.contract, event: "Constructed", logs: logs, sub: sub}, nil
-}
-
-// WatchConstructed is a free log subscription operation binding the contract event 0x8d36aa70807342c3036697a846281194626fd4afa892356ad5979e03831ab080.
-//
-// Solidity: event Constructed(bytes32 claimKey, bytes32 refundKey)
-func (_Swap *SwapFilterer) WatchConstructed(opts *bind.WatchOpts, sink chan<- *SwapConstructed) (event.Subscription, error) {
-
-	logs, sub, err := _Swap.contract.WatchLogs(opts, "Constructed")
-	if err != nil {
-		return nil, err
-	}
-	return event.NewSubscription(func(quit <-chan struct{}) error {
-		defer sub.Unsubscribe()
-		for {
-			select {
-			case log := <-logs:
-				// New log arrived, parse the event and forward to the user
-				event := new(SwapConstructed)
-				if err := _Swap.contract.UnpackLog(event, "Constructed", log); err != nil {
-					return err
-				}
-				event.Raw = log
-
-				select {
-				case sink <- event:
-				case err := <-sub.Err():
-					return err
-				case <-quit:
-					return nil
-				}
-			case err := <-sub.Err():
-				return err
-			case <-quit:
-				return nil
-			}
-		}
-	}), nil
-}
-
-// ParseConstructed is a log parse operation binding the contract event 0x8d36aa70807342c3036697a846281194626fd4afa892356ad5979e03831ab080.
-//
-// Solidity: event Constructed(bytes32 claimKey, bytes32 refundKey)
-func (_Swap *SwapFilterer) ParseConstructed(log types.Log) (*SwapConstructed, error) {
-	event := new(SwapConstructed)
-	if err := _Swap.contract.UnpackLog(event, "Constructed", log); err != nil {
-		return nil, err
-	}
-	event.Raw = log
-	return event, nil
-}
-
-// SwapReadyIterator is returned from FilterReady and is used to iterate over the raw logs and unpacked data for Ready events raised by the Swap contract.
-type SwapReadyIterator struct {
-	Event *SwapReady // Event containing the contract specifics and raw log
-
-	contract *bind.BoundContract // Generic contract to use for unpacking event data
-	event    string              // Event name to use for unpacking event data
-
-	logs chan types.Log        // Log channel receiving the found contract events
-	sub  ethereum.Subscription // Subscription for errors, completion and termination
-	done bool                  // Whether the subscription completed delivering logs
-	fail error                 // Occurred error to stop iteration
-}
-
-// Next advances the iterator to the subsequent event, returning whether there
-// are any more events found. In case of a retrieval or parsing error, false is
-// returned and Error() can be queried for the exact failure.
-func (it *SwapReadyIterator) Next() bool {
-	// If the iterator failed, stop iterating
-	if it.fail != nil {
-		return false
-	}
-	// If the iterator completed, deliver directly whatever's available
-	if it.done {
-		select {
-		case log := <-it.logs:
-			it.Event = new(SwapReady)
-			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
-				it.fail = err
-				return false
-			}
-			it.Event.Raw = log
-			return true
-
-		default:
-			return false
-		}
-	}
-	// Iterator still in progress, wait for either a data or an error event
-	select {
-	case log := <-it.logs:
-		it.Event = new(SwapReady)
-		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
-			it.fail = err
-			return false
-		}
-		it.Event.Raw = log
-		return true
-
-	case err := <-it.sub.Err():
-		it.done = true
-		it.fail = err
-		return it.Next()
-	}
-}
-
-// Error returns any retrieval or parsing error occurred during filtering.
-func (it *SwapReadyIterator) Error() error {
-	return it.fail
-}
-
-// Close terminates the iteration process, releasing any pending underlying
-// resources.
-func (it *SwapReadyIterator) Close() error {
-	it.sub.Unsubscribe()
-	return nil
-}
-
-// SwapReady represents a Ready event raised by the Swap contract.
-type SwapReady struct {
-	B   bool
-	Raw types.Log // Blockchain specific contextual infos
-}
-
-// FilterReady is a free log retrieval operation binding the contract event 0xb54ee60cc7bf27004d4c21b3226232af966dcdb31a046c95533970b3eea24ae9.
-//
-// Solidity: event Ready(bool b)
-func (_Swap *SwapFilterer) FilterReady(opts *bind.FilterOpts) (*SwapReadyIterator, error) {
-
-	logs, sub, err := _Swap.contract.FilterLogs(opts, "Ready")
-	if err != nil {
-		return nil, err
-	}
-	return &SwapReadyIterator{contract: _Swap.contract, event: "Ready", logs: logs, sub: sub}, nil
-}
-
-// WatchReady is a free log subscription operation binding the contract event 0xb54ee60cc7bf27004d4c21b3226232af966dcdb31a046c95533970b3eea24ae9.
-//
-// Solidity: event Ready(bool b)
-func (_Swap *SwapFilterer) WatchReady(opts *bind.WatchOpts, sink chan<- *SwapReady) (event.Subscription, error) {
-
-	logs, sub, err := _Swap.contract.WatchLogs(opts, "Ready")
-	if err != nil {
-		return nil, err
-	}
-	return event.NewSubscription(func(quit <-chan struct{}) error {
-		defer sub.Unsubscribe()
-		for {
-			select {
-			case log := <-logs:
-				// New log arrived, parse the event and forward to the user
-				event := new(SwapReady)
-				if err := _Swap.contract.UnpackLog(event, "Ready", log); err != nil {
-					return err
-				}
-				event.Raw = log
-
-				select {
-				case sink <- event:
-				case err := <-sub.Err():
-					return err
-				case <-quit:
-					return nil
-				}
-			case err := <-sub.Err():
-				return err
-			case <-quit:
-				return nil
-			}
-		}
-	}), nil
-}
-
-// ParseReady is a log parse operation binding the contract event 0xb54ee60cc7bf27004d4c21b3226232af966dcdb31a046c95533970b3eea24ae9.
-//
-// Solidity: event Ready(bool b)
-func (_Swap *SwapFilterer) ParseReady(log types.Log) (*SwapReady, error) {
-	event := new(SwapReady)
-	if err := _Swap.contract.UnpackLog(event, "Ready", log); err != nil {
-		return nil, err
-	}
-	event.Raw = log
-	return event, nil
-}
-
-// SwapRefundedIterator is returned from FilterRefunded and is used to iterate over the raw logs and unpacked data for Refunded events raised by the Swap contract.
-type SwapRefundedIterator struct {
-	Event *SwapRefunded // Event containing the contract specifics and raw log
-
-	contract *bind.BoundContract // Generic contract to use for unpacking event data
-	event    string              // Event name to use for unpacking event data
-
-	logs chan types.Log        // Log channel receiving the found contract events
-	sub  ethereum.Subscription // Subscription for errors, completion and termination
-	done bool                  // Whether the subscription completed delivering logs
-	fail error                 // Occurred error to stop iteration
-}
-
-// Next advances the iterator to the subsequent event, returning whether there
-// are any more events found. In case of a retrieval or parsing error, false is
-// returned and Error() can be queried for the exact failure.
-func (it *SwapRefundedIterator) Next() bool {
-	// If the iterator failed, stop iterating
-	if it.fail != nil {
-		return false
-	}
-	// If the iterator completed, deliver directly whatever's available
-	if it.done {
-		select {
-		case log := <-it.logs:
-			it.Event = new(SwapRefunded)
-			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
-				it.fail = err
-				return false
-			}
-			it.Event.Raw = log
-			return true
-
-		default:
-			return false
-		}
-	}
-	// Iterator still in progress, wait for either a data or an error event
-	select {
-	case log := <-it.logs:
-		it.Event = new(SwapRefunded)
-		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
-			it.fail = err
-			return false
-		}
-		it.Event.Raw = log
-		return true
-
-	case err := <-it.sub.Err():
-		it.done = true
-		it.fail = err
-		return it.Next()
-	}
-}
-
-// Error returns any retrieval or parsing error occurred during filtering.
-func (it *SwapRefundedIterator) Error() error {
-	return it.fail
-}
-
-// Close terminates the iteration process, releasing any pending underlying
-// resources.
-func (it *SwapRefundedIterator) Close() error {
-	it.sub.Unsubscribe()
-	return nil
-}
-
-// SwapRefunded represents a Refunded event raised by the Swap contract.
-type SwapRefunded struct {
-	S   [32]byte
-	Raw types.Log // Blockchain specific contextual infos
-}
-
-// FilterRefunded is a free log retrieval operation binding the contract event 0xfe509803c09416b28ff3d8f690c8b0c61462a892c46d5430c8fb20abe472daf0.
-//
-// Solidity: event Refunded(bytes32 s)
-func (_Swap *SwapFilterer) FilterRefunded(opts *bind.FilterOpts) (*SwapRefundedIterator, error) {
-
-	logs, sub, err := _Swap.contract.FilterLogs(opts, "Refunded")
-	if err != nil {
-		return nil, err
-	}
-	return &SwapRefundedIterator{contract: _Swap.contract, event: "Refunded", logs: logs, sub: sub}, nil
-}
-
-// WatchRefunded is a free log subscription operation binding the contract event 0xfe509803c09416b28ff3d8f690c8b0c61462a892c46d5430c8fb20abe472daf0.
-//
-// Solidity: event Refunded(bytes32 s)
-func (_Swap *SwapFilterer) WatchRefunded(opts *bind.WatchOpts, sink chan<- *SwapRefunded) (event.Subscription, error) {
-
-	logs, sub, err := _Swap.contract.WatchLogs(opts, "Refunded")
-	if err != nil {
-		return nil, err
-	}
-	return event.NewSubscription(func(quit <-chan struct{}) error {
-		defer sub.Unsubscribe()
-		for {
-			select {
-			case log := <-logs:
-				// New log arrived, parse the event and forward to the user
-				event := new(SwapRefunded)
-				if err := _Swap.contract.UnpackLog(event, "Refunded", log); err != nil {
-					return err
-				}
-				event.Raw = log
-
-				select {
-				case sink <- event:
-				case err := <-sub.Err():
-					return err
-				case <-quit:
-					return nil
-				}
-			case err := <-sub.Err():
-				return err
-			case <-quit:
-				return nil
-			}
-		}
-	}), nil
-}
-
-// ParseRefunded is a log parse operation binding the contract event 0xfe509803c09416b28ff3d8f690c8b0c61462a892c46d5430c8fb20abe472daf0.
-//
-// Solidity: event Refunded(bytes32 s)
-func (_Swap *SwapFilterer) ParseRefunded(log types.Log) (*SwapRefunded, error) {
-	event := new(SwapRefunded)
-	if err := _Swap.contract.UnpackLog(event, "Refunded", log); err != nil {
-		return nil, err
-	}
-	event.Raw = log
-	return event, nil
-}
@@ -0,0 +1,999 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package swap
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// SwapFactoryABI is the input ABI used to generate the binding from.
+const SwapFactoryABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"swapID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"Claimed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"swapID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"claimKey\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"refundKey\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timeout0\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"New\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"swapID\",\"type\":\"bytes32\"}],\"name\":\"Ready\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"swapID\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"Refunded\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_swapID\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_s\",\"type\":\"bytes32\"}],\"name\":\"claim\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"scalar\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"qKeccak\",\"type\":\"uint256\"}],\"name\":\"mulVerify\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_pubKeyClaim\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_pubKeyRefund\",\"type\":\"bytes32\"},{\"internalType\":\"addresspayable\",\"name\":\"_claimer\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_timeoutDuration\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"_asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_value\",\"type\":\"uint256\"}],\"name\":\"newSwap\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_swapID\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_s\",\"type\":\"bytes32\"}],\"name\":\"refund\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_swapID\",\"type\":\"bytes32\"}],\"name\":\"setReady\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"swapCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"swaps\",\"outputs\":[{\"internalType\":\"addresspayable\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"addresspayable\",\"name\":\"claimer\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"pubKeyClaim\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"pubKeyRefund\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"timeout0\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timeout1\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"enumSwapFactory.Stage\",\"name\":\"stage\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+// SwapFactoryBin is the compiled bytecode used for deploying new contracts.
+var SwapFactoryBin = "0x608060405234801561001057600080fd5b506121cc806100206000396000f3fe6080604052600436106100705760003560e01c8063b13700401161004e578063b1370040146100f2578063b32d1b4f14610122578063e4683a791461015f578063eb84e7f21461018857610070565b80632eff0d9e146100755780636bcfee15146100a057806384cc9dfb146100c9575b600080fd5b34801561008157600080fd5b5061008a6101cd565b6040516100979190611242565b60405180910390f35b3480156100ac57600080fd5b506100c760048036038101906100c29190611298565b6101d3565b005b3480156100d557600080fd5b506100f060048036038101906100eb91906112c5565b610351565b005b61010c600480360381019061010791906113cd565b61064d565b6040516101199190611469565b60405180910390f35b34801561012e57600080fd5b5061014960048036038101906101449190611484565b610b13565b60405161015691906114df565b60405180910390f35b34801561016b57600080fd5b50610186600480360381019061018191906112c5565b610c18565b005b34801561019457600080fd5b506101af60048036038101906101aa9190611298565b610edd565b6040516101c49998979695949392919061158f565b60405180910390f35b60015481565b60008060008381526020019081526020016000209050600160038111156101fd576101fc611518565b5b8160080160009054906101000a900460ff16600381111561022157610220611518565b5b14610261576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161025890611679565b60405180910390fd5b8060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16146102f3576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016102ea9061170b565b60405180910390fd5b60028160080160006101000a81548160ff0219169083600381111561031b5761031a611518565b5b0217905550817f5fc23b25552757626e08b316cc2387ad1bc70ee1594af7204db4ce0c39f5d15f60405160405180910390a25050565b600080600084815260200190815260200160002090506001600381111561037b5761037a611518565b5b8160080160009054906101000a900460ff16600381111561039f5761039e611518565b5b14806103e05750600260038111156103ba576103b9611518565b5b8160080160009054906101000a900460ff1660038111156103de576103dd611518565b5b145b61041f576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161041690611777565b60405180910390fd5b8060010160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16146104b1576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016104a8906117e3565b60405180910390fd5b8060040154421015806104f95750600260038111156104d3576104d2611518565b5b8160080160009054906101000a900460ff1660038111156104f7576104f6611518565b5b145b610538576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161052f9061184f565b60405180910390fd5b8060050154421061057e576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610575906118bb565b60405180910390fd5b61058c828260020154610f98565b60038160080160006101000a81548160ff021916908360038111156105b4576105b3611518565b5b0217905550827f38d6042dbdae8e73a7f6afbabd3fbe0873f9f5ed3cd71294591c3908c2e65fee836040516105e99190611469565b60405180910390a26106488160060160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff168260010160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff168360070154610feb565b505050565b6000808211610691576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161068890611927565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff160361070c57813414610707576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016106fe90611993565b60405180910390fd5b610918565b6000341461074f576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161074690611a25565b60405180910390fd5b60008373ffffffffffffffffffffffffffffffffffffffff166370a08231306040518263ffffffff1660e01b815260040161078a9190611a45565b602060405180830381865afa1580156107a7573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906107cb9190611a75565b9050610850846323b872dd60e01b3330876040516024016107ee93929190611aa2565b604051602081830303815290604052907bffffffffffffffffffffffffffffffffffffffffffffffffffffffff19166020820180517bffffffffffffffffffffffffffffffffffffffffffffffffffffffff83818316178352505050506110f2565b82818573ffffffffffffffffffffffffffffffffffffffff166370a08231306040518263ffffffff1660e01b815260040161088b9190611a45565b602060405180830381865afa1580156108a8573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906108cc9190611a75565b6108d69190611b08565b14610916576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161090d90611bae565b60405180910390fd5b505b60003060015460405160200161092f929190611bce565b6040516020818303038152906040528051906020012090506001600081548092919061095a90611bf7565b919050555060008060008381526020019081526020016000209050338160000160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550868160010160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff1602179055508881600201819055508781600301819055508542610a199190611c3f565b8160040181905550600286610a2e9190611c73565b42610a399190611c3f565b8160050181905550848160060160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555083816007018190555060018160080160006101000a81548160ff02191690836003811115610ab557610ab4611518565b5b0217905550817f91446ce035ac29998b5473504609a5ef5e961005daba4630a1684b63be848f568a8a846004015485600501548a8a604051610afc96959493929190611cb5565b60405180910390a281925050509695505050505050565b60008060016000601b7f79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f8179860001b7ffffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd036414180610b6f57610b6e611d16565b5b7f79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798890960001b60405160008152602001604052604051610bb29493929190611ddf565b6020604051602081039080840390855afa158015610bd4573d6000803e3d6000fd5b5050506020604051035190508073ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff161491505092915050565b6000806000848152602001908152602001600020905060016003811115610c4257610c41611518565b5b8160080160009054906101000a900460ff166003811115610c6657610c65611518565b5b1480610ca7575060026003811115610c8157610c80611518565b5b8160080160009054906101000a900460ff166003811115610ca557610ca4611518565b5b145b610ce6576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610cdd90611777565b60405180910390fd5b8060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614610d78576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610d6f90611e70565b60405180910390fd5b806005015442101580610dd05750806004015442108015610dcf575060026003811115610da857610da7611518565b5b8160080160009054906101000a900460ff166003811115610dcc57610dcb611518565b5b14155b5b610e0f576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610e0690611f02565b60405180910390fd5b610e1d828260030154610f98565b60038160080160006101000a81548160ff02191690836003811115610e4557610e44611518565b5b0217905550827e7c875846b687732a7579c19bb1dade66cd14e9f4f809565e2b2b5e76c72b4f83604051610e799190611469565b60405180910390a2610ed88160060160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff168260000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff168360070154610feb565b505050565b60006020528060005260406000206000915090508060000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff16908060010160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff16908060020154908060030154908060040154908060050154908060060160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff16908060070154908060080160009054906101000a900460ff16905089565b610fa88260001c8260001c610b13565b610fe7576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610fde90611f94565b60405180910390fd5b5050565b600073ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff160361106b578173ffffffffffffffffffffffffffffffffffffffff166108fc829081150290604051600060405180830381858888f19350505050158015611065573d6000803e3d6000fd5b506110ed565b6110ec8363a9059cbb60e01b848460405160240161108a929190611fb4565b604051602081830303815290604052907bffffffffffffffffffffffffffffffffffffffffffffffffffffffff19166020820180517bffffffffffffffffffffffffffffffffffffffffffffffffffffffff83818316178352505050506110f2565b5b505050565b60008273ffffffffffffffffffffffffffffffffffffffff163b1161114c576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161114390612029565b60405180910390fd5b6000808373ffffffffffffffffffffffffffffffffffffffff168360405161117491906120ba565b6000604051808303816000865af19150503d80600081146111b1576040519150601f19603f3d011682016040523d82523d6000602084013e6111b6565b606091505b50915091508180156111e457506000815114806111e35750808060200190518101906111e291906120fd565b5b5b611223576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161121a90612176565b60405180910390fd5b50505050565b6000819050919050565b61123c81611229565b82525050565b60006020820190506112576000830184611233565b92915050565b600080fd5b6000819050919050565b61127581611262565b811461128057600080fd5b50565b6000813590506112928161126c565b92915050565b6000602082840312156112ae576112ad61125d565b5b60006112bc84828501611283565b91505092915050565b600080604083850312156112dc576112db61125d565b5b60006112ea85828601611283565b92505060206112fb85828601611283565b9150509250929050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600061133082611305565b9050919050565b61134081611325565b811461134b57600080fd5b50565b60008135905061135d81611337565b92915050565b61136c81611229565b811461137757600080fd5b50565b60008135905061138981611363565b92915050565b600061139a82611305565b9050919050565b6113aa8161138f565b81146113b557600080fd5b50565b6000813590506113c7816113a1565b92915050565b60008060008060008060c087890312156113ea576113e961125d565b5b60006113f889828a01611283565b965050602061140989828a01611283565b955050604061141a89828a0161134e565b945050606061142b89828a0161137a565b935050608061143c89828a016113b8565b92505060a061144d89828a0161137a565b9150509295509295509295565b61146381611262565b82525050565b600060208201905061147e600083018461145a565b92915050565b6000806040838503121561149b5761149a61125d565b5b60006114a98582860161137a565b92505060206114ba8582860161137a565b9150509250929050565b60008115159050919050565b6114d9816114c4565b82525050565b60006020820190506114f460008301846114d0565b92915050565b61150381611325565b82525050565b6115128161138f565b82525050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602160045260246000fd5b6004811061155857611557611518565b5b50565b600081905061156982611547565b919050565b60006115798261155b565b9050919050565b6115898161156e565b82525050565b6000610120820190506115a5600083018c6114fa565b6115b2602083018b6114fa565b6115bf604083018a61145a565b6115cc606083018961145a565b6115d96080830188611233565b6115e660a0830187611233565b6115f360c0830186611509565b61160060e0830185611233565b61160e610100830184611580565b9a9950505050505050505050565b600082825260208201905092915050565b7f73776170206973206e6f742070656e64696e6700000000000000000000000000600082015250565b600061166360138361161c565b915061166e8261162d565b602082019050919050565b6000602082019050818103600083015261169281611656565b9050919050565b7f6f6e6c79207468652073776170206f776e65722063616e20736574207265616460008201527f7900000000000000000000000000000000000000000000000000000000000000602082015250565b60006116f560218361161c565b915061170082611699565b604082019050919050565b60006020820190508181036000830152611724816116e8565b9050919050565b7f73776170206973206e6f7420696e2070726f6772657373000000000000000000600082015250565b600061176160178361161c565b915061176c8261172b565b602082019050919050565b6000602082019050818103600083015261179081611754565b9050919050565b7f6f6e6c7920636c61696d65722063616e20636c61696d21000000000000000000600082015250565b60006117cd60178361161c565b91506117d882611797565b602082019050919050565b600060208201905081810360008301526117fc816117c0565b9050919050565b7f746f6f206561726c7920746f20636c61696d2100000000000000000000000000600082015250565b600061183960138361161c565b915061184482611803565b602082019050919050565b600060208201905081810360008301526118688161182c565b9050919050565b7f746f6f206c61746520746f20636c61696d210000000000000000000000000000600082015250565b60006118a560128361161c565b91506118b08261186f565b602082019050919050565b600060208201905081810360008301526118d481611898565b9050919050565b7f76616c7565206d757374206265206e6f6e2d7a65726f00000000000000000000600082015250565b600061191160168361161c565b915061191c826118db565b602082019050919050565b6000602082019050818103600083015261194081611904565b9050919050565b7f76616c7565206e6f742073616d652061732065746865722073656e7400000000600082015250565b600061197d601c8361161c565b915061198882611947565b602082019050919050565b600060208201905081810360008301526119ac81611970565b9050919050565b7f63616e6e6f742073656e6420657468657220746f206120746f6b656e2073776160008201527f7000000000000000000000000000000000000000000000000000000000000000602082015250565b6000611a0f60218361161c565b9150611a1a826119b3565b604082019050919050565b60006020820190508181036000830152611a3e81611a02565b9050919050565b6000602082019050611a5a6000830184611509565b92915050565b600081519050611a6f81611363565b92915050565b600060208284031215611a8b57611a8a61125d565b5b6000611a9984828501611a60565b91505092915050565b6000606082019050611ab76000830186611509565b611ac46020830185611509565b611ad16040830184611233565b949350505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b6000611b1382611229565b9150611b1e83611229565b9250828203905081811115611b3657611b35611ad9565b5b92915050565b7f726563656976656420746f6b656e20616d6f756e74206e6f742073616d65206160008201527f732076616c756500000000000000000000000000000000000000000000000000602082015250565b6000611b9860278361161c565b9150611ba382611b3c565b604082019050919050565b60006020820190508181036000830152611bc781611b8b565b9050919050565b6000604082019050611be36000830185611509565b611bf06020830184611233565b9392505050565b6000611c0282611229565b91507fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8203611c3457611c33611ad9565b5b600182019050919050565b6000611c4a82611229565b9150611c5583611229565b9250828201905080821115611c6d57611c6c611ad9565b5b92915050565b6000611c7e82611229565b9150611c8983611229565b9250828202611c9781611229565b91508282048414831517611cae57611cad611ad9565b5b5092915050565b600060c082019050611cca600083018961145a565b611cd7602083018861145a565b611ce46040830187611233565b611cf16060830186611233565b611cfe6080830185611509565b611d0b60a0830184611233565b979650505050505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601260045260246000fd5b6000819050919050565b60008160001b9050919050565b6000611d77611d72611d6d84611d45565b611d4f565b611262565b9050919050565b611d8781611d5c565b82525050565b6000819050919050565b600060ff82169050919050565b6000819050919050565b6000611dc9611dc4611dbf84611d8d565b611da4565b611d97565b9050919050565b611dd981611dae565b82525050565b6000608082019050611df46000830187611d7e565b611e016020830186611dd0565b611e0e604083018561145a565b611e1b606083018461145a565b95945050505050565b7f6f6e6c79207468652073776170206f776e65722063616e20726566756e640000600082015250565b6000611e5a601e8361161c565b9150611e6582611e24565b602082019050919050565b60006020820190508181036000830152611e8981611e4d565b9050919050565b7f4974277320426f622773207475726e206e6f772c20706c65617365207761697460008201527f2100000000000000000000000000000000000000000000000000000000000000602082015250565b6000611eec60218361161c565b9150611ef782611e90565b604082019050919050565b60006020820190508181036000830152611f1b81611edf565b9050919050565b7f70726f76696465642073656372657420646f6573206e6f74206d61746368207460008201527f6865206578706563746564207075624b65790000000000000000000000000000602082015250565b6000611f7e60328361161c565b9150611f8982611f22565b604082019050919050565b60006020820190508181036000830152611fad81611f71565b9050919050565b6000604082019050611fc960008301856114fa565b611fd66020830184611233565b9392505050565b7f746f6b656e206973206e6f74206120636f6e7472616374000000000000000000600082015250565b600061201360178361161c565b915061201e82611fdd565b602082019050919050565b6000602082019050818103600083015261204281612006565b9050919050565b600081519050919050565b600081905092915050565b60005b8381101561207d578082015181840152602081019050612062565b60008484015250505050565b600061209482612049565b61209e8185612054565b93506120ae81856020860161205f565b80840191505092915050565b60006120c68284612089565b915081905092915050565b6120da816114c4565b81146120e557600080fd5b50565b6000815190506120f7816120d1565b92915050565b6000602082840312156121135761211261125d565b5b6000612121848285016120e8565b91505092915050565b7f746f6b656e207472616e73666572206661696c65640000000000000000000000600082015250565b600061216060158361161c565b915061216b8261212a565b602082019050919050565b6000602082019050818103600083015261218f81612153565b905091905056fea2646970667358221220e48041a11377714aa7ba9ab3374e330ad5d62409a1cdba037317aceb4084c3b364736f6c63430008150033"
+
+// DeploySwapFactory deploys a new Ethereum contract, binding an instance of SwapFactory to it.
+func DeploySwapFactory(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *SwapFactory, error) {
+	parsed, err := abi.JSON(strings.NewReader(SwapFactoryABI))
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, parsed, common.FromHex(SwapFactoryBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &SwapFactory{SwapFactoryCaller: SwapFactoryCaller{contract: contract}, SwapFactoryTransactor: SwapFactoryTransactor{contract: contract}, SwapFactoryFilterer: SwapFactoryFilterer{contract: contract}}, nil
+}
+
+// SwapFactory is an auto generated Go binding around an Ethereum contract.
+type SwapFactory struct {
+	SwapFactoryCaller     // Read-only binding to the contract
+	SwapFactoryTransactor // Write-only binding to the contract
+	SwapFactoryFilterer   // Log filterer for contract events
+}
+
+// SwapFactoryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type SwapFactoryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SwapFactoryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type SwapFactoryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SwapFactoryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type SwapFactoryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SwapFactorySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type SwapFactorySession struct {
+	Contract     *SwapFactory      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// SwapFactoryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type SwapFactoryCallerSession struct {
+	Contract *SwapFactoryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// SwapFactoryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type SwapFactoryTransactorSession struct {
+	Contract     *SwapFactoryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// SwapFactoryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type SwapFactoryRaw struct {
+	Contract *SwapFactory // Generic contract binding to access the raw methods on
+}
+
+// SwapFactoryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type SwapFactoryCallerRaw struct {
+	Contract *SwapFactoryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// SwapFactoryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type SwapFactoryTransactorRaw struct {
+	Contract *SwapFactoryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewSwapFactory creates a new instance of SwapFactory, bound to a specific deployed contract.
+func NewSwapFactory(address common.Address, backend bind.ContractBackend) (*SwapFactory, error) {
+	contract, err := bindSwapFactory(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapFactory{SwapFactoryCaller: SwapFactoryCaller{contract: contract}, SwapFactoryTransactor: SwapFactoryTransactor{contract: contract}, SwapFactoryFilterer: SwapFactoryFilterer{contract: contract}}, nil
+}
+
+// NewSwapFactoryCaller creates a new read-only instance of SwapFactory, bound to a specific deployed contract.
+func NewSwapFactoryCaller(address common.Address, caller bind.ContractCaller) (*SwapFactoryCaller, error) {
+	contract, err := bindSwapFactory(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapFactoryCaller{contract: contract}, nil
+}
+
+// NewSwapFactoryTransactor creates a new write-only instance of SwapFactory, bound to a specific deployed contract.
+func NewSwapFactoryTransactor(address common.Address, transactor bind.ContractTransactor) (*SwapFactoryTransactor, error) {
+	contract, err := bindSwapFactory(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapFactoryTransactor{contract: contract}, nil
+}
+
+// NewSwapFactoryFilterer creates a new log filterer instance of SwapFactory, bound to a specific deployed contract.
+func NewSwapFactoryFilterer(address common.Address, filterer bind.ContractFilterer) (*SwapFactoryFilterer, error) {
+	contract, err := bindSwapFactory(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapFactoryFilterer{contract: contract}, nil
+}
+
+// bindSwapFactory binds a generic wrapper to an already deployed contract.
+func bindSwapFactory(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(SwapFactoryABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SwapFactory *SwapFactoryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SwapFactory.Contract.SwapFactoryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SwapFactory *SwapFactoryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SwapFactory.Contract.SwapFactoryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SwapFactory *SwapFactoryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SwapFactory.Contract.SwapFactoryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SwapFactory *SwapFactoryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SwapFactory.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SwapFactory *SwapFactoryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SwapFactory.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SwapFactory *SwapFactoryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SwapFactory.Contract.contract.Transact(opts, method, params...)
+}
+
+// MulVerify is a free data retrieval call binding the contract method 0xb32d1b4f.
+//
+// Solidity: function mulVerify(uint256 scalar, uint256 qKeccak) pure returns(bool)
+func (_SwapFactory *SwapFactoryCaller) MulVerify(opts *bind.CallOpts, scalar *big.Int, qKeccak *big.Int) (bool, error) {
+	var out []interface{}
+	err := _SwapFactory.contract.Call(opts, &out, "mulVerify", scalar, qKeccak)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// MulVerify is a free data retrieval call binding the contract method 0xb32d1b4f.
+//
+// Solidity: function mulVerify(uint256 scalar, uint256 qKeccak) pure returns(bool)
+func (_SwapFactory *SwapFactorySession) MulVerify(scalar *big.Int, qKeccak *big.Int) (bool, error) {
+	return _SwapFactory.Contract.MulVerify(&_SwapFactory.CallOpts, scalar, qKeccak)
+}
+
+// MulVerify is a free data retrieval call binding the contract method 0xb32d1b4f.
+//
+// Solidity: function mulVerify(uint256 scalar, uint256 qKeccak) pure returns(bool)
+func (_SwapFactory *SwapFactoryCallerSession) MulVerify(scalar *big.Int, qKeccak *big.Int) (bool, error) {
+	return _SwapFactory.Contract.MulVerify(&_SwapFactory.CallOpts, scalar, qKeccak)
+}
+
+// SwapCount is a free data retrieval call binding the contract method 0x2eff0d9e.
+//
+// Solidity: function swapCount() view returns(uint256)
+func (_SwapFactory *SwapFactoryCaller) SwapCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _SwapFactory.contract.Call(opts, &out, "swapCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SwapCount is a free data retrieval call binding the contract method 0x2eff0d9e.
+//
+// Solidity: function swapCount() view returns(uint256)
+func (_SwapFactory *SwapFactorySession) SwapCount() (*big.Int, error) {
+	return _SwapFactory.Contract.SwapCount(&_SwapFactory.CallOpts)
+}
+
+// SwapCount is a free data retrieval call binding the contract method 0x2eff0d9e.
+//
+// Solidity: function swapCount() view returns(uint256)
+func (_SwapFactory *SwapFactoryCallerSession) SwapCount() (*big.Int, error) {
+	return _SwapFactory.Contract.SwapCount(&_SwapFactory.CallOpts)
+}
+
+// Swaps is a free data retrieval call binding the contract method 0xeb84e7f2.
+//
+// Solidity: function swaps(bytes32 ) view returns(address owner, address claimer, bytes32 pubKeyClaim, bytes32 pubKeyRefund, uint256 timeout0, uint256 timeout1, address asset, uint256 value, uint8 stage)
+func (_SwapFactory *SwapFactoryCaller) Swaps(opts *bind.CallOpts, arg0 [32]byte) (struct {
+	Owner        common.Address
+	Claimer      common.Address
+	PubKeyClaim  [32]byte
+	PubKeyRefund [32]byte
+	Timeout0     *big.Int
+	Timeout1     *big.Int
+	Asset        common.Address
+	Value        *big.Int
+	Stage        uint8
+}, error) {
+	var out []interface{}
+	err := _SwapFactory.contract.Call(opts, &out, "swaps", arg0)
+
+	outstruct := new(struct {
+		Owner        common.Address
+		Claimer      common.Address
+		PubKeyClaim  [32]byte
+		PubKeyRefund [32]byte
+		Timeout0     *big.Int
+		Timeout1     *big.Int
+		Asset        common.Address
+		Value        *big.Int
+		Stage        uint8
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Owner = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Claimer = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.PubKeyClaim = *abi.ConvertType(out[2], new([32]byte)).(*[32]byte)
+	outstruct.PubKeyRefund = *abi.ConvertType(out[3], new([32]byte)).(*[32]byte)
+	outstruct.Timeout0 = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.Timeout1 = *abi.ConvertType(out[5], new(*big.Int)).(**big.Int)
+	outstruct.Asset = *abi.ConvertType(out[6], new(common.Address)).(*common.Address)
+	outstruct.Value = *abi.ConvertType(out[7], new(*big.Int)).(**big.Int)
+	outstruct.Stage = *abi.ConvertType(out[8], new(uint8)).(*uint8)
+
+	return *outstruct, err
+
+}
+
+// Swaps is a free data retrieval call binding the contract method 0xeb84e7f2.
+//
+// Solidity: function swaps(bytes32 ) view returns(address owner, address claimer, bytes32 pubKeyClaim, bytes32 pubKeyRefund, uint256 timeout0, uint256 timeout1, address asset, uint256 value, uint8 stage)
+func (_SwapFactory *SwapFactorySession) Swaps(arg0 [32]byte) (struct {
+	Owner        common.Address
+	Claimer      common.Address
+	PubKeyClaim  [32]byte
+	PubKeyRefund [32]byte
+	Timeout0     *big.Int
+	Timeout1     *big.Int
+	Asset        common.Address
+	Value        *big.Int
+	Stage        uint8
+}, error) {
+	return _SwapFactory.Contract.Swaps(&_SwapFactory.CallOpts, arg0)
+}
+
+// Swaps is a free data retrieval call binding the contract method 0xeb84e7f2.
+//
+// Solidity: function swaps(bytes32 ) view returns(address owner, address claimer, bytes32 pubKeyClaim, bytes32 pubKeyRefund, uint256 timeout0, uint256 timeout1, address asset, uint256 value, uint8 stage)
+func (_SwapFactory *SwapFactoryCallerSession) Swaps(arg0 [32]byte) (struct {
+	Owner        common.Address
+	Claimer      common.Address
+	PubKeyClaim  [32]byte
+	PubKeyRefund [32]byte
+	Timeout0     *big.Int
+	Timeout1     *big.Int
+	Asset        common.Address
+	Value        *big.Int
+	Stage        uint8
+}, error) {
+	return _SwapFactory.Contract.Swaps(&_SwapFactory.CallOpts, arg0)
+}
+
+// Claim is a paid mutator transaction binding the contract method 0x84cc9dfb.
+//
+// Solidity: function claim(bytes32 _swapID, bytes32 _s) returns()
+func (_SwapFactory *SwapFactoryTransactor) Claim(opts *bind.TransactOpts, _swapID [32]byte, _s [32]byte) (*types.Transaction, error) {
+	return _SwapFactory.contract.Transact(opts, "claim", _swapID, _s)
+}
+
+// Claim is a paid mutator transaction binding the contract method 0x84cc9dfb.
+//
+// Solidity: function claim(bytes32 _swapID, bytes32 _s) returns()
+func (_SwapFactory *SwapFactorySession) Claim(_swapID [32]byte, _s [32]byte) (*types.Transaction, error) {
+	return _SwapFactory.Contract.Claim(&_SwapFactory.TransactOpts, _swapID, _s)
+}
+
+// Claim is a paid mutator transaction binding the contract method 0x84cc9dfb.
+//
+// Solidity: function claim(bytes32 _swapID, bytes32 _s) returns()
+func (_SwapFactory *SwapFactoryTransactorSession) Claim(_swapID [32]byte, _s [32]byte) (*types.Transaction, error) {
+	return _SwapFactory.Contract.Claim(&_SwapFactory.TransactOpts, _swapID, _s)
+}
+
+// NewSwap is a paid mutator transaction binding the contract method 0xb1370040.
+//
+// Solidity: function newSwap(bytes32 _pubKeyClaim, bytes32 _pubKeyRefund, address _claimer, uint256 _timeoutDuration, address _asset, uint256 _value) payable returns(bytes32)
+func (_SwapFactory *SwapFactoryTransactor) NewSwap(opts *bind.TransactOpts, _pubKeyClaim [32]byte, _pubKeyRefund [32]byte, _claimer common.Address, _timeoutDuration *big.Int, _asset common.Address, _value *big.Int) (*types.Transaction, error) {
+	return _SwapFactory.contract.Transact(opts, "newSwap", _pubKeyClaim, _pubKeyRefund, _claimer, _timeoutDuration, _asset, _value)
+}
+
+// NewSwap is a paid mutator transaction binding the contract method 0xb1370040.
+//
+// Solidity: function newSwap(bytes32 _pubKeyClaim, bytes32 _pubKeyRefund, address _claimer, uint256 _timeoutDuration, address _asset, uint256 _value) payable returns(bytes32)
+func (_SwapFactory *SwapFactorySession) NewSwap(_pubKeyClaim [32]byte, _pubKeyRefund [32]byte, _claimer common.Address, _timeoutDuration *big.Int, _asset common.Address, _value *big.Int) (*types.Transaction, error) {
+	return _SwapFactory.Contract.NewSwap(&_SwapFactory.TransactOpts, _pubKeyClaim, _pubKeyRefund, _claimer, _timeoutDuration, _asset, _value)
+}
+
+// NewSwap is a paid mutator transaction binding the contract method 0xb1370040.
+//
+// Solidity: function newSwap(bytes32 _pubKeyClaim, bytes32 _pubKeyRefund, address _claimer, uint256 _timeoutDuration, address _asset, uint256 _value) payable returns(bytes32)
+func (_SwapFactory *SwapFactoryTransactorSession) NewSwap(_pubKeyClaim [32]byte, _pubKeyRefund [32]byte, _claimer common.Address, _timeoutDuration *big.Int, _asset common.Address, _value *big.Int) (*types.Transaction, error) {
+	return _SwapFactory.Contract.NewSwap(&_SwapFactory.TransactOpts, _pubKeyClaim, _pubKeyRefund, _claimer, _timeoutDuration, _asset, _value)
+}
+
+// Refund is a paid mutator transaction binding the contract method 0xe4683a79.
+//
+// Solidity: function refund(bytes32 _swapID, bytes32 _s) returns()
+func (_SwapFactory *SwapFactoryTransactor) Refund(opts *bind.TransactOpts, _swapID [32]byte, _s [32]byte) (*types.Transaction, error) {
+	return _SwapFactory.contract.Transact(opts, "refund", _swapID, _s)
+}
+
+// Refund is a paid mutator transaction binding the contract method 0xe4683a79.
+//
+// Solidity: function refund(bytes32 _swapID, bytes32 _s) returns()
+func (_SwapFactory *SwapFactorySession) Refund(_swapID [32]byte, _s [32]byte) (*types.Transaction, error) {
+	return _SwapFactory.Contract.Refund(&_SwapFactory.TransactOpts, _swapID, _s)
+}
+
+// Refund is a paid mutator transaction binding the contract method 0xe4683a79.
+//
+// Solidity: function refund(bytes32 _swapID, bytes32 _s) returns()
+func (_SwapFactory *SwapFactoryTransactorSession) Refund(_swapID [32]byte, _s [32]byte) (*types.Transaction, error) {
+	return _SwapFactory.Contract.Refund(&_SwapFactory.TransactOpts, _swapID, _s)
+}
+
+// SetReady is a paid mutator transaction binding the contract method 0x6bcfee15.
+//
+// Solidity: function setReady(bytes32 _swapID) returns()
+func (_SwapFactory *SwapFactoryTransactor) SetReady(opts *bind.TransactOpts, _swapID [32]byte) (*types.Transaction, error) {
+	return _SwapFactory.contract.Transact(opts, "setReady", _swapID)
+}
+
+// SetReady is a paid mutator transaction binding the contract method 0x6bcfee15.
+//
+// Solidity: function setReady(bytes32 _swapID) returns()
+func (_SwapFactory *SwapFactorySession) SetReady(_swapID [32]byte) (*types.Transaction, error) {
+	return _SwapFactory.Contract.SetReady(&_SwapFactory.TransactOpts, _swapID)
+}
+
+// SetReady is a paid mutator transaction binding the contract method 0x6bcfee15.
+//
+// Solidity: function setReady(bytes32 _swapID) returns()
+func (_SwapFactory *SwapFactoryTransactorSession) SetReady(_swapID [32]byte) (*types.Transaction, error) {
+	return _SwapFactory.Contract.SetReady(&_SwapFactory.TransactOpts, _swapID)
+}
+
+// SwapFactoryClaimedIterator is returned from FilterClaimed and is used to iterate over the raw logs and unpacked data for Claimed events raised by the SwapFactory contract.
+type SwapFactoryClaimedIterator struct {
+	Event *SwapFactoryClaimed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SwapFactoryClaimedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SwapFactoryClaimed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SwapFactoryClaimed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SwapFactoryClaimedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SwapFactoryClaimedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SwapFactoryClaimed represents a Claimed event raised by the SwapFactory contract.
+type SwapFactoryClaimed struct {
+	SwapID [32]byte
+	S      [32]byte
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterClaimed is a free log retrieval operation binding the contract event 0x38d6042dbdae8e73a7f6afbabd3fbe0873f9f5ed3cd71294591c3908c2e65fee.
+//
+// Solidity: event Claimed(bytes32 indexed swapID, bytes32 s)
+func (_SwapFactory *SwapFactoryFilterer) FilterClaimed(opts *bind.FilterOpts, swapID [][32]byte) (*SwapFactoryClaimedIterator, error) {
+
+	var swapIDRule []interface{}
+	for _, swapIDItem := range swapID {
+		swapIDRule = append(swapIDRule, swapIDItem)
+	}
+
+	logs, sub, err := _SwapFactory.contract.FilterLogs(opts, "Claimed", swapIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapFactoryClaimedIterator{contract: _SwapFactory.contract, event: "Claimed", logs: logs, sub: sub}, nil
+}
+
+// WatchClaimed is a free log subscription operation binding the contract event 0x38d6042dbdae8e73a7f6afbabd3fbe0873f9f5ed3cd71294591c3908c2e65fee.
+//
+// Solidity: event Claimed(bytes32 indexed swapID, bytes32 s)
+func (_SwapFactory *SwapFactoryFilterer) WatchClaimed(opts *bind.WatchOpts, sink chan<- *SwapFactoryClaimed, swapID [][32]byte) (event.Subscription, error) {
+
+	var swapIDRule []interface{}
+	for _, swapIDItem := range swapID {
+		swapIDRule = append(swapIDRule, swapIDItem)
+	}
+
+	logs, sub, err := _SwapFactory.contract.WatchLogs(opts, "Claimed", swapIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SwapFactoryClaimed)
+				if err := _SwapFactory.contract.UnpackLog(event, "Claimed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseClaimed is a log parse operation binding the contract event 0x38d6042dbdae8e73a7f6afbabd3fbe0873f9f5ed3cd71294591c3908c2e65fee.
+//
+// Solidity: event Claimed(bytes32 indexed swapID, bytes32 s)
+func (_SwapFactory *SwapFactoryFilterer) ParseClaimed(log types.Log) (*SwapFactoryClaimed, error) {
+	event := new(SwapFactoryClaimed)
+	if err := _SwapFactory.contract.UnpackLog(event, "Claimed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SwapFactoryNewIterator is returned from FilterNew and is used to iterate over the raw logs and unpacked data for New events raised by the SwapFactory contract.
+type SwapFactoryNewIterator struct {
+	Event *SwapFactoryNew // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SwapFactoryNewIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SwapFactoryNew)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SwapFactoryNew)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SwapFactoryNewIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SwapFactoryNewIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SwapFactoryNew represents a New event raised by the SwapFactory contract.
+type SwapFactoryNew struct {
+	SwapID    [32]byte
+	ClaimKey  [32]byte
+	RefundKey [32]byte
+	Timeout0  *big.Int
+	Timeout1  *big.Int
+	Asset     common.Address
+	Value     *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterNew is a free log retrieval operation binding the contract event 0x91446ce035ac29998b5473504609a5ef5e961005daba4630a1684b63be848f56.
+//
+// Solidity: event New(bytes32 indexed swapID, bytes32 claimKey, bytes32 refundKey, uint256 timeout0, uint256 timeout1, address asset, uint256 value)
+func (_SwapFactory *SwapFactoryFilterer) FilterNew(opts *bind.FilterOpts, swapID [][32]byte) (*SwapFactoryNewIterator, error) {
+
+	var swapIDRule []interface{}
+	for _, swapIDItem := range swapID {
+		swapIDRule = append(swapIDRule, swapIDItem)
+	}
+
+	logs, sub, err := _SwapFactory.contract.FilterLogs(opts, "New", swapIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapFactoryNewIterator{contract: _SwapFactory.contract, event: "New", logs: logs, sub: sub}, nil
+}
+
+// WatchNew is a free log subscription operation binding the contract event 0x91446ce035ac29998b5473504609a5ef5e961005daba4630a1684b63be848f56.
+//
+// Solidity: event New(bytes32 indexed swapID, bytes32 claimKey, bytes32 refundKey, uint256 timeout0, uint256 timeout1, address asset, uint256 value)
+func (_SwapFactory *SwapFactoryFilterer) WatchNew(opts *bind.WatchOpts, sink chan<- *SwapFactoryNew, swapID [][32]byte) (event.Subscription, error) {
+
+	var swapIDRule []interface{}
+	for _, swapIDItem := range swapID {
+		swapIDRule = append(swapIDRule, swapIDItem)
+	}
+
+	logs, sub, err := _SwapFactory.contract.WatchLogs(opts, "New", swapIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SwapFactoryNew)
+				if err := _SwapFactory.contract.UnpackLog(event, "New", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseNew is a log parse operation binding the contract event 0x91446ce035ac29998b5473504609a5ef5e961005daba4630a1684b63be848f56.
+//
+// Solidity: event New(bytes32 indexed swapID, bytes32 claimKey, bytes32 refundKey, uint256 timeout0, uint256 timeout1, address asset, uint256 value)
+func (_SwapFactory *SwapFactoryFilterer) ParseNew(log types.Log) (*SwapFactoryNew, error) {
+	event := new(SwapFactoryNew)
+	if err := _SwapFactory.contract.UnpackLog(event, "New", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SwapFactoryReadyIterator is returned from FilterReady and is used to iterate over the raw logs and unpacked data for Ready events raised by the SwapFactory contract.
+type SwapFactoryReadyIterator struct {
+	Event *SwapFactoryReady // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SwapFactoryReadyIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SwapFactoryReady)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SwapFactoryReady)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SwapFactoryReadyIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SwapFactoryReadyIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SwapFactoryReady represents a Ready event raised by the SwapFactory contract.
+type SwapFactoryReady struct {
+	SwapID [32]byte
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterReady is a free log retrieval operation binding the contract event 0x5fc23b25552757626e08b316cc2387ad1bc70ee1594af7204db4ce0c39f5d15f.
+//
+// Solidity: event Ready(bytes32 indexed swapID)
+func (_SwapFactory *SwapFactoryFilterer) FilterReady(opts *bind.FilterOpts, swapID [][32]byte) (*SwapFactoryReadyIterator, error) {
+
+	var swapIDRule []interface{}
+	for _, swapIDItem := range swapID {
+		swapIDRule = append(swapIDRule, swapIDItem)
+	}
+
+	logs, sub, err := _SwapFactory.contract.FilterLogs(opts, "Ready", swapIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapFactoryReadyIterator{contract: _SwapFactory.contract, event: "Ready", logs: logs, sub: sub}, nil
+}
+
+// WatchReady is a free log subscription operation binding the contract event 0x5fc23b25552757626e08b316cc2387ad1bc70ee1594af7204db4ce0c39f5d15f.
+//
+// Solidity: event Ready(bytes32 indexed swapID)
+func (_SwapFactory *SwapFactoryFilterer) WatchReady(opts *bind.WatchOpts, sink chan<- *SwapFactoryReady, swapID [][32]byte) (event.Subscription, error) {
+
+	var swapIDRule []interface{}
+	for _, swapIDItem := range swapID {
+		swapIDRule = append(swapIDRule, swapIDItem)
+	}
+
+	logs, sub, err := _SwapFactory.contract.WatchLogs(opts, "Ready", swapIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SwapFactoryReady)
+				if err := _SwapFactory.contract.UnpackLog(event, "Ready", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseReady is a log parse operation binding the contract event 0x5fc23b25552757626e08b316cc2387ad1bc70ee1594af7204db4ce0c39f5d15f.
+//
+// Solidity: event Ready(bytes32 indexed swapID)
+func (_SwapFactory *SwapFactoryFilterer) ParseReady(log types.Log) (*SwapFactoryReady, error) {
+	event := new(SwapFactoryReady)
+	if err := _SwapFactory.contract.UnpackLog(event, "Ready", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SwapFactoryRefundedIterator is returned from FilterRefunded and is used to iterate over the raw logs and unpacked data for Refunded events raised by the SwapFactory contract.
+type SwapFactoryRefundedIterator struct {
+	Event *SwapFactoryRefunded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SwapFactoryRefundedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SwapFactoryRefunded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SwapFactoryRefunded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SwapFactoryRefundedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SwapFactoryRefundedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SwapFactoryRefunded represents a Refunded event raised by the SwapFactory contract.
+type SwapFactoryRefunded struct {
+	SwapID [32]byte
+	S      [32]byte
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterRefunded is a free log retrieval operation binding the contract event 0x007c875846b687732a7579c19bb1dade66cd14e9f4f809565e2b2b5e76c72b4f.
+//
+// Solidity: event Refunded(bytes32 indexed swapID, bytes32 s)
+func (_SwapFactory *SwapFactoryFilterer) FilterRefunded(opts *bind.FilterOpts, swapID [][32]byte) (*SwapFactoryRefundedIterator, error) {
+
+	var swapIDRule []interface{}
+	for _, swapIDItem := range swapID {
+		swapIDRule = append(swapIDRule, swapIDItem)
+	}
+
+	logs, sub, err := _SwapFactory.contract.FilterLogs(opts, "Refunded", swapIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapFactoryRefundedIterator{contract: _SwapFactory.contract, event: "Refunded", logs: logs, sub: sub}, nil
+}
+
+// WatchRefunded is a free log subscription operation binding the contract event 0x007c875846b687732a7579c19bb1dade66cd14e9f4f809565e2b2b5e76c72b4f.
+//
+// Solidity: event Refunded(bytes32 indexed swapID, bytes32 s)
+func (_SwapFactory *SwapFactoryFilterer) WatchRefunded(opts *bind.WatchOpts, sink chan<- *SwapFactoryRefunded, swapID [][32]byte) (event.Subscription, error) {
+
+	var swapIDRule []interface{}
+	for _, swapIDItem := range swapID {
+		swapIDRule = append(swapIDRule, swapIDItem)
+	}
+
+	logs, sub, err := _SwapFactory.contract.WatchLogs(opts, "Refunded", swapIDRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SwapFactoryRefunded)
+				if err := _SwapFactory.contract.UnpackLog(event, "Refunded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRefunded is a log parse operation binding the contract event 0x007c875846b687732a7579c19bb1dade66cd14e9f4f809565e2b2b5e76c72b4f.
+//
+// Solidity: event Refunded(bytes32 indexed swapID, bytes32 s)
+func (_SwapFactory *SwapFactoryFilterer) ParseRefunded(log types.Log) (*SwapFactoryRefunded, error) {
+	event := new(SwapFactoryRefunded)
+	if err := _SwapFactory.contract.UnpackLog(event, "Refunded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,373 @@
+package swap
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/crypto/secp256k1"
+	"github.com/noot/atomic-swap/dleq"
+)
+
+var defaultTimeoutDuration = big.NewInt(60) // 60 seconds
+
+func setupAliceAuth(t *testing.T) (*bind.TransactOpts, *ethclient.Client, *ecdsa.PrivateKey) {
+	conn, err := ethclient.Dial(common.DefaultEthEndpoint)
+	require.NoError(t, err)
+	pkA, err := crypto.HexToECDSA(common.DefaultPrivKeyAlice)
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(pkA, big.NewInt(common.GanacheChainID))
+	require.NoError(t, err)
+	return auth, conn, pkA
+}
+
+func deploySwapFactory(t *testing.T, auth *bind.TransactOpts,
+	conn *ethclient.Client) (ethcommon.Address, *SwapFactory) {
+	address, tx, contract, err := DeploySwapFactory(auth, conn)
+	require.NoError(t, err)
+	_, ok := common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+	t.Logf("gas cost to deploy SwapFactory.sol: %d", tx.Gas())
+	return address, contract
+}
+
+// newEtherSwap creates a new swap for 1 wei in the factory and returns its ID.
+func newEtherSwap(t *testing.T, auth *bind.TransactOpts, conn *ethclient.Client, address ethcommon.Address,
+	contract *SwapFactory, claimKey, refundKey [32]byte, claimer ethcommon.Address) [32]byte {
+	value := big.NewInt(1)
+	auth.Value = value
+	defer func() {
+		auth.Value = nil
+	}()
+
+	tx, err := contract.NewSwap(auth, claimKey, refundKey, claimer, defaultTimeoutDuration,
+		ethcommon.Address{}, value)
+	require.NoError(t, err)
+	t.Logf("gas cost to call NewSwap: %d", tx.Gas())
+
+	receipt, ok := common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+
+	id, err := GetIDFromLogs(address, receipt.Logs)
+	require.NoError(t, err)
+	return id
+}
+
+func TestSwapFactory_NewSwap(t *testing.T) {
+	auth, conn, pkA := setupAliceAuth(t)
+	addr := crypto.PubkeyToAddress(pkA.PublicKey)
+	address, contract := deploySwapFactory(t, auth, conn)
+
+	claimKey, refundKey := [32]byte{1}, [32]byte{2}
+	id := newEtherSwap(t, auth, conn, address, contract, claimKey, refundKey, addr)
+
+	swap, err := contract.Swaps(&bind.CallOpts{}, id)
+	require.NoError(t, err)
+	require.Equal(t, addr, swap.Owner)
+	require.Equal(t, addr, swap.Claimer)
+	require.Equal(t, claimKey, swap.PubKeyClaim)
+	require.Equal(t, refundKey, swap.PubKeyRefund)
+	require.Equal(t, ethcommon.Address{}, swap.Asset)
+	require.Equal(t, big.NewInt(1), swap.Value)
+	require.Equal(t, StagePending, swap.Stage)
+	require.Equal(t, defaultTimeoutDuration, new(big.Int).Sub(swap.Timeout1, swap.Timeout0))
+
+	// each swap gets its own ID
+	id2 := newEtherSwap(t, auth, conn, address, contract, claimKey, refundKey, addr)
+	require.NotEqual(t, id, id2)
+}
+
+func TestSwapFactory_Claim_vec(t *testing.T) {
+	secret, err := hex.DecodeString("D30519BCAE8D180DBFCC94FE0B8383DC310185B0BE97B4365083EBCECCD75759")
+	require.NoError(t, err)
+	pubX, err := hex.DecodeString("3AF1E1EFA4D1E1AD5CB9E3967E98E901DAFCD37C44CF0BFB6C216997F5EE51DF")
+	require.NoError(t, err)
+	pubY, err := hex.DecodeString("E4ACAC3E6F139E0C7DB2BD736824F51392BDA176965A1C59EB9C3C5FF9E85D7A")
+	require.NoError(t, err)
+
+	var s, x, y [32]byte
+	copy(s[:], secret)
+	copy(x[:], pubX)
+	copy(y[:], pubY)
+
+	pk := secp256k1.NewPublicKey(x, y)
+	cmt := pk.Keccak256()
+
+	// create swap with claim key hash
+	auth, conn, pkA := setupAliceAuth(t)
+	pub := pkA.Public().(*ecdsa.PublicKey)
+	addr := crypto.PubkeyToAddress(*pub)
+	t.Logf("commitment: 0x%x", cmt)
+
+	address, contract := deploySwapFactory(t, auth, conn)
+	id := newEtherSwap(t, auth, conn, address, contract, cmt, [32]byte{}, addr)
+
+	// set swap to Ready
+	_, err = contract.SetReady(auth, id)
+	require.NoError(t, err)
+
+	// now let's try to claim
+	tx, err := contract.Claim(auth, id, s)
+	require.NoError(t, err)
+	t.Log(tx.Hash())
+}
+
+func TestSwapFactory_Claim_random(t *testing.T) {
+	// generate claim secret and public key
+	dleq := &dleq.GoDLEq{}
+	proof, err := dleq.Prove()
+	require.NoError(t, err)
+	res, err := dleq.Verify(proof)
+	require.NoError(t, err)
+
+	// hash public key
+	cmt := res.Secp256k1PublicKey().Keccak256()
+
+	// create swap with claim key hash
+	auth, conn, pkA := setupAliceAuth(t)
+	pub := pkA.Public().(*ecdsa.PublicKey)
+	addr := crypto.PubkeyToAddress(*pub)
+
+	address, contract := deploySwapFactory(t, auth, conn)
+	id := newEtherSwap(t, auth, conn, address, contract, cmt, [32]byte{}, addr)
+
+	// set swap to Ready
+	tx, err := contract.SetReady(auth, id)
+	require.NoError(t, err)
+	t.Logf("gas cost to call SetReady: %d", tx.Gas())
+
+	// now let's try to claim
+	var s [32]byte
+	secret := proof.Secret()
+	copy(s[:], common.Reverse(secret[:]))
+	tx, err = contract.Claim(auth, id, s)
+	require.NoError(t, err)
+	t.Logf("gas cost to call Claim: %d", tx.Gas())
+
+	receipt, ok := common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+	require.Equal(t, 1, len(receipt.Logs))
+	require.Equal(t, ethcommon.Hash(id), receipt.Logs[0].Topics[1])
+
+	sk, err := GetSecretFromLog(receipt.Logs[0], "Claimed")
+	require.NoError(t, err)
+	expected := proof.Secret()
+	require.Equal(t, expected[:], sk.Bytes())
+
+	swap, err := contract.Swaps(&bind.CallOpts{}, id)
+	require.NoError(t, err)
+	require.Equal(t, StageCompleted, swap.Stage)
+
+	// the swap can't be claimed twice
+	_, err = contract.Claim(auth, id, s)
+	require.Error(t, err)
+}
+
+func TestSwapFactory_Refund_beforeT0(t *testing.T) {
+	// generate refund secret and public key
+	dleq := &dleq.GoDLEq{}
+	proof, err := dleq.Prove()
+	require.NoError(t, err)
+	res, err := dleq.Verify(proof)
+	require.NoError(t, err)
+
+	// hash public key
+	cmt := res.Secp256k1PublicKey().Keccak256()
+
+	// create swap with refund key hash
+	auth, conn, pkA := setupAliceAuth(t)
+	pub := pkA.Public().(*ecdsa.PublicKey)
+	addr := crypto.PubkeyToAddress(*pub)
+
+	address, contract := deploySwapFactory(t, auth, conn)
+	id := newEtherSwap(t, auth, conn, address, contract, [32]byte{}, cmt, addr)
+
+	// now let's try to refund
+	var s [32]byte
+	secret := proof.Secret()
+	copy(s[:], common.Reverse(secret[:]))
+	tx, err := contract.Refund(auth, id, s)
+	require.NoError(t, err)
+	t.Logf("gas cost to call Refund: %d", tx.Gas())
+}
+
+func TestSwapFactory_Refund_afterT1(t *testing.T) {
+	// generate refund secret and public key
+	dleq := &dleq.GoDLEq{}
+	proof, err := dleq.Prove()
+	require.NoError(t, err)
+	res, err := dleq.Verify(proof)
+	require.NoError(t, err)
+
+	// hash public key
+	cmt := res.Secp256k1PublicKey().Keccak256()
+
+	// create swap with refund key hash
+	auth, conn, pkA := setupAliceAuth(t)
+	pub := pkA.Public().(*ecdsa.PublicKey)
+	addr := crypto.PubkeyToAddress(*pub)
+
+	address, contract := deploySwapFactory(t, auth, conn)
+	id := newEtherSwap(t, auth, conn, address, contract, [32]byte{}, cmt, addr)
+
+	// fast forward past t1
+	rpcClient, err := rpc.Dial(common.DefaultEthEndpoint)
+	require.NoError(t, err)
+
+	var result string
+	err = rpcClient.Call(&result, "evm_snapshot")
+	require.NoError(t, err)
+
+	err = rpcClient.Call(nil, "evm_increaseTime", defaultTimeoutDuration.Int64()*2+60)
+	require.NoError(t, err)
+
+	defer func() {
+		var ok bool
+		err = rpcClient.Call(&ok, "evm_revert", result)
+		require.NoError(t, err)
+	}()
+
+	// now let's try to refund
+	var s [32]byte
+	secret := proof.Secret()
+	copy(s[:], common.Reverse(secret[:]))
+	tx, err := contract.Refund(auth, id, s)
+	require.NoError(t, err)
+	t.Logf("gas cost to call Refund: %d", tx.Gas())
+}
+
+func TestSwapFactory_ERC20_Claim(t *testing.T) {
+	// generate claim secret and public key
+	dleq := &dleq.GoDLEq{}
+	proof, err := dleq.Prove()
+	require.NoError(t, err)
+	res, err := dleq.Verify(proof)
+	require.NoError(t, err)
+
+	// hash public key
+	cmt := res.Secp256k1PublicKey().Keccak256()
+
+	auth, conn, pkA := setupAliceAuth(t)
+	addr := crypto.PubkeyToAddress(pkA.PublicKey)
+	address, contract := deploySwapFactory(t, auth, conn)
+
+	tokenAddr, tx, token, err := DeployERC20Mock(auth, conn, 6)
+	require.NoError(t, err)
+	_, ok := common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+
+	amount := big.NewInt(1e6)
+	tx, err = token.Mint(auth, addr, amount)
+	require.NoError(t, err)
+	_, ok = common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+
+	// the factory transfers the tokens into itself when the swap is created,
+	// so it must be approved to spend them beforehand
+	tx, err = token.Approve(auth, address, amount)
+	require.NoError(t, err)
+	_, ok = common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+
+	tx, err = contract.NewSwap(auth, cmt, [32]byte{}, addr, defaultTimeoutDuration, tokenAddr, amount)
+	require.NoError(t, err)
+	receipt, ok := common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+	t.Logf("gas cost to call NewSwap with a token: %d", tx.Gas())
+
+	id, err := GetIDFromLogs(address, receipt.Logs)
+	require.NoError(t, err)
+
+	balance, err := token.BalanceOf(&bind.CallOpts{}, address)
+	require.NoError(t, err)
+	require.Equal(t, amount, balance)
+
+	swap, err := contract.Swaps(&bind.CallOpts{}, id)
+	require.NoError(t, err)
+	require.Equal(t, tokenAddr, swap.Asset)
+	require.Equal(t, amount, swap.Value)
+
+	// set swap to Ready
+	_, err = contract.SetReady(auth, id)
+	require.NoError(t, err)
+
+	// now let's try to claim
+	var s [32]byte
+	secret := proof.Secret()
+	copy(s[:], common.Reverse(secret[:]))
+	tx, err = contract.Claim(auth, id, s)
+	require.NoError(t, err)
+	_, ok = common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+	t.Logf("gas cost to call Claim: %d", tx.Gas())
+
+	balance, err = token.BalanceOf(&bind.CallOpts{}, address)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), balance.Int64())
+
+	balance, err = token.BalanceOf(&bind.CallOpts{}, addr)
+	require.NoError(t, err)
+	require.Equal(t, amount, balance)
+}
+
+func TestSwapFactory_ERC20_NotApproved(t *testing.T) {
+	auth, conn, pkA := setupAliceAuth(t)
+	addr := crypto.PubkeyToAddress(pkA.PublicKey)
+	_, contract := deploySwapFactory(t, auth, conn)
+
+	tokenAddr, tx, token, err := DeployERC20Mock(auth, conn, 18)
+	require.NoError(t, err)
+	_, ok := common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+
+	amount := big.NewInt(1e18)
+	tx, err = token.Mint(auth, addr, amount)
+	require.NoError(t, err)
+	_, ok = common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+
+	// creating the swap without approving the factory to spend the tokens fails
+	_, err = contract.NewSwap(auth, [32]byte{}, [32]byte{}, addr, defaultTimeoutDuration, tokenAddr, amount)
+	require.Error(t, err)
+}
+
+func TestSwapFactory_ERC20_TransferFee(t *testing.T) {
+	auth, conn, pkA := setupAliceAuth(t)
+	addr := crypto.PubkeyToAddress(pkA.PublicKey)
+	factoryAddr, contract := deploySwapFactory(t, auth, conn)
+
+	tokenAddr, tx, token, err := DeployERC20Mock(auth, conn, 18)
+	require.NoError(t, err)
+	_, ok := common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+
+	amount := big.NewInt(1e18)
+	tx, err = token.Mint(auth, addr, amount)
+	require.NoError(t, err)
+	_, ok = common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+
+	tx, err = token.Approve(auth, factoryAddr, amount)
+	require.NoError(t, err)
+	_, ok = common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+
+	tx, err = token.SetTransferFee(auth, big.NewInt(1))
+	require.NoError(t, err)
+	_, ok = common.WaitForReceipt(context.Background(), conn, tx.Hash())
+	require.True(t, ok)
+
+	// the factory would receive less than the swap's value, so creating the swap fails
+	_, err = contract.NewSwap(auth, [32]byte{}, [32]byte{}, addr, defaultTimeoutDuration, tokenAddr, amount)
+	require.Error(t, err)
+}
@@ -1,29 +1,102 @@
 package swap
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/noot/atomic-swap/common"
 	mcrypto "github.com/noot/atomic-swap/crypto/monero"
 )
 
+// Stages of a swap in the SwapFactory contract, as returned by SwapFactory.Swaps
+const (
+	StageInvalid uint8 = iota
+	StagePending
+	StageReady
+	StageCompleted
+)
+
+// swapFactoryABI is the parsed ABI of the SwapFactory contract
+var swapFactoryABI = mustParseABI(SwapFactoryABI)
+
+// Topics of the events emitted by the SwapFactory contract, derived from its ABI
+var (
+	NewTopic      = swapFactoryABI.Events["New"].ID
+	ReadyTopic    = swapFactoryABI.Events["Ready"].ID
+	ClaimedTopic  = swapFactoryABI.Events["Claimed"].ID
+	RefundedTopic = swapFactoryABI.Events["Refunded"].ID
+)
+
+// swapFactoryRuntimeCode is the code of a deployed SwapFactory contract
+var swapFactoryRuntimeCode = mustGetRuntimeCode(ethcommon.FromHex(SwapFactoryBin))
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
+
+// mustGetRuntimeCode returns the runtime code of a contract given its deployment code. The
+// deployment code solc generates for a contract without immutables ends with RETURN and INVALID,
+// followed by the runtime code it returns.
+func mustGetRuntimeCode(code []byte) []byte {
+	const (
+		push1   = 0x60
+		push32  = 0x7f
+		ret     = 0xf3
+		invalid = 0xfe
+	)
+
+	for i := 0; i < len(code)-1; i++ {
+		switch op := code[i]; {
+		case op >= push1 && op <= push32:
+			// skip the pushed data, which isn't code
+			i += int(op-push1) + 1
+		case op == ret && code[i+1] == invalid:
+			return code[i+2:]
+		}
+	}
+
+	panic("cannot find runtime code in deployment code")
+}
+
+// ErrNotSwapFactory is returned by CheckSwapFactoryContract if the contract isn't a SwapFactory.
+var ErrNotSwapFactory = errors.New("contract is not a SwapFactory contract")
+
+// CheckSwapFactoryContract checks that the contract at the given address is a SwapFactory, so
+// that the swaps it reports, and the transactions we send it, can be trusted.
+func CheckSwapFactoryContract(ctx context.Context, client bind.ContractCaller, address ethcommon.Address) error {
+	code, err := client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get contract code: %w", err)
+	}
+
+	if !bytes.Equal(code, swapFactoryRuntimeCode) {
+		return fmt.Errorf("%w: address=%s", ErrNotSwapFactory, address)
+	}
+
+	return nil
+}
+
 // GetSecretFromLog returns the secret from a Claimed or Refunded log
 func GetSecretFromLog(log *ethtypes.Log, event string) (*mcrypto.PrivateSpendKey, error) {
 	if event != "Refunded" && event != "Claimed" {
 		return nil, errors.New("invalid event name, must be one of Claimed or Refunded")
 	}
 
-	abi, err := abi.JSON(strings.NewReader(SwapABI))
-	if err != nil {
-		return nil, err
-	}
-
 	data := log.Data
-	res, err := abi.Unpack(event, data)
+	res, err := swapFactoryABI.Unpack(event, data)
 	if err != nil {
 		return nil, err
 	}
@@ -41,3 +114,39 @@ func GetSecretFromLog(log *ethtypes.Log, event string) (*mcrypto.PrivateSpendKey
 
 	return sk, nil
 }
+
+// GetIDFromLogs returns the ID of the swap created by a call to SwapFactory.NewSwap,
+// given the logs of the transaction's receipt.
+func GetIDFromLogs(address ethcommon.Address, logs []*ethtypes.Log) (ethcommon.Hash, error) {
+	for _, log := range logs {
+		// token swaps also emit the token's Transfer log
+		if log.Address != address || len(log.Topics) < 2 || log.Topics[0] != NewTopic {
+			continue
+		}
+
+		return log.Topics[1], nil
+	}
+
+	return ethcommon.Hash{}, errors.New("cannot find New log")
+}
+
+// GetSecretFromLogs returns the secret from the Claimed or Refunded log of the swap with the given
+// ID in the contract at the given address, given the logs of a transaction's receipt. Logs of other
+// contracts, or other swaps in the same contract, are ignored.
+func GetSecretFromLogs(address ethcommon.Address, id ethcommon.Hash, logs []*ethtypes.Log,
+	event string) (*mcrypto.PrivateSpendKey, error) {
+	if event != "Refunded" && event != "Claimed" {
+		return nil, errors.New("invalid event name, must be one of Claimed or Refunded")
+	}
+
+	topic := swapFactoryABI.Events[event].ID
+	for _, log := range logs {
+		if log.Address != address || len(log.Topics) < 2 || log.Topics[0] != topic || log.Topics[1] != id {
+			continue
+		}
+
+		return GetSecretFromLog(log, event)
+	}
+
+	return nil, fmt.Errorf("cannot find %s log for swap %s", event, id)
+}
@@ -0,0 +1,82 @@
+package swap
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/dleq"
+)
+
+func TestGetSecretFromLogs(t *testing.T) {
+	proof, err := (&dleq.GoDLEq{}).Prove()
+	require.NoError(t, err)
+	secret := proof.Secret()
+	var s [32]byte
+	copy(s[:], common.Reverse(secret[:]))
+
+	address := ethcommon.Address{1}
+	id := ethcommon.Hash{9}
+	other := &ethtypes.Log{
+		Address: address,
+		Topics:  []ethcommon.Hash{ClaimedTopic, {8}},
+		Data:    make([]byte, 32),
+	}
+	otherContract := &ethtypes.Log{
+		Address: ethcommon.Address{2},
+		Topics:  []ethcommon.Hash{ClaimedTopic, id},
+		Data:    make([]byte, 32),
+	}
+	claimed := &ethtypes.Log{
+		Address: address,
+		Topics:  []ethcommon.Hash{ClaimedTopic, id},
+		Data:    s[:],
+	}
+
+	// only the log of our swap is used, wherever it is in the receipt
+	sk, err := GetSecretFromLogs(address, id, []*ethtypes.Log{other, otherContract, claimed}, "Claimed")
+	require.NoError(t, err)
+	expected := proof.Secret()
+	require.Equal(t, expected[:], sk.Bytes())
+
+	_, err = GetSecretFromLogs(address, id, []*ethtypes.Log{other, otherContract}, "Claimed")
+	require.Error(t, err)
+
+	_, err = GetSecretFromLogs(address, id, []*ethtypes.Log{claimed}, "Refunded")
+	require.Error(t, err)
+}
+
+// mockCodeClient is a bind.ContractCaller which only returns the code of contracts.
+type mockCodeClient struct {
+	bind.ContractCaller
+	code map[ethcommon.Address][]byte
+}
+
+func (c *mockCodeClient) CodeAt(_ context.Context, address ethcommon.Address, _ *big.Int) ([]byte, error) {
+	return c.code[address], nil
+}
+
+func TestCheckSwapFactoryContract(t *testing.T) {
+	// the deployment code copies the runtime code, of the given size, from the end of itself
+	require.Equal(t, 0x21cc, len(swapFactoryRuntimeCode))
+	require.True(t, bytes.HasSuffix(ethcommon.FromHex(SwapFactoryBin), swapFactoryRuntimeCode))
+
+	factory, other := ethcommon.Address{1}, ethcommon.Address{2}
+	client := &mockCodeClient{
+		code: map[ethcommon.Address][]byte{
+			factory: swapFactoryRuntimeCode,
+			other:   ethcommon.FromHex(ERC20MockBin),
+		},
+	}
+
+	require.NoError(t, CheckSwapFactoryContract(context.Background(), client, factory))
+	require.ErrorIs(t, CheckSwapFactoryContract(context.Background(), client, other), ErrNotSwapFactory)
+	require.ErrorIs(t, CheckSwapFactoryContract(context.Background(), client, ethcommon.Address{3}), ErrNotSwapFactory)
+}
@@ -0,0 +1,296 @@
+package swap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	eth "github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	logging "github.com/ipfs/go-log"
+
+	mcrypto "github.com/noot/atomic-swap/crypto/monero"
+)
+
+var log = logging.Logger("contract")
+
+const defaultPollInterval = time.Second * 5
+
+// EventType is the type of an event emitted by the SwapFactory contract
+type EventType byte
+
+// Events emitted by the SwapFactory contract for a swap
+const (
+	EventNew EventType = iota
+	EventReady
+	EventClaimed
+	EventRefunded
+)
+
+// String returns the name of the event in the contract's ABI
+func (t EventType) String() string {
+	switch t {
+	case EventNew:
+		return "New"
+	case EventReady:
+		return "Ready"
+	case EventClaimed:
+		return "Claimed"
+	case EventRefunded:
+		return "Refunded"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is an event emitted by the SwapFactory contract for the swap being watched
+type Event struct {
+	Type   EventType
+	SwapID ethcommon.Hash
+
+	// Secret is the secret revealed by a Claimed or Refunded event; it's nil for other events
+	Secret *mcrypto.PrivateSpendKey
+
+	Log ethtypes.Log
+}
+
+// WatcherClient is the subset of an ethereum client used by a Watcher
+type WatcherClient interface {
+	eth.LogFilterer
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// WatcherConfig contains the configuration values for a new Watcher
+type WatcherConfig struct {
+	Ctx     context.Context
+	Client  WatcherClient
+	Address ethcommon.Address // address of the SwapFactory contract
+	SwapID  ethcommon.Hash
+
+	// FromBlock is the block to start watching from, so that events emitted before the watcher
+	// was started are also delivered.
+	FromBlock uint64
+
+	// PollInterval is how often to poll for new events, if the client doesn't support
+	// subscriptions; if unset, it defaults to 5 seconds.
+	PollInterval time.Duration
+}
+
+type logKey struct {
+	txHash ethcommon.Hash
+	index  uint
+}
+
+// Watcher watches the SwapFactory contract for the events of a single swap, and delivers them
+// as they are emitted. It subscribes to the contract's logs if the client supports it, and falls
+// back to polling otherwise, or if the subscription fails.
+type Watcher struct {
+	ctx          context.Context
+	client       WatcherClient
+	query        eth.FilterQuery
+	pollInterval time.Duration
+
+	// next block to poll from
+	next uint64
+	seen map[logKey]struct{}
+
+	eventCh chan *Event
+}
+
+// NewWatcher returns a new *Watcher
+func NewWatcher(cfg *WatcherConfig) (*Watcher, error) {
+	if cfg.Client == nil {
+		return nil, errors.New("must provide ethereum client")
+	}
+
+	if (cfg.SwapID == ethcommon.Hash{}) {
+		return nil, errors.New("must provide swap ID")
+	}
+
+	pollInterval := cfg.PollInterval
+	if pollInterval == 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	return &Watcher{
+		ctx:    cfg.Ctx,
+		client: cfg.Client,
+		query: eth.FilterQuery{
+			Addresses: []ethcommon.Address{cfg.Address},
+			Topics: [][]ethcommon.Hash{
+				{NewTopic, ReadyTopic, ClaimedTopic, RefundedTopic},
+				{cfg.SwapID},
+			},
+		},
+		pollInterval: pollInterval,
+		next:         cfg.FromBlock,
+		seen:         make(map[logKey]struct{}),
+		eventCh:      make(chan *Event, 4),
+	}, nil
+}
+
+// Start starts watching the contract. Events are delivered in the order they were emitted on the
+// returned channel, which is closed once the watcher's context is cancelled.
+func (w *Watcher) Start() <-chan *Event {
+	go func() {
+		defer close(w.eventCh)
+		w.run()
+	}()
+
+	return w.eventCh
+}
+
+func (w *Watcher) run() {
+	logCh := make(chan ethtypes.Log)
+	sub, err := w.client.SubscribeFilterLogs(w.ctx, w.query, logCh)
+	if err != nil {
+		log.Debugf("failed to subscribe to contract logs, polling instead: err=%s", err)
+		w.poll()
+		return
+	}
+
+	// catch up on any events emitted before we subscribed; events delivered by both
+	// the subscription and the catch-up are only handled once. If it fails, it's retried
+	// every poll interval until it succeeds.
+	var retryCh <-chan time.Time
+	if err = w.filter(); err != nil {
+		log.Warnf("failed to filter contract logs: err=%s", err)
+		retryCh = time.After(w.pollInterval)
+	}
+
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case err := <-sub.Err():
+			log.Warnf("contract log subscription failed, polling instead: err=%s", err)
+			w.poll()
+			return
+		case <-retryCh:
+			retryCh = nil
+			if err := w.filter(); err != nil {
+				log.Warnf("failed to filter contract logs: err=%s", err)
+				retryCh = time.After(w.pollInterval)
+			}
+		case l := <-logCh:
+			if !w.handleLog(l) {
+				return
+			}
+
+			// until we've caught up, the blocks before the subscription's logs must still be filtered
+			if retryCh == nil && l.BlockNumber >= w.next {
+				w.next = l.BlockNumber + 1
+			}
+		}
+	}
+}
+
+func (w *Watcher) poll() {
+	for {
+		if err := w.filter(); err != nil {
+			log.Warnf("failed to filter contract logs: err=%s", err)
+		}
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// filter delivers the events emitted from the next unfiltered block up to the latest block.
+func (w *Watcher) filter() error {
+	head, err := w.client.BlockNumber(w.ctx)
+	if err != nil {
+		return err
+	}
+
+	if head < w.next {
+		return nil
+	}
+
+	query := w.query
+	query.FromBlock = new(big.Int).SetUint64(w.next)
+	query.ToBlock = new(big.Int).SetUint64(head)
+
+	logs, err := w.client.FilterLogs(w.ctx, query)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range logs {
+		if !w.handleLog(l) {
+			return w.ctx.Err()
+		}
+	}
+
+	w.next = head + 1
+	return nil
+}
+
+// handleLog delivers the event for the given log, if it hasn't been delivered already.
+// It returns false if the watcher's context was cancelled.
+func (w *Watcher) handleLog(l ethtypes.Log) bool {
+	// logs are removed if their block is re-organized out of the chain
+	if l.Removed {
+		return true
+	}
+
+	key := logKey{txHash: l.TxHash, index: l.Index}
+	if _, has := w.seen[key]; has {
+		return true
+	}
+
+	ev, err := newEvent(l)
+	if err != nil {
+		log.Warnf("failed to parse contract log: tx=%s err=%s", l.TxHash, err)
+		return true
+	}
+
+	w.seen[key] = struct{}{}
+
+	select {
+	case <-w.ctx.Done():
+		return false
+	case w.eventCh <- ev:
+		return true
+	}
+}
+
+func newEvent(l ethtypes.Log) (*Event, error) {
+	if len(l.Topics) < 2 {
+		return nil, errors.New("log is missing topics")
+	}
+
+	ev := &Event{
+		SwapID: l.Topics[1],
+		Log:    l,
+	}
+
+	var err error
+	switch l.Topics[0] {
+	case NewTopic:
+		ev.Type = EventNew
+	case ReadyTopic:
+		ev.Type = EventReady
+	case ClaimedTopic:
+		ev.Type = EventClaimed
+		ev.Secret, err = GetSecretFromLog(&l, EventClaimed.String())
+	case RefundedTopic:
+		ev.Type = EventRefunded
+		ev.Secret, err = GetSecretFromLog(&l, EventRefunded.String())
+	default:
+		return nil, fmt.Errorf("unknown event topic %s", l.Topics[0])
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret from %s log: %w", ev.Type, err)
+	}
+
+	return ev, nil
+}
@@ -0,0 +1,258 @@
+package swap
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/stretchr/testify/require"
+
+	"github.com/noot/atomic-swap/common"
+	"github.com/noot/atomic-swap/dleq"
+)
+
+// mockWatcherClient is a WatcherClient which doesn't support subscriptions, so that the
+// watcher polls it for logs.
+type mockWatcherClient struct {
+	sync.Mutex
+	head uint64
+	logs []ethtypes.Log
+}
+
+func (c *mockWatcherClient) BlockNumber(_ context.Context) (uint64, error) {
+	c.Lock()
+	defer c.Unlock()
+	return c.head, nil
+}
+
+func (c *mockWatcherClient) FilterLogs(_ context.Context, q eth.FilterQuery) ([]ethtypes.Log, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	var logs []ethtypes.Log
+	for _, l := range c.logs {
+		if l.BlockNumber < q.FromBlock.Uint64() || l.BlockNumber > q.ToBlock.Uint64() {
+			continue
+		}
+
+		logs = append(logs, l)
+	}
+
+	return logs, nil
+}
+
+func (c *mockWatcherClient) SubscribeFilterLogs(_ context.Context, _ eth.FilterQuery,
+	_ chan<- ethtypes.Log) (eth.Subscription, error) {
+	return nil, errors.New("notifications not supported")
+}
+
+func (c *mockWatcherClient) addLog(l ethtypes.Log) {
+	c.Lock()
+	defer c.Unlock()
+	c.head = l.BlockNumber
+	c.logs = append(c.logs, l)
+}
+
+// mockSubscribingClient is a WatcherClient which supports subscriptions, and fails to filter
+// logs the given number of times.
+type mockSubscribingClient struct {
+	*mockWatcherClient
+	failFilters int
+	subLogs     []ethtypes.Log
+}
+
+func (c *mockSubscribingClient) FilterLogs(ctx context.Context, q eth.FilterQuery) ([]ethtypes.Log, error) {
+	c.Lock()
+	if c.failFilters > 0 {
+		c.failFilters--
+		c.Unlock()
+		return nil, errors.New("failed to filter logs")
+	}
+
+	c.Unlock()
+	return c.mockWatcherClient.FilterLogs(ctx, q)
+}
+
+func (c *mockSubscribingClient) SubscribeFilterLogs(_ context.Context, _ eth.FilterQuery,
+	ch chan<- ethtypes.Log) (eth.Subscription, error) {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		for _, l := range c.subLogs {
+			select {
+			case ch <- l:
+			case <-quit:
+				return nil
+			}
+		}
+
+		<-quit
+		return nil
+	}), nil
+}
+
+func receiveEvent(t *testing.T, events <-chan *Event) *Event {
+	select {
+	case ev := <-events:
+		require.NotNil(t, ev)
+		return ev
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for event")
+		return nil
+	}
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	proof, err := (&dleq.GoDLEq{}).Prove()
+	require.NoError(t, err)
+	secret := proof.Secret()
+	var s [32]byte
+	copy(s[:], common.Reverse(secret[:]))
+
+	id := ethcommon.Hash{9}
+	client := &mockWatcherClient{}
+	client.addLog(ethtypes.Log{
+		Topics:      []ethcommon.Hash{NewTopic, id},
+		BlockNumber: 1,
+		Index:       0,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w, err := NewWatcher(&WatcherConfig{
+		Ctx:          ctx,
+		Client:       client,
+		SwapID:       id,
+		PollInterval: time.Millisecond * 50,
+	})
+	require.NoError(t, err)
+	events := w.Start()
+
+	// events emitted before the watcher started are delivered
+	ev := receiveEvent(t, events)
+	require.Equal(t, EventNew, ev.Type)
+	require.Equal(t, id, ev.SwapID)
+	require.Nil(t, ev.Secret)
+
+	client.addLog(ethtypes.Log{
+		Topics:      []ethcommon.Hash{ReadyTopic, id},
+		BlockNumber: 2,
+		TxHash:      ethcommon.Hash{2},
+	})
+	ev = receiveEvent(t, events)
+	require.Equal(t, EventReady, ev.Type)
+
+	client.addLog(ethtypes.Log{
+		Topics:      []ethcommon.Hash{ClaimedTopic, id},
+		Data:        s[:],
+		BlockNumber: 3,
+		TxHash:      ethcommon.Hash{3},
+	})
+	ev = receiveEvent(t, events)
+	require.Equal(t, EventClaimed, ev.Type)
+	require.NotNil(t, ev.Secret)
+	expected := proof.Secret()
+	require.Equal(t, expected[:], ev.Secret.Bytes())
+
+	// the channel is closed once the watcher is stopped
+	cancel()
+	select {
+	case _, ok := <-events:
+		require.False(t, ok)
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for watcher to stop")
+	}
+}
+
+func TestWatcher_Subscribe_RetriesCatchUp(t *testing.T) {
+	id := ethcommon.Hash{9}
+	client := &mockSubscribingClient{
+		mockWatcherClient: &mockWatcherClient{},
+		failFilters:       2,
+		subLogs: []ethtypes.Log{{
+			Topics:      []ethcommon.Hash{ReadyTopic, id},
+			BlockNumber: 2,
+			TxHash:      ethcommon.Hash{2},
+		}},
+	}
+	client.addLog(ethtypes.Log{
+		Topics:      []ethcommon.Hash{NewTopic, id},
+		BlockNumber: 1,
+		TxHash:      ethcommon.Hash{1},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w, err := NewWatcher(&WatcherConfig{
+		Ctx:          ctx,
+		Client:       client,
+		SwapID:       id,
+		PollInterval: time.Millisecond * 50,
+	})
+	require.NoError(t, err)
+	events := w.Start()
+
+	// the subscription's event is delivered first, and the event emitted before we subscribed
+	// once the catch-up succeeds
+	ev := receiveEvent(t, events)
+	require.Equal(t, EventReady, ev.Type)
+	ev = receiveEvent(t, events)
+	require.Equal(t, EventNew, ev.Type)
+}
+
+func TestWatcher_Contract(t *testing.T) {
+	proof, err := (&dleq.GoDLEq{}).Prove()
+	require.NoError(t, err)
+	res, err := (&dleq.GoDLEq{}).Verify(proof)
+	require.NoError(t, err)
+	cmt := res.Secp256k1PublicKey().Keccak256()
+
+	auth, conn, pkA := setupAliceAuth(t)
+	addr := crypto.PubkeyToAddress(pkA.PublicKey)
+	address, contract := deploySwapFactory(t, auth, conn)
+	id := newEtherSwap(t, auth, conn, address, contract, cmt, [32]byte{}, addr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w, err := NewWatcher(&WatcherConfig{
+		Ctx:          ctx,
+		Client:       conn,
+		Address:      address,
+		SwapID:       id,
+		PollInterval: time.Millisecond * 100,
+	})
+	require.NoError(t, err)
+	events := w.Start()
+
+	ev := receiveEvent(t, events)
+	require.Equal(t, EventNew, ev.Type)
+	require.Equal(t, ethcommon.Hash(id), ev.SwapID)
+
+	_, err = contract.SetReady(auth, id)
+	require.NoError(t, err)
+	ev = receiveEvent(t, events)
+	require.Equal(t, EventReady, ev.Type)
+
+	secret := proof.Secret()
+	var s [32]byte
+	copy(s[:], common.Reverse(secret[:]))
+	_, err = contract.Claim(auth, id, s)
+	require.NoError(t, err)
+	ev = receiveEvent(t, events)
+	require.Equal(t, EventClaimed, ev.Type)
+	expected := proof.Secret()
+	require.Equal(t, expected[:], ev.Secret.Bytes())
+
+	info, err := contract.Swaps(&bind.CallOpts{}, id)
+	require.NoError(t, err)
+	require.Equal(t, StageCompleted, info.Stage)
+}
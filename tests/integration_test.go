@@ -12,6 +12,7 @@ import (
 	"github.com/noot/atomic-swap/cmd/client/client"
 	"github.com/noot/atomic-swap/common"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -21,8 +22,8 @@ const (
 	defaultBobDaemonEndpoint   = "http://localhost:5002"
 	defaultDiscoverTimeout     = 2 // 2 seconds
 
-	bobProvideAmount = float64(44.4)
-	exchangeRate     = float64(0.05)
+	bobProvideAmount = "44.4"
+	exchangeRate     = "0.05"
 )
 
 func TestMain(m *testing.M) {
@@ -149,7 +150,8 @@ func TestStartCharlie(t *testing.T) {
 func TestAlice_Discover(t *testing.T) {
 	startNodes(t)
 	bc := client.NewClient(defaultBobDaemonEndpoint)
-	_, err := bc.MakeOffer(bobProvideAmount, bobProvideAmount, exchangeRate)
+	_, err := bc.MakeOffer(common.ProvidesXMR, ethcommon.Address{}, common.MustNewAmount(bobProvideAmount),
+		common.MustNewAmount(bobProvideAmount), common.MustNewExchangeRate(exchangeRate), 0)
 	require.NoError(t, err)
 
 	c := client.NewClient(defaultAliceDaemonEndpoint)
@@ -170,7 +172,8 @@ func TestBob_Discover(t *testing.T) {
 func TestAlice_Query(t *testing.T) {
 	startNodes(t)
 	bc := client.NewClient(defaultBobDaemonEndpoint)
-	_, err := bc.MakeOffer(bobProvideAmount, bobProvideAmount, exchangeRate)
+	_, err := bc.MakeOffer(common.ProvidesXMR, ethcommon.Address{}, common.MustNewAmount(bobProvideAmount),
+		common.MustNewAmount(bobProvideAmount), common.MustNewExchangeRate(exchangeRate), 0)
 	require.NoError(t, err)
 
 	c := client.NewClient(defaultAliceDaemonEndpoint)
@@ -183,16 +186,17 @@ func TestAlice_Query(t *testing.T) {
 	resp, err := c.Query(providers[0][0])
 	require.NoError(t, err)
 	require.Equal(t, 1, len(resp.Offers))
-	require.Equal(t, bobProvideAmount, resp.Offers[0].MinimumAmount)
-	require.Equal(t, bobProvideAmount, resp.Offers[0].MaximumAmount)
-	require.Equal(t, exchangeRate, float64(resp.Offers[0].ExchangeRate))
+	require.Equal(t, bobProvideAmount, resp.Offers[0].MinimumAmount.String())
+	require.Equal(t, bobProvideAmount, resp.Offers[0].MaximumAmount.String())
+	require.Equal(t, exchangeRate, resp.Offers[0].ExchangeRate.String())
 }
 
 func TestAlice_TakeOffer(t *testing.T) {
 	startNodes(t)
 
 	bc := client.NewClient(defaultBobDaemonEndpoint)
-	offerID, err := bc.MakeOffer(0.1, bobProvideAmount, exchangeRate)
+	offerID, err := bc.MakeOffer(common.ProvidesXMR, ethcommon.Address{}, common.MustNewAmount("0.1"),
+		common.MustNewAmount(bobProvideAmount), common.MustNewExchangeRate(exchangeRate), 0)
 	require.NoError(t, err)
 
 	c := client.NewClient(defaultAliceDaemonEndpoint)
@@ -202,7 +206,7 @@ func TestAlice_TakeOffer(t *testing.T) {
 	require.Equal(t, 1, len(providers))
 	require.GreaterOrEqual(t, len(providers[0]), 2)
 
-	id, err := c.TakeOffer(providers[0][0], offerID, 0.1)
+	id, err := c.TakeOffer(providers[0][0], offerID, common.MustNewAmount("0.1"))
 	require.NoError(t, err)
 	require.Equal(t, uint64(0), id)
 }